@@ -0,0 +1,1104 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: comet/v1/message.proto
+
+package comet
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 二进制帧(子协议 protobuf)的消息信封，字段与 JSON 帧 {"ackid","event","payload","event_id"} 一一对应
+type Envelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event   string `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Ackid   string `protobuf:"bytes,2,opt,name=ackid,proto3" json:"ackid,omitempty"`
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Types that are assignable to Payload:
+	//	*Envelope_Json
+	//	*Envelope_Message
+	//	*Envelope_MessageRevoke
+	//	*Envelope_MessageKeyboard
+	//	*Envelope_ContactApply
+	//	*Envelope_ContactStatus
+	//	*Envelope_GroupApply
+	Payload isEnvelope_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Envelope) GetAckid() string {
+	if x != nil {
+		return x.Ackid
+	}
+	return ""
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (m *Envelope) GetPayload() isEnvelope_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetJson() []byte {
+	if x, ok := x.GetPayload().(*Envelope_Json); ok {
+		return x.Json
+	}
+	return nil
+}
+
+func (x *Envelope) GetMessage() *ImMessagePayload {
+	if x, ok := x.GetPayload().(*Envelope_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *Envelope) GetMessageRevoke() *ImMessageRevokePayload {
+	if x, ok := x.GetPayload().(*Envelope_MessageRevoke); ok {
+		return x.MessageRevoke
+	}
+	return nil
+}
+
+func (x *Envelope) GetMessageKeyboard() *ImMessageKeyboardPayload {
+	if x, ok := x.GetPayload().(*Envelope_MessageKeyboard); ok {
+		return x.MessageKeyboard
+	}
+	return nil
+}
+
+func (x *Envelope) GetContactApply() *ImContactApplyPayload {
+	if x, ok := x.GetPayload().(*Envelope_ContactApply); ok {
+		return x.ContactApply
+	}
+	return nil
+}
+
+func (x *Envelope) GetContactStatus() *ImContactStatusPayload {
+	if x, ok := x.GetPayload().(*Envelope_ContactStatus); ok {
+		return x.ContactStatus
+	}
+	return nil
+}
+
+func (x *Envelope) GetGroupApply() *ImGroupApplyPayload {
+	if x, ok := x.GetPayload().(*Envelope_GroupApply); ok {
+		return x.GroupApply
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_Json struct {
+	// 未定义 protobuf 结构的事件，内容为 JSON 编码的 payload
+	Json []byte `protobuf:"bytes,10,opt,name=json,proto3,oneof"`
+}
+
+type Envelope_Message struct {
+	// im.message
+	Message *ImMessagePayload `protobuf:"bytes,11,opt,name=message,proto3,oneof"`
+}
+
+type Envelope_MessageRevoke struct {
+	// im.message.revoke
+	MessageRevoke *ImMessageRevokePayload `protobuf:"bytes,12,opt,name=message_revoke,json=messageRevoke,proto3,oneof"`
+}
+
+type Envelope_MessageKeyboard struct {
+	// im.message.keyboard
+	MessageKeyboard *ImMessageKeyboardPayload `protobuf:"bytes,13,opt,name=message_keyboard,json=messageKeyboard,proto3,oneof"`
+}
+
+type Envelope_ContactApply struct {
+	// im.contact.apply
+	ContactApply *ImContactApplyPayload `protobuf:"bytes,14,opt,name=contact_apply,json=contactApply,proto3,oneof"`
+}
+
+type Envelope_ContactStatus struct {
+	// im.contact.status
+	ContactStatus *ImContactStatusPayload `protobuf:"bytes,15,opt,name=contact_status,json=contactStatus,proto3,oneof"`
+}
+
+type Envelope_GroupApply struct {
+	// im.group.apply
+	GroupApply *ImGroupApplyPayload `protobuf:"bytes,16,opt,name=group_apply,json=groupApply,proto3,oneof"`
+}
+
+func (*Envelope_Json) isEnvelope_Payload() {}
+
+func (*Envelope_Message) isEnvelope_Payload() {}
+
+func (*Envelope_MessageRevoke) isEnvelope_Payload() {}
+
+func (*Envelope_MessageKeyboard) isEnvelope_Payload() {}
+
+func (*Envelope_ContactApply) isEnvelope_Payload() {}
+
+func (*Envelope_ContactStatus) isEnvelope_Payload() {}
+
+func (*Envelope_GroupApply) isEnvelope_Payload() {}
+
+type ImMessagePayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TalkMode int32          `protobuf:"varint,1,opt,name=talk_mode,json=talkMode,proto3" json:"talk_mode,omitempty"`
+	FromId   int32          `protobuf:"varint,2,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToFromId int32          `protobuf:"varint,3,opt,name=to_from_id,json=toFromId,proto3" json:"to_from_id,omitempty"`
+	Body     *ImMessageBody `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *ImMessagePayload) Reset() {
+	*x = ImMessagePayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImMessagePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImMessagePayload) ProtoMessage() {}
+
+func (x *ImMessagePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImMessagePayload.ProtoReflect.Descriptor instead.
+func (*ImMessagePayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImMessagePayload) GetTalkMode() int32 {
+	if x != nil {
+		return x.TalkMode
+	}
+	return 0
+}
+
+func (x *ImMessagePayload) GetFromId() int32 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *ImMessagePayload) GetToFromId() int32 {
+	if x != nil {
+		return x.ToFromId
+	}
+	return 0
+}
+
+func (x *ImMessagePayload) GetBody() *ImMessageBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type ImMessageBody struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgId          string          `protobuf:"bytes,1,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	Sequence       int64           `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	MsgType        int32           `protobuf:"varint,3,opt,name=msg_type,json=msgType,proto3" json:"msg_type,omitempty"`
+	FromId         int32           `protobuf:"varint,4,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	Nickname       string          `protobuf:"bytes,5,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Avatar         string          `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	IsRevoked      int32           `protobuf:"varint,7,opt,name=is_revoked,json=isRevoked,proto3" json:"is_revoked,omitempty"`
+	IsEdited       int32           `protobuf:"varint,8,opt,name=is_edited,json=isEdited,proto3" json:"is_edited,omitempty"`
+	SendTime       string          `protobuf:"bytes,9,opt,name=send_time,json=sendTime,proto3" json:"send_time,omitempty"`
+	Extra          *structpb.Value `protobuf:"bytes,10,opt,name=extra,proto3" json:"extra,omitempty"`
+	Quote          *structpb.Value `protobuf:"bytes,11,opt,name=quote,proto3" json:"quote,omitempty"`
+	Reactions      *structpb.Value `protobuf:"bytes,12,opt,name=reactions,proto3" json:"reactions,omitempty"`
+	ThreadId       string          `protobuf:"bytes,13,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	Thread         *structpb.Value `protobuf:"bytes,14,opt,name=thread,proto3" json:"thread,omitempty"`
+	DeliveryStatus int32           `protobuf:"varint,15,opt,name=delivery_status,json=deliveryStatus,proto3" json:"delivery_status,omitempty"`
+	IsUrgent       bool            `protobuf:"varint,16,opt,name=is_urgent,json=isUrgent,proto3" json:"is_urgent,omitempty"`
+	Urgent         *structpb.Value `protobuf:"bytes,17,opt,name=urgent,proto3" json:"urgent,omitempty"`
+}
+
+func (x *ImMessageBody) Reset() {
+	*x = ImMessageBody{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImMessageBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImMessageBody) ProtoMessage() {}
+
+func (x *ImMessageBody) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImMessageBody.ProtoReflect.Descriptor instead.
+func (*ImMessageBody) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImMessageBody) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *ImMessageBody) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetMsgType() int32 {
+	if x != nil {
+		return x.MsgType
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetFromId() int32 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ImMessageBody) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *ImMessageBody) GetIsRevoked() int32 {
+	if x != nil {
+		return x.IsRevoked
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetIsEdited() int32 {
+	if x != nil {
+		return x.IsEdited
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetSendTime() string {
+	if x != nil {
+		return x.SendTime
+	}
+	return ""
+}
+
+func (x *ImMessageBody) GetExtra() *structpb.Value {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+func (x *ImMessageBody) GetQuote() *structpb.Value {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *ImMessageBody) GetReactions() *structpb.Value {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ImMessageBody) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *ImMessageBody) GetThread() *structpb.Value {
+	if x != nil {
+		return x.Thread
+	}
+	return nil
+}
+
+func (x *ImMessageBody) GetDeliveryStatus() int32 {
+	if x != nil {
+		return x.DeliveryStatus
+	}
+	return 0
+}
+
+func (x *ImMessageBody) GetIsUrgent() bool {
+	if x != nil {
+		return x.IsUrgent
+	}
+	return false
+}
+
+func (x *ImMessageBody) GetUrgent() *structpb.Value {
+	if x != nil {
+		return x.Urgent
+	}
+	return nil
+}
+
+type ImMessageRevokePayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TalkMode int32  `protobuf:"varint,1,opt,name=talk_mode,json=talkMode,proto3" json:"talk_mode,omitempty"`
+	FromId   int32  `protobuf:"varint,2,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToFromId int32  `protobuf:"varint,3,opt,name=to_from_id,json=toFromId,proto3" json:"to_from_id,omitempty"`
+	MsgId    string `protobuf:"bytes,4,opt,name=msg_id,json=msgId,proto3" json:"msg_id,omitempty"`
+	Remark   string `protobuf:"bytes,5,opt,name=remark,proto3" json:"remark,omitempty"`
+}
+
+func (x *ImMessageRevokePayload) Reset() {
+	*x = ImMessageRevokePayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImMessageRevokePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImMessageRevokePayload) ProtoMessage() {}
+
+func (x *ImMessageRevokePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImMessageRevokePayload.ProtoReflect.Descriptor instead.
+func (*ImMessageRevokePayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImMessageRevokePayload) GetTalkMode() int32 {
+	if x != nil {
+		return x.TalkMode
+	}
+	return 0
+}
+
+func (x *ImMessageRevokePayload) GetFromId() int32 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *ImMessageRevokePayload) GetToFromId() int32 {
+	if x != nil {
+		return x.ToFromId
+	}
+	return 0
+}
+
+func (x *ImMessageRevokePayload) GetMsgId() string {
+	if x != nil {
+		return x.MsgId
+	}
+	return ""
+}
+
+func (x *ImMessageRevokePayload) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+type ImMessageKeyboardPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromId   int32 `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	ToFromId int32 `protobuf:"varint,2,opt,name=to_from_id,json=toFromId,proto3" json:"to_from_id,omitempty"`
+}
+
+func (x *ImMessageKeyboardPayload) Reset() {
+	*x = ImMessageKeyboardPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImMessageKeyboardPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImMessageKeyboardPayload) ProtoMessage() {}
+
+func (x *ImMessageKeyboardPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImMessageKeyboardPayload.ProtoReflect.Descriptor instead.
+func (*ImMessageKeyboardPayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImMessageKeyboardPayload) GetFromId() int32 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *ImMessageKeyboardPayload) GetToFromId() int32 {
+	if x != nil {
+		return x.ToFromId
+	}
+	return 0
+}
+
+type ImContactApplyPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    int32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Nickname  string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Remark    string `protobuf:"bytes,3,opt,name=remark,proto3" json:"remark,omitempty"`
+	ApplyTime string `protobuf:"bytes,4,opt,name=apply_time,json=applyTime,proto3" json:"apply_time,omitempty"`
+}
+
+func (x *ImContactApplyPayload) Reset() {
+	*x = ImContactApplyPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImContactApplyPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImContactApplyPayload) ProtoMessage() {}
+
+func (x *ImContactApplyPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImContactApplyPayload.ProtoReflect.Descriptor instead.
+func (*ImContactApplyPayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ImContactApplyPayload) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImContactApplyPayload) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ImContactApplyPayload) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *ImContactApplyPayload) GetApplyTime() string {
+	if x != nil {
+		return x.ApplyTime
+	}
+	return ""
+}
+
+type ImContactStatusPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ImContactStatusPayload) Reset() {
+	*x = ImContactStatusPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImContactStatusPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImContactStatusPayload) ProtoMessage() {}
+
+func (x *ImContactStatusPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImContactStatusPayload.ProtoReflect.Descriptor instead.
+func (*ImContactStatusPayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImContactStatusPayload) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ImContactStatusPayload) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ImGroupApplyPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupId   int32  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName string `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	UserId    int32  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Nickname  string `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Remark    string `protobuf:"bytes,5,opt,name=remark,proto3" json:"remark,omitempty"`
+	ApplyTime string `protobuf:"bytes,6,opt,name=apply_time,json=applyTime,proto3" json:"apply_time,omitempty"`
+}
+
+func (x *ImGroupApplyPayload) Reset() {
+	*x = ImGroupApplyPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comet_v1_message_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImGroupApplyPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImGroupApplyPayload) ProtoMessage() {}
+
+func (x *ImGroupApplyPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_comet_v1_message_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImGroupApplyPayload.ProtoReflect.Descriptor instead.
+func (*ImGroupApplyPayload) Descriptor() ([]byte, []int) {
+	return file_comet_v1_message_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ImGroupApplyPayload) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ImGroupApplyPayload) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *ImGroupApplyPayload) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImGroupApplyPayload) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *ImGroupApplyPayload) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *ImGroupApplyPayload) GetApplyTime() string {
+	if x != nil {
+		return x.ApplyTime
+	}
+	return ""
+}
+
+var File_comet_v1_message_proto protoreflect.FileDescriptor
+
+var file_comet_v1_message_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x1a,
+	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89, 0x04,
+	0x0a, 0x08, 0x45, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x6b, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x63, 0x6b, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x48,
+	0x00, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
+	0x2e, 0x49, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x46, 0x0a, 0x0e,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x49, 0x6d, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x49, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x4b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48,
+	0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4b, 0x65, 0x79, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x12, 0x43, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x5f, 0x61, 0x70,
+	0x70, 0x6c, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6d, 0x65,
+	0x74, 0x2e, 0x49, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79,
+	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x63, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x49, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00,
+	0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x3d, 0x0a, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x49, 0x6d, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x48, 0x00, 0x52, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x90, 0x01, 0x0a, 0x10, 0x49, 0x6d,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x61, 0x6c, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x74, 0x61, 0x6c, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x72,
+	0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x6f, 0x46, 0x72, 0x6f, 0x6d,
+	0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x2e, 0x49, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xd8, 0x04, 0x0a,
+	0x0d, 0x49, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x15,
+	0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66,
+	0x72, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f,
+	0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69,
+	0x73, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x65,
+	0x64, 0x69, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x73, 0x45,
+	0x64, 0x69, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x12, 0x2c, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x34,
+	0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49,
+	0x64, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73,
+	0x5f, 0x75, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69,
+	0x73, 0x55, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x75, 0x72, 0x67, 0x65, 0x6e,
+	0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x06, 0x75, 0x72, 0x67, 0x65, 0x6e, 0x74, 0x22, 0x9b, 0x01, 0x0a, 0x16, 0x49, 0x6d, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x6c, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x61, 0x6c, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x6f,
+	0x46, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x6d, 0x61, 0x72, 0x6b, 0x22, 0x51, 0x0a, 0x18, 0x49, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x4b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x74, 0x6f,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x74, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x49, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x15, 0x49, 0x6d, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e,
+	0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x61, 0x72,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x61, 0x72, 0x6b, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x49,
+	0x0a, 0x16, 0x49, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xbb, 0x01, 0x0a, 0x13, 0x49, 0x6d,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x6c,
+	0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70,
+	0x70, 0x6c, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x10, 0x5a, 0x0e, 0x63, 0x6f, 0x6d, 0x65, 0x74,
+	0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6d, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_comet_v1_message_proto_rawDescOnce sync.Once
+	file_comet_v1_message_proto_rawDescData = file_comet_v1_message_proto_rawDesc
+)
+
+func file_comet_v1_message_proto_rawDescGZIP() []byte {
+	file_comet_v1_message_proto_rawDescOnce.Do(func() {
+		file_comet_v1_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_comet_v1_message_proto_rawDescData)
+	})
+	return file_comet_v1_message_proto_rawDescData
+}
+
+var file_comet_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_comet_v1_message_proto_goTypes = []any{
+	(*Envelope)(nil),                 // 0: comet.Envelope
+	(*ImMessagePayload)(nil),         // 1: comet.ImMessagePayload
+	(*ImMessageBody)(nil),            // 2: comet.ImMessageBody
+	(*ImMessageRevokePayload)(nil),   // 3: comet.ImMessageRevokePayload
+	(*ImMessageKeyboardPayload)(nil), // 4: comet.ImMessageKeyboardPayload
+	(*ImContactApplyPayload)(nil),    // 5: comet.ImContactApplyPayload
+	(*ImContactStatusPayload)(nil),   // 6: comet.ImContactStatusPayload
+	(*ImGroupApplyPayload)(nil),      // 7: comet.ImGroupApplyPayload
+	(*structpb.Value)(nil),           // 8: google.protobuf.Value
+}
+var file_comet_v1_message_proto_depIdxs = []int32{
+	1,  // 0: comet.Envelope.message:type_name -> comet.ImMessagePayload
+	3,  // 1: comet.Envelope.message_revoke:type_name -> comet.ImMessageRevokePayload
+	4,  // 2: comet.Envelope.message_keyboard:type_name -> comet.ImMessageKeyboardPayload
+	5,  // 3: comet.Envelope.contact_apply:type_name -> comet.ImContactApplyPayload
+	6,  // 4: comet.Envelope.contact_status:type_name -> comet.ImContactStatusPayload
+	7,  // 5: comet.Envelope.group_apply:type_name -> comet.ImGroupApplyPayload
+	2,  // 6: comet.ImMessagePayload.body:type_name -> comet.ImMessageBody
+	8,  // 7: comet.ImMessageBody.extra:type_name -> google.protobuf.Value
+	8,  // 8: comet.ImMessageBody.quote:type_name -> google.protobuf.Value
+	8,  // 9: comet.ImMessageBody.reactions:type_name -> google.protobuf.Value
+	8,  // 10: comet.ImMessageBody.thread:type_name -> google.protobuf.Value
+	8,  // 11: comet.ImMessageBody.urgent:type_name -> google.protobuf.Value
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_comet_v1_message_proto_init() }
+func file_comet_v1_message_proto_init() {
+	if File_comet_v1_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_comet_v1_message_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Envelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ImMessagePayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ImMessageBody); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ImMessageRevokePayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ImMessageKeyboardPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ImContactApplyPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ImContactStatusPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comet_v1_message_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ImGroupApplyPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_comet_v1_message_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_Json)(nil),
+		(*Envelope_Message)(nil),
+		(*Envelope_MessageRevoke)(nil),
+		(*Envelope_MessageKeyboard)(nil),
+		(*Envelope_ContactApply)(nil),
+		(*Envelope_ContactStatus)(nil),
+		(*Envelope_GroupApply)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_comet_v1_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_comet_v1_message_proto_goTypes,
+		DependencyIndexes: file_comet_v1_message_proto_depIdxs,
+		MessageInfos:      file_comet_v1_message_proto_msgTypes,
+	}.Build()
+	File_comet_v1_message_proto = out.File
+	file_comet_v1_message_proto_rawDesc = nil
+	file_comet_v1_message_proto_goTypes = nil
+	file_comet_v1_message_proto_depIdxs = nil
+}
@@ -68,6 +68,7 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		SmsService:  smsService,
 		UserService: userService,
 	}
+	iBus := provider.NewBus(conf, client)
 	jwtTokenStorage := cache.NewTokenSessionStorage(client)
 	redisLock := cache.NewRedisLock(client)
 	robot := repo.NewRobot(db)
@@ -80,7 +81,7 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 	iRsa := provider.NewRsa(conf)
 	auth := &v1.Auth{
 		Config:              conf,
-		Redis:               client,
+		Bus:                 iBus,
 		JwtTokenStorage:     jwtTokenStorage,
 		RedisLock:           redisLock,
 		RobotRepo:           robot,
@@ -114,23 +115,77 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 	repoContact := repo.NewContact(db, contactRemark, relation)
 	repoGroup := repo.NewGroup(db)
 	groupMember := repo.NewGroupMember(db, relation)
+	talkReadReceipt := repo.NewTalkReadReceipt(db)
+	talkUserMessage := repo.NewTalkRecordFriend(db)
+	talkGroupMessage := repo.NewTalkRecordGroup(db)
+	sequence := cache.NewSequence(client)
+	repoSequence := repo.NewSequence(db, sequence)
+	talkSyncEvent := repo.NewTalkSyncEvent(db, repoSequence)
+	talkMessageMention := repo.NewTalkMessageMention(db)
+	talkMessageUrgent := repo.NewTalkMessageUrgent(db)
+	talkGroupThread := repo.NewTalkGroupThread(db)
+	talkMessageFile := repo.NewTalkMessageFile(db)
+	fileUpload := repo.NewFileUpload(db)
+	vote := cache.NewVote(client)
+	groupVote := repo.NewGroupVote(db, vote)
+	iFilesystem := provider.NewFilesystem(conf)
 	pushMessage := &business.PushMessage{
-		Redis: client,
+		Bus:             iBus,
+		Config:          conf,
+		ClientStorage:   clientStorage,
+		GroupMemberRepo: groupMember,
+	}
+	messageService := &message.Service{
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		SplitUploadRepo:      fileUpload,
+		TalkRecordsVoteRepo:  groupVote,
+		UsersRepo:            users,
+		Filesystem:           iFilesystem,
+		UnreadStorage:        unreadStorage,
+		MessageStorage:       messageStorage,
+		ServerStorage:        serverStorage,
+		ClientStorage:        clientStorage,
+		Sequence:             repoSequence,
+		RobotRepo:            robot,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkUserMessageRepo:  talkUserMessage,
+		TalkGroupMessageRepo: talkGroupMessage,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkMessageFileRepo:  talkMessageFile,
+		PushMessage:          pushMessage,
+		Bus:                  iBus,
 	}
 	talkService := &service.TalkService{
-		Source:          source,
-		GroupMemberRepo: groupMember,
-		UserRepo:        users,
-		PushMessage:     pushMessage,
-		MessageStorage:  messageStorage,
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		UserRepo:             users,
+		TalkReadReceiptRepo:  talkReadReceipt,
+		TalkRecordFriendRepo: talkUserMessage,
+		TalkRecordGroupRepo:  talkGroupMessage,
+		TalkSyncEventRepo:    talkSyncEvent,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkMessageFileRepo:  talkMessageFile,
+		MessageService:       messageService,
+		PushMessage:          pushMessage,
+		MessageStorage:       messageStorage,
+		UnreadStorage:        unreadStorage,
 	}
 	talkSession := repo.NewTalkSession(db)
+	talkDraft := repo.NewTalkDraft(db)
+	draftStorage := cache.NewDraftStorage(client)
 	talkSessionService := &service.TalkSessionService{
-		Source:          source,
-		TalkSessionRepo: talkSession,
+		Source:            source,
+		TalkSessionRepo:   talkSession,
+		TalkDraftRepo:     talkDraft,
+		TalkSyncEventRepo: talkSyncEvent,
+		TalkMentionRepo:   talkMessageMention,
+		DraftStorage:      draftStorage,
+		PushMessage:       pushMessage,
 	}
-	sequence := cache.NewSequence(client)
-	repoSequence := repo.NewSequence(db, sequence)
 	groupService := &service.GroupService{
 		Source:          source,
 		GroupRepo:       repoGroup,
@@ -152,6 +207,15 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 	clientConnectService := &service.ClientConnectService{
 		Storage: clientStorage,
 	}
+	talkMessageTtlService := &service.TalkMessageTtlService{
+		Source:          source,
+		GroupRepo:       repoGroup,
+		GroupMemberRepo: groupMember,
+		UsersRepo:       users,
+		TalkSessionRepo: talkSession,
+		AuthService:     authService,
+		MessageService:  messageService,
+	}
 	session := &talk.Session{
 		RedisLock:            redisLock,
 		MessageStorage:       messageStorage,
@@ -168,26 +232,43 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		AuthService:          authService,
 		ContactService:       contactService,
 		ClientConnectService: clientConnectService,
+		MessageTtlService:    talkMessageTtlService,
 	}
-	iFilesystem := provider.NewFilesystem(conf)
-	talkMessage := &talk.Message{
-		TalkService: talkService,
-		AuthService: authService,
-		Filesystem:  iFilesystem,
-	}
-	talkUserMessage := repo.NewTalkRecordFriend(db)
-	talkGroupMessage := repo.NewTalkRecordGroup(db)
-	vote := cache.NewVote(client)
-	groupVote := repo.NewGroupVote(db, vote)
 	talkGroupMessageDel := repo.NewTalkRecordGroupDel(db)
+	talkMessageEdit := repo.NewTalkMessageEdit(db)
+	talkMessageReaction := repo.NewTalkMessageReaction(db)
 	talkRecordService := &service.TalkRecordService{
-		Source:                source,
-		TalkVoteCache:         vote,
-		TalkRecordsVoteRepo:   groupVote,
-		GroupMemberRepo:       groupMember,
-		TalkRecordFriendRepo:  talkUserMessage,
-		TalkRecordGroupRepo:   talkGroupMessage,
-		TalkRecordsDeleteRepo: talkGroupMessageDel,
+		Source:                  source,
+		TalkVoteCache:           vote,
+		TalkRecordsVoteRepo:     groupVote,
+		GroupMemberRepo:         groupMember,
+		TalkRecordFriendRepo:    talkUserMessage,
+		TalkRecordGroupRepo:     talkGroupMessage,
+		TalkRecordsDeleteRepo:   talkGroupMessageDel,
+		TalkMessageEditRepo:     talkMessageEdit,
+		TalkMessageReactionRepo: talkMessageReaction,
+		TalkGroupThreadRepo:     talkGroupThread,
+		TalkSyncEventRepo:       talkSyncEvent,
+		TalkUrgentRepo:          talkMessageUrgent,
+		UnreadStorage:           unreadStorage,
+	}
+	talkMessagePin := repo.NewTalkMessagePin(db)
+	talkPinService := &service.TalkPinService{
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		UsersRepo:            users,
+		TalkMessagePinRepo:   talkMessagePin,
+		TalkRecordFriendRepo: talkUserMessage,
+		TalkRecordGroupRepo:  talkGroupMessage,
+		MessageService:       messageService,
+		PushMessage:          pushMessage,
+	}
+	talkMessage := &talk.Message{
+		TalkService:        talkService,
+		TalkRecordsService: talkRecordService,
+		TalkPinService:     talkPinService,
+		AuthService:        authService,
+		Filesystem:         iFilesystem,
 	}
 	groupMemberService := &service.GroupMemberService{
 		Source:          source,
@@ -201,6 +282,108 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		GroupMemberService:   groupMemberService,
 		AuthService:          authService,
 		Filesystem:           iFilesystem,
+		UnreadStorage:        unreadStorage,
+	}
+	talkMessageSchedule := repo.NewTalkMessageSchedule(db)
+	talkScheduleService := &service.TalkScheduleService{
+		Source:                  source,
+		TalkMessageScheduleRepo: talkMessageSchedule,
+		AuthService:             authService,
+		MessageService:          messageService,
+	}
+	schedule := &talk.Schedule{
+		AuthService:         authService,
+		TalkScheduleService: talkScheduleService,
+	}
+	talkExport := repo.NewTalkExport(db)
+	talkExportService := &service.TalkExportService{
+		Source:            source,
+		TalkExportRepo:    talkExport,
+		GroupRepo:         repoGroup,
+		GroupMemberRepo:   groupMember,
+		UsersRepo:         users,
+		TalkRecordService: talkRecordService,
+		Filesystem:        iFilesystem,
+		Bus:               iBus,
+	}
+	export := &talk.Export{
+		TalkExportService: talkExportService,
+		Filesystem:        iFilesystem,
+	}
+	talkBroadcast := repo.NewTalkBroadcast(db)
+	talkBroadcastLog := repo.NewTalkBroadcastLog(db)
+	talkBroadcastService := &service.TalkBroadcastService{
+		Source:               source,
+		TalkBroadcastRepo:    talkBroadcast,
+		TalkBroadcastLogRepo: talkBroadcastLog,
+		Sequence:             repoSequence,
+		AuthService:          authService,
+		MessageService:       messageService,
+	}
+	broadcast := &talk.Broadcast{
+		TalkBroadcastService: talkBroadcastService,
+	}
+	talkQuickReply := repo.NewTalkQuickReply(db)
+	talkQuickReplyService := &service.TalkQuickReplyService{
+		Source:             source,
+		TalkQuickReplyRepo: talkQuickReply,
+		OrganizeRepo:       organize,
+		DepartmentRepo:     department,
+		UsersRepo:          users,
+		GroupRepo:          repoGroup,
+		AuthService:        authService,
+		MessageService:     messageService,
+	}
+	quickReply := &talk.QuickReply{
+		TalkQuickReplyService: talkQuickReplyService,
+	}
+	talkUrgentService := &service.TalkUrgentService{
+		Source:              source,
+		TalkUrgentRepo:      talkMessageUrgent,
+		TalkUserMessageRepo: talkUserMessage,
+		GroupMemberRepo:     groupMember,
+		OrganizeRepo:        organize,
+		DepartmentRepo:      department,
+		PositionRepo:        position,
+		PushMessage:         pushMessage,
+	}
+	urgent := &talk.Urgent{
+		TalkUrgentService: talkUrgentService,
+	}
+	talkLiveLocation := repo.NewTalkLiveLocation(db)
+	liveLocationStorage := cache.NewLiveLocationStorage(client)
+	talkLiveLocationService := &service.TalkLiveLocationService{
+		Source:               source,
+		TalkLiveLocationRepo: talkLiveLocation,
+		LiveLocationStorage:  liveLocationStorage,
+		GroupMemberRepo:      groupMember,
+		AuthService:          authService,
+		MessageService:       messageService,
+		PushMessage:          pushMessage,
+	}
+	liveLocation := &talk.LiveLocation{
+		TalkLiveLocationService: talkLiveLocationService,
+	}
+	talkMessageFavorite := repo.NewTalkMessageFavorite(db)
+	talkFavoriteService := &service.TalkFavoriteService{
+		Source:                  source,
+		GroupMemberRepo:         groupMember,
+		TalkRecordFriendRepo:    talkUserMessage,
+		TalkRecordGroupRepo:     talkGroupMessage,
+		TalkMessageFavoriteRepo: talkMessageFavorite,
+		TalkMessageFileRepo:     talkMessageFile,
+		AuthService:             authService,
+		MessageService:          messageService,
+	}
+	favorite := &talk.Favorite{
+		TalkFavoriteService: talkFavoriteService,
+	}
+	talkMentionService := &service.TalkMentionService{
+		Source:          source,
+		TalkMentionRepo: talkMessageMention,
+	}
+	mention := &talk.Mention{
+		TalkMentionService: talkMentionService,
 	}
 	emoticon := repo.NewEmoticon(db)
 	emoticonService := &service.EmoticonService{
@@ -214,7 +397,6 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		EmoticonService: emoticonService,
 		Filesystem:      iFilesystem,
 	}
-	fileUpload := repo.NewFileUpload(db)
 	fileSplitUploadService := &service.FileSplitUploadService{
 		Source:          source,
 		SplitUploadRepo: fileUpload,
@@ -227,21 +409,6 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		SplitUploadService: fileSplitUploadService,
 	}
 	groupNotice := repo.NewGroupNotice(db)
-	messageService := &message.Service{
-		Source:              source,
-		GroupMemberRepo:     groupMember,
-		SplitUploadRepo:     fileUpload,
-		TalkRecordsVoteRepo: groupVote,
-		UsersRepo:           users,
-		Filesystem:          iFilesystem,
-		UnreadStorage:       unreadStorage,
-		MessageStorage:      messageStorage,
-		ServerStorage:       serverStorage,
-		ClientStorage:       clientStorage,
-		Sequence:            repoSequence,
-		RobotRepo:           robot,
-		PushMessage:         pushMessage,
-	}
 	groupGroup := &group.Group{
 		RedisLock:          redisLock,
 		Repo:               source,
@@ -365,31 +532,40 @@ func NewHttpInjector(conf *config.Config) *apis.AppProvider {
 		ArticleTagService: articleTagService,
 	}
 	publish := &talk.Publish{
-		AuthService:    authService,
-		MessageService: messageService,
+		AuthService:       authService,
+		MessageService:    messageService,
+		TalkUrgentService: talkUrgentService,
 	}
 	webV1 := &web.V1{
-		Common:       common,
-		Auth:         auth,
-		User:         user,
-		Organize:     v1Organize,
-		Talk:         session,
-		TalkMessage:  talkMessage,
-		TalkRecords:  records,
-		Emoticon:     v1Emoticon,
-		Upload:       upload,
-		Group:        groupGroup,
-		GroupNotice:  notice,
-		GroupApply:   apply,
-		GroupVote:    vote2,
-		Contact:      contactContact,
-		ContactApply: contactApply,
-		ContactGroup: group2,
-		Article:      articleArticle,
-		ArticleAnnex: annex,
-		ArticleClass: class,
-		ArticleTag:   tag,
-		Message:      publish,
+		Common:           common,
+		Auth:             auth,
+		User:             user,
+		Organize:         v1Organize,
+		Talk:             session,
+		TalkMessage:      talkMessage,
+		TalkRecords:      records,
+		TalkSchedule:     schedule,
+		TalkExport:       export,
+		TalkBroadcast:    broadcast,
+		TalkQuickReply:   quickReply,
+		TalkUrgent:       urgent,
+		TalkLiveLocation: liveLocation,
+		TalkFavorite:     favorite,
+		TalkMention:      mention,
+		Emoticon:         v1Emoticon,
+		Upload:           upload,
+		Group:            groupGroup,
+		GroupNotice:      notice,
+		GroupApply:       apply,
+		GroupVote:        vote2,
+		Contact:          contactContact,
+		ContactApply:     contactApply,
+		ContactGroup:     group2,
+		Article:          articleArticle,
+		ArticleAnnex:     annex,
+		ArticleClass:     class,
+		ArticleTag:       tag,
+		Message:          publish,
 	}
 	webHandler := &web.Handler{
 		V1: webV1,
@@ -449,14 +625,78 @@ func NewCometInjector(conf *config.Config) *comet.AppProvider {
 		Source:          source,
 		GroupMemberRepo: groupMember,
 	}
+	iBus := provider.NewBus(conf, client)
 	pushMessage := &business.PushMessage{
-		Redis: client,
+		Bus:             iBus,
+		Config:          conf,
+		ClientStorage:   clientStorage,
+		GroupMemberRepo: groupMember,
+	}
+	talkLiveLocation := repo.NewTalkLiveLocation(db)
+	liveLocationStorage := cache.NewLiveLocationStorage(client)
+	organize := repo.NewOrganize(db)
+	contactRemark := cache.NewContactRemark(client)
+	repoContact := repo.NewContact(db, contactRemark, relation)
+	repoGroup := repo.NewGroup(db)
+	authService := &service.AuthService{
+		OrganizeRepo:    organize,
+		ContactRepo:     repoContact,
+		GroupRepo:       repoGroup,
+		GroupMemberRepo: groupMember,
+	}
+	fileUpload := repo.NewFileUpload(db)
+	vote := cache.NewVote(client)
+	groupVote := repo.NewGroupVote(db, vote)
+	users := repo.NewUsers(db, client)
+	iFilesystem := provider.NewFilesystem(conf)
+	unreadStorage := cache.NewUnreadStorage(client)
+	messageStorage := cache.NewMessageStorage(client)
+	sequence := cache.NewSequence(client)
+	repoSequence := repo.NewSequence(db, sequence)
+	robot := repo.NewRobot(db)
+	talkGroupThread := repo.NewTalkGroupThread(db)
+	talkUserMessage := repo.NewTalkRecordFriend(db)
+	talkGroupMessage := repo.NewTalkRecordGroup(db)
+	talkMessageMention := repo.NewTalkMessageMention(db)
+	talkMessageUrgent := repo.NewTalkMessageUrgent(db)
+	talkMessageFile := repo.NewTalkMessageFile(db)
+	messageService := &message.Service{
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		SplitUploadRepo:      fileUpload,
+		TalkRecordsVoteRepo:  groupVote,
+		UsersRepo:            users,
+		Filesystem:           iFilesystem,
+		UnreadStorage:        unreadStorage,
+		MessageStorage:       messageStorage,
+		ServerStorage:        serverStorage,
+		ClientStorage:        clientStorage,
+		Sequence:             repoSequence,
+		RobotRepo:            robot,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkUserMessageRepo:  talkUserMessage,
+		TalkGroupMessageRepo: talkGroupMessage,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkMessageFileRepo:  talkMessageFile,
+		PushMessage:          pushMessage,
+		Bus:                  iBus,
+	}
+	talkLiveLocationService := &service.TalkLiveLocationService{
+		Source:               source,
+		TalkLiveLocationRepo: talkLiveLocation,
+		LiveLocationStorage:  liveLocationStorage,
+		GroupMemberRepo:      groupMember,
+		AuthService:          authService,
+		MessageService:       messageService,
+		PushMessage:          pushMessage,
 	}
 	chatHandler := &chat.Handler{
-		Redis:         client,
-		Source:        source,
-		MemberService: groupMemberService,
-		PushMessage:   pushMessage,
+		Redis:                   client,
+		Source:                  source,
+		MemberService:           groupMemberService,
+		PushMessage:             pushMessage,
+		TalkLiveLocationService: talkLiveLocationService,
 	}
 	roomStorage := socket.NewRoomStorage()
 	chatEvent := &event.ChatEvent{
@@ -467,9 +707,11 @@ func NewCometInjector(conf *config.Config) *comet.AppProvider {
 		RoomStorage:     roomStorage,
 		PushMessage:     pushMessage,
 	}
+	replayStorage := cache.NewReplayStorage(client)
 	chatChannel := &handler2.ChatChannel{
 		Storage: clientConnectService,
 		Event:   chatEvent,
+		Replay:  replayStorage,
 	}
 	exampleHandler := example.NewHandler()
 	exampleEvent := &event.ExampleEvent{
@@ -488,57 +730,87 @@ func NewCometInjector(conf *config.Config) *comet.AppProvider {
 	jwtTokenStorage := cache.NewTokenSessionStorage(client)
 	engine := router2.NewRouter(conf, handlerHandler, jwtTokenStorage)
 	healthSubscribe := process.NewHealthSubscribe(serverStorage)
-	organize := repo.NewOrganize(db)
-	users := repo.NewUsers(db, client)
-	vote := cache.NewVote(client)
-	groupVote := repo.NewGroupVote(db, vote)
-	talkUserMessage := repo.NewTalkRecordFriend(db)
-	talkGroupMessage := repo.NewTalkRecordGroup(db)
+	talkMessageReaction := repo.NewTalkMessageReaction(db)
 	talkGroupMessageDel := repo.NewTalkRecordGroupDel(db)
+	talkMessageEdit := repo.NewTalkMessageEdit(db)
+	talkSyncEvent := repo.NewTalkSyncEvent(db, repoSequence)
 	talkRecordService := &service.TalkRecordService{
-		Source:                source,
-		TalkVoteCache:         vote,
-		TalkRecordsVoteRepo:   groupVote,
-		GroupMemberRepo:       groupMember,
-		TalkRecordFriendRepo:  talkUserMessage,
-		TalkRecordGroupRepo:   talkGroupMessage,
-		TalkRecordsDeleteRepo: talkGroupMessageDel,
+		Source:                  source,
+		TalkVoteCache:           vote,
+		TalkRecordsVoteRepo:     groupVote,
+		GroupMemberRepo:         groupMember,
+		TalkRecordFriendRepo:    talkUserMessage,
+		TalkRecordGroupRepo:     talkGroupMessage,
+		TalkRecordsDeleteRepo:   talkGroupMessageDel,
+		TalkMessageEditRepo:     talkMessageEdit,
+		TalkMessageReactionRepo: talkMessageReaction,
+		TalkGroupThreadRepo:     talkGroupThread,
+		TalkSyncEventRepo:       talkSyncEvent,
+		TalkUrgentRepo:          talkMessageUrgent,
+		UnreadStorage:           unreadStorage,
+	}
+	talkReadReceipt := repo.NewTalkReadReceipt(db)
+	talkService := &service.TalkService{
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		UserRepo:             users,
+		TalkReadReceiptRepo:  talkReadReceipt,
+		TalkRecordFriendRepo: talkUserMessage,
+		TalkRecordGroupRepo:  talkGroupMessage,
+		TalkSyncEventRepo:    talkSyncEvent,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkMessageFileRepo:  talkMessageFile,
+		MessageService:       messageService,
+		PushMessage:          pushMessage,
+		MessageStorage:       messageStorage,
+		UnreadStorage:        unreadStorage,
 	}
-	contactRemark := cache.NewContactRemark(client)
-	repoContact := repo.NewContact(db, contactRemark, relation)
 	contactService := &service.ContactService{
 		Source:      source,
 		ContactRepo: repoContact,
 	}
+	liveLocationTimer := chat2.NewLiveLocationTimer(talkLiveLocationService)
 	handler3 := &chat2.Handler{
-		Config:               conf,
-		OrganizeRepo:         organize,
-		UserRepo:             users,
-		Source:               source,
-		TalkRecordsService:   talkRecordService,
-		ContactService:       contactService,
-		ClientConnectService: clientConnectService,
-		RoomStorage:          roomStorage,
+		Config:                  conf,
+		OrganizeRepo:            organize,
+		UserRepo:                users,
+		TalkMessageReactionRepo: talkMessageReaction,
+		Source:                  source,
+		TalkRecordsService:      talkRecordService,
+		TalkService:             talkService,
+		ContactService:          contactService,
+		ClientConnectService:    clientConnectService,
+		RoomStorage:             roomStorage,
+		LiveLocationTimer:       liveLocationTimer,
 	}
 	chatSubscribe := consume.NewChatSubscribe(handler3)
 	handler4 := example2.NewHandler()
 	exampleSubscribe := consume.NewExampleSubscribe(handler4)
-	messageSubscribe := process.NewMessageSubscribe(client, chatSubscribe, exampleSubscribe)
+	messageSubscribe := process.NewMessageSubscribe(iBus, chatSubscribe, exampleSubscribe)
 	subServers := &process.SubServers{
-		HealthSubscribe:  healthSubscribe,
-		MessageSubscribe: messageSubscribe,
+		HealthSubscribe:   healthSubscribe,
+		MessageSubscribe:  messageSubscribe,
+		LiveLocationTimer: liveLocationTimer,
 	}
 	server := process.NewServer(subServers)
 	emailClient := provider.NewEmailClient(conf)
 	providers := &provider.Providers{
 		EmailClient: emailClient,
 	}
+	tcpServer := &comet.TcpServer{
+		Config:  conf,
+		Handler: handlerHandler,
+		Storage: jwtTokenStorage,
+	}
 	appProvider := &comet.AppProvider{
 		Config:    conf,
 		Engine:    engine,
 		Coroutine: server,
 		Handler:   handlerHandler,
 		Providers: providers,
+		TcpServer: tcpServer,
 	}
 	return appProvider
 }
@@ -562,11 +834,135 @@ func NewCronInjector(conf *config.Config) *mission.CronProvider {
 	clearExpireServer := &cron.ClearExpireServer{
 		Storage: serverStorage,
 	}
+	source := repo.NewSource(db, client)
+	talkMessageSchedule := repo.NewTalkMessageSchedule(db)
+	organize := repo.NewOrganize(db)
+	contactRemark := cache.NewContactRemark(client)
+	relation := cache.NewRelation(client)
+	repoContact := repo.NewContact(db, contactRemark, relation)
+	repoGroup := repo.NewGroup(db)
+	groupMember := repo.NewGroupMember(db, relation)
+	authService := &service.AuthService{
+		OrganizeRepo:    organize,
+		ContactRepo:     repoContact,
+		GroupRepo:       repoGroup,
+		GroupMemberRepo: groupMember,
+	}
+	fileUpload := repo.NewFileUpload(db)
+	vote := cache.NewVote(client)
+	groupVote := repo.NewGroupVote(db, vote)
+	users := repo.NewUsers(db, client)
+	unreadStorage := cache.NewUnreadStorage(client)
+	messageStorage := cache.NewMessageStorage(client)
+	clientStorage := cache.NewClientStorage(client, conf, serverStorage)
+	sequence := cache.NewSequence(client)
+	repoSequence := repo.NewSequence(db, sequence)
+	robot := repo.NewRobot(db)
+	talkGroupThread := repo.NewTalkGroupThread(db)
+	talkUserMessage := repo.NewTalkRecordFriend(db)
+	talkGroupMessage := repo.NewTalkRecordGroup(db)
+	talkMessageMention := repo.NewTalkMessageMention(db)
+	talkMessageUrgent := repo.NewTalkMessageUrgent(db)
+	talkMessageFile := repo.NewTalkMessageFile(db)
+	iBus := provider.NewBus(conf, client)
+	pushMessage := &business.PushMessage{
+		Bus:             iBus,
+		Config:          conf,
+		ClientStorage:   clientStorage,
+		GroupMemberRepo: groupMember,
+	}
+	messageService := &message.Service{
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		SplitUploadRepo:      fileUpload,
+		TalkRecordsVoteRepo:  groupVote,
+		UsersRepo:            users,
+		Filesystem:           iFilesystem,
+		UnreadStorage:        unreadStorage,
+		MessageStorage:       messageStorage,
+		ServerStorage:        serverStorage,
+		ClientStorage:        clientStorage,
+		Sequence:             repoSequence,
+		RobotRepo:            robot,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkUserMessageRepo:  talkUserMessage,
+		TalkGroupMessageRepo: talkGroupMessage,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkMessageFileRepo:  talkMessageFile,
+		PushMessage:          pushMessage,
+		Bus:                  iBus,
+	}
+	talkScheduleService := &service.TalkScheduleService{
+		Source:                  source,
+		TalkMessageScheduleRepo: talkMessageSchedule,
+		AuthService:             authService,
+		MessageService:          messageService,
+	}
+	sendScheduleMessage := &cron.SendScheduleMessage{
+		TalkScheduleService: talkScheduleService,
+	}
+	clearExpireMessage := &cron.ClearExpireMessage{
+		DB:         db,
+		Filesystem: iFilesystem,
+	}
+	department := repo.NewDepartment(db)
+	position := repo.NewPosition(db)
+	talkUrgentService := &service.TalkUrgentService{
+		Source:              source,
+		TalkUrgentRepo:      talkMessageUrgent,
+		TalkUserMessageRepo: talkUserMessage,
+		GroupMemberRepo:     groupMember,
+		OrganizeRepo:        organize,
+		DepartmentRepo:      department,
+		PositionRepo:        position,
+		PushMessage:         pushMessage,
+	}
+	notifyUrgentMessage := &cron.NotifyUrgentMessage{
+		TalkUrgentService: talkUrgentService,
+	}
+	talkExport := repo.NewTalkExport(db)
+	talkGroupMessageDel := repo.NewTalkRecordGroupDel(db)
+	talkMessageEdit := repo.NewTalkMessageEdit(db)
+	talkMessageReaction := repo.NewTalkMessageReaction(db)
+	talkSyncEvent := repo.NewTalkSyncEvent(db, repoSequence)
+	talkRecordService := &service.TalkRecordService{
+		Source:                  source,
+		TalkVoteCache:           vote,
+		TalkRecordsVoteRepo:     groupVote,
+		GroupMemberRepo:         groupMember,
+		TalkRecordFriendRepo:    talkUserMessage,
+		TalkRecordGroupRepo:     talkGroupMessage,
+		TalkRecordsDeleteRepo:   talkGroupMessageDel,
+		TalkMessageEditRepo:     talkMessageEdit,
+		TalkMessageReactionRepo: talkMessageReaction,
+		TalkGroupThreadRepo:     talkGroupThread,
+		TalkSyncEventRepo:       talkSyncEvent,
+		TalkUrgentRepo:          talkMessageUrgent,
+		UnreadStorage:           unreadStorage,
+	}
+	talkExportService := &service.TalkExportService{
+		Source:            source,
+		TalkExportRepo:    talkExport,
+		GroupRepo:         repoGroup,
+		GroupMemberRepo:   groupMember,
+		UsersRepo:         users,
+		TalkRecordService: talkRecordService,
+		Filesystem:        iFilesystem,
+		Bus:               iBus,
+	}
+	recoverTalkExport := &cron.RecoverTalkExport{
+		TalkExportService: talkExportService,
+	}
 	crontab := &cron.Crontab{
-		ClearWsCache:      clearWsCache,
-		ClearArticle:      clearArticle,
-		ClearTmpFile:      clearTmpFile,
-		ClearExpireServer: clearExpireServer,
+		ClearWsCache:        clearWsCache,
+		ClearArticle:        clearArticle,
+		ClearTmpFile:        clearTmpFile,
+		ClearExpireServer:   clearExpireServer,
+		SendScheduleMessage: sendScheduleMessage,
+		ClearExpireMessage:  clearExpireMessage,
+		NotifyUrgentMessage: notifyUrgentMessage,
+		RecoverTalkExport:   recoverTalkExport,
 	}
 	cronProvider := &mission.CronProvider{
 		Config:  conf,
@@ -588,12 +984,32 @@ func NewQueueInjector(conf *config.Config) *mission.QueueProvider {
 		IpAddressClient: ipaddressClient,
 	}
 	talkSession := repo.NewTalkSession(db)
-	talkSessionService := &service.TalkSessionService{
-		Source:          source,
-		TalkSessionRepo: talkSession,
-	}
+	talkDraft := repo.NewTalkDraft(db)
+	sequence := cache.NewSequence(client)
+	repoSequence := repo.NewSequence(db, sequence)
+	talkSyncEvent := repo.NewTalkSyncEvent(db, repoSequence)
+	talkMessageMention := repo.NewTalkMessageMention(db)
+	draftStorage := cache.NewDraftStorage(client)
+	iBus := provider.NewBus(conf, client)
+	serverStorage := cache.NewSidStorage(client)
+	clientStorage := cache.NewClientStorage(client, conf, serverStorage)
 	relation := cache.NewRelation(client)
 	groupMember := repo.NewGroupMember(db, relation)
+	pushMessage := &business.PushMessage{
+		Bus:             iBus,
+		Config:          conf,
+		ClientStorage:   clientStorage,
+		GroupMemberRepo: groupMember,
+	}
+	talkSessionService := &service.TalkSessionService{
+		Source:            source,
+		TalkSessionRepo:   talkSession,
+		TalkDraftRepo:     talkDraft,
+		TalkSyncEventRepo: talkSyncEvent,
+		TalkMentionRepo:   talkMessageMention,
+		DraftStorage:      draftStorage,
+		PushMessage:       pushMessage,
+	}
 	fileUpload := repo.NewFileUpload(db)
 	vote := cache.NewVote(client)
 	groupVote := repo.NewGroupVote(db, vote)
@@ -601,27 +1017,32 @@ func NewQueueInjector(conf *config.Config) *mission.QueueProvider {
 	iFilesystem := provider.NewFilesystem(conf)
 	unreadStorage := cache.NewUnreadStorage(client)
 	messageStorage := cache.NewMessageStorage(client)
-	serverStorage := cache.NewSidStorage(client)
-	clientStorage := cache.NewClientStorage(client, conf, serverStorage)
-	sequence := cache.NewSequence(client)
-	repoSequence := repo.NewSequence(db, sequence)
-	pushMessage := &business.PushMessage{
-		Redis: client,
-	}
+	talkGroupThread := repo.NewTalkGroupThread(db)
+	talkUserMessage := repo.NewTalkRecordFriend(db)
+	talkGroupMessage := repo.NewTalkRecordGroup(db)
+	talkMessageUrgent := repo.NewTalkMessageUrgent(db)
+	talkMessageFile := repo.NewTalkMessageFile(db)
 	messageService := &message.Service{
-		Source:              source,
-		GroupMemberRepo:     groupMember,
-		SplitUploadRepo:     fileUpload,
-		TalkRecordsVoteRepo: groupVote,
-		UsersRepo:           users,
-		Filesystem:          iFilesystem,
-		UnreadStorage:       unreadStorage,
-		MessageStorage:      messageStorage,
-		ServerStorage:       serverStorage,
-		ClientStorage:       clientStorage,
-		Sequence:            repoSequence,
-		RobotRepo:           robot,
-		PushMessage:         pushMessage,
+		Source:               source,
+		GroupMemberRepo:      groupMember,
+		SplitUploadRepo:      fileUpload,
+		TalkRecordsVoteRepo:  groupVote,
+		UsersRepo:            users,
+		Filesystem:           iFilesystem,
+		UnreadStorage:        unreadStorage,
+		MessageStorage:       messageStorage,
+		ServerStorage:        serverStorage,
+		ClientStorage:        clientStorage,
+		Sequence:             repoSequence,
+		RobotRepo:            robot,
+		TalkGroupThreadRepo:  talkGroupThread,
+		TalkUserMessageRepo:  talkUserMessage,
+		TalkGroupMessageRepo: talkGroupMessage,
+		TalkMentionRepo:      talkMessageMention,
+		TalkUrgentRepo:       talkMessageUrgent,
+		TalkMessageFileRepo:  talkMessageFile,
+		PushMessage:          pushMessage,
+		Bus:                  iBus,
 	}
 	userLoginConsumer := &queue.UserLoginConsumer{
 		RobotRepo:          robot,
@@ -629,12 +1050,56 @@ func NewQueueInjector(conf *config.Config) *mission.QueueProvider {
 		TalkSessionService: talkSessionService,
 		Message:            messageService,
 	}
+	linkPreviewStorage := cache.NewLinkPreviewStorage(client)
+	unfurler := provider.NewUnfurler()
+	linkPreviewConsumer := &queue.LinkPreviewConsumer{
+		TalkRecordFriendRepo: talkUserMessage,
+		TalkRecordGroupRepo:  talkGroupMessage,
+		LinkPreviewStorage:   linkPreviewStorage,
+		Unfurler:             unfurler,
+		PushMessage:          pushMessage,
+	}
+	talkExport := repo.NewTalkExport(db)
+	repoGroup := repo.NewGroup(db)
+	talkGroupMessageDel := repo.NewTalkRecordGroupDel(db)
+	talkMessageEdit := repo.NewTalkMessageEdit(db)
+	talkMessageReaction := repo.NewTalkMessageReaction(db)
+	talkRecordService := &service.TalkRecordService{
+		Source:                  source,
+		TalkVoteCache:           vote,
+		TalkRecordsVoteRepo:     groupVote,
+		GroupMemberRepo:         groupMember,
+		TalkRecordFriendRepo:    talkUserMessage,
+		TalkRecordGroupRepo:     talkGroupMessage,
+		TalkRecordsDeleteRepo:   talkGroupMessageDel,
+		TalkMessageEditRepo:     talkMessageEdit,
+		TalkMessageReactionRepo: talkMessageReaction,
+		TalkGroupThreadRepo:     talkGroupThread,
+		TalkSyncEventRepo:       talkSyncEvent,
+		TalkUrgentRepo:          talkMessageUrgent,
+		UnreadStorage:           unreadStorage,
+	}
+	talkExportService := &service.TalkExportService{
+		Source:            source,
+		TalkExportRepo:    talkExport,
+		GroupRepo:         repoGroup,
+		GroupMemberRepo:   groupMember,
+		UsersRepo:         users,
+		TalkRecordService: talkRecordService,
+		Filesystem:        iFilesystem,
+		Bus:               iBus,
+	}
+	talkExportConsumer := &queue.TalkExportConsumer{
+		TalkExportService: talkExportService,
+	}
 	consumers := &queue.Consumers{
-		UserLoginConsumer: userLoginConsumer,
+		UserLoginConsumer:   userLoginConsumer,
+		LinkPreviewConsumer: linkPreviewConsumer,
+		TalkExportConsumer:  talkExportConsumer,
 	}
 	queueProvider := &mission.QueueProvider{
 		Consumers: consumers,
-		Redis:     client,
+		Bus:       iBus,
 	}
 	return queueProvider
 }
@@ -0,0 +1,7 @@
+package config
+
+// Bus 消息总线配置
+type Bus struct {
+	Driver string `json:"driver" yaml:"driver"`   // 驱动 redis(默认)、stream、nsq
+	MaxLen int64  `json:"max_len" yaml:"max_len"` // stream 驱动每个主题保留的消息数
+}
@@ -18,13 +18,24 @@ type Config struct {
 	Filesystem *Filesystem `json:"filesystem" yaml:"filesystem"`
 	Email      *Email      `json:"email" yaml:"email"`
 	Server     *Server     `json:"server" yaml:"server"`
-	Nsq        *Nsq        `json:"nsq" yaml:"nsq"` // 目前没用到
+	Nsq        *Nsq        `json:"nsq" yaml:"nsq"`
+	Bus        *Bus        `json:"bus" yaml:"bus"`
 }
 
 type Server struct {
-	Http      int `json:"http" yaml:"http"`
-	Websocket int `json:"websocket" yaml:"websocket"`
-	Tcp       int `json:"tcp" yaml:"tcp"`
+	Http               int `json:"http" yaml:"http"`
+	Websocket          int `json:"websocket" yaml:"websocket"`
+	Tcp                int `json:"tcp" yaml:"tcp"`
+	BroadcastGroupSize int `json:"broadcast_group_size" yaml:"broadcast_group_size"`
+}
+
+// GetBroadcastGroupSize 群成员数超过该值时群消息广播到所有节点，否则仅推送到成员在线的节点
+func (s *Server) GetBroadcastGroupSize() int {
+	if s.BroadcastGroupSize <= 0 {
+		return 500
+	}
+
+	return s.BroadcastGroupSize
 }
 
 func New(filename string) *Config {
@@ -9,27 +9,35 @@ import (
 )
 
 type V1 struct {
-	Common       *v1.Common
-	Auth         *v1.Auth
-	User         *v1.User
-	Organize     *v1.Organize
-	Talk         *talk.Session
-	TalkMessage  *talk.Message
-	TalkRecords  *talk.Records
-	Emoticon     *v1.Emoticon
-	Upload       *v1.Upload
-	Group        *group.Group
-	GroupNotice  *group.Notice
-	GroupApply   *group.Apply
-	GroupVote    *group.Vote
-	Contact      *contact.Contact
-	ContactApply *contact.Apply
-	ContactGroup *contact.Group
-	Article      *article.Article
-	ArticleAnnex *article.Annex
-	ArticleClass *article.Class
-	ArticleTag   *article.Tag
-	Message      *talk.Publish
+	Common           *v1.Common
+	Auth             *v1.Auth
+	User             *v1.User
+	Organize         *v1.Organize
+	Talk             *talk.Session
+	TalkMessage      *talk.Message
+	TalkRecords      *talk.Records
+	TalkSchedule     *talk.Schedule
+	TalkExport       *talk.Export
+	TalkBroadcast    *talk.Broadcast
+	TalkQuickReply   *talk.QuickReply
+	TalkUrgent       *talk.Urgent
+	TalkLiveLocation *talk.LiveLocation
+	TalkFavorite     *talk.Favorite
+	TalkMention      *talk.Mention
+	Emoticon         *v1.Emoticon
+	Upload           *v1.Upload
+	Group            *group.Group
+	GroupNotice      *group.Notice
+	GroupApply       *group.Apply
+	GroupVote        *group.Vote
+	Contact          *contact.Contact
+	ContactApply     *contact.Apply
+	ContactGroup     *contact.Group
+	Article          *article.Article
+	ArticleAnnex     *article.Annex
+	ArticleClass     *article.Class
+	ArticleTag       *article.Tag
+	Message          *talk.Publish
 }
 
 type Handler struct {
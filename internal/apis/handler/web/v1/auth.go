@@ -6,13 +6,12 @@ import (
 
 	"go-chat/internal/pkg/encrypt/rsautil"
 
-	"github.com/redis/go-redis/v9"
-
 	"go-chat/api/pb/queue/v1"
 	"go-chat/api/pb/web/v1"
 	"go-chat/config"
 	"go-chat/internal/entity"
 	"go-chat/internal/pkg/core"
+	"go-chat/internal/pkg/core/bus"
 	"go-chat/internal/pkg/jsonutil"
 	"go-chat/internal/pkg/jwt"
 	"go-chat/internal/pkg/logger"
@@ -23,7 +22,7 @@ import (
 
 type Auth struct {
 	Config              *config.Config
-	Redis               *redis.Client
+	Bus                 bus.IBus
 	JwtTokenStorage     *cache.JwtTokenStorage
 	RedisLock           *cache.RedisLock
 	RobotRepo           *repo.Robot
@@ -63,7 +62,7 @@ func (c *Auth) Login(ctx *core.Context) error {
 	})
 
 	// 投递登录消息，异步通知其他模块进行处理
-	if err := c.Redis.Publish(ctx.Ctx(), entity.LoginTopic, data).Err(); err != nil {
+	if err := c.Bus.Publish(ctx.Ctx(), entity.LoginTopic, data); err != nil {
 		logger.ErrorWithFields(
 			"投递登录消息异常", err,
 			queue.UserLoginRequest{
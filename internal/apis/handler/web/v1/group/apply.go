@@ -75,7 +75,8 @@ func (c *Apply) Create(ctx *core.Context) error {
 		c.GroupApplyStorage.Incr(ctx.Ctx(), find.UserId)
 	}
 
-	_ = c.PushMessage.Push(ctx.Ctx(), entity.ImChannelChat, &entity.SubscribeMessage{
+	// 推送到群成员所在节点，由节点筛选群主及管理员
+	_ = c.PushMessage.PushGroup(ctx.Ctx(), int(in.GroupId), &entity.SubscribeMessage{
 		Event: entity.SubEventGroupApply,
 		Payload: jsonutil.Encode(entity.SubEventGroupApplyPayload{
 			GroupId: int(in.GroupId),
@@ -0,0 +1,184 @@
+package talk
+
+import (
+	"html"
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/sliceutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type Broadcast struct {
+	TalkBroadcastService service.ITalkBroadcastService
+}
+
+type CreateBroadcastRequest struct {
+	Name    string `json:"name" binding:"required,max=64"`            // 列表名称
+	UserIds []int  `json:"user_ids" binding:"required,min=1,max=200"` // 接收者ID列表
+}
+
+// Create 创建群发列表
+func (c *Broadcast) Create(ctx *core.Context) error {
+	in := &CreateBroadcastRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	data, err := c.TalkBroadcastService.Create(ctx.Ctx(), ctx.UserId(), in.Name, in.UserIds)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"id": data.Id})
+}
+
+type UpdateBroadcastRequest struct {
+	Id      int    `json:"id" binding:"required,gt=0"`
+	Name    string `json:"name" binding:"required,max=64"`            // 列表名称
+	UserIds []int  `json:"user_ids" binding:"required,min=1,max=200"` // 接收者ID列表
+}
+
+// Update 修改群发列表
+func (c *Broadcast) Update(ctx *core.Context) error {
+	in := &UpdateBroadcastRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkBroadcastService.Update(ctx.Ctx(), ctx.UserId(), in.Id, in.Name, in.UserIds); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type DeleteBroadcastRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"`
+}
+
+// Delete 删除群发列表
+func (c *Broadcast) Delete(ctx *core.Context) error {
+	in := &DeleteBroadcastRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkBroadcastService.Delete(ctx.Ctx(), ctx.UserId(), in.Id); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+// List 群发列表
+func (c *Broadcast) List(ctx *core.Context) error {
+	items, err := c.TalkBroadcastService.List(ctx.Ctx(), ctx.UserId())
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(items, func(item *model.TalkBroadcast, index int) map[string]any {
+			return map[string]any{
+				"id":         item.Id,
+				"name":       item.Name,
+				"user_ids":   sliceutil.ParseIds(item.UserIds),
+				"created_at": item.CreatedAt.Format(time.DateTime),
+				"updated_at": item.UpdatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type SendBroadcastRequest struct {
+	Id   int    `json:"id" binding:"required,gt=0"`              // 群发列表ID
+	Type string `json:"type" binding:"required,oneof=text code"` // 消息类型 text:文本消息 code:代码消息
+	Body struct {
+		Text string `json:"text"`
+		Code string `json:"code"`
+		Lang string `json:"lang"`
+	} `json:"body" binding:"required"`
+}
+
+// Send 向群发列表发送消息
+func (c *Broadcast) Send(ctx *core.Context) error {
+	in := &SendBroadcastRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	opt := &service.TalkBroadcastSendOption{
+		UserId:      ctx.UserId(),
+		BroadcastId: in.Id,
+	}
+
+	switch in.Type {
+	case "text":
+		if in.Body.Text == "" {
+			return ctx.InvalidParams("消息内容不能为空")
+		}
+
+		opt.MsgType = entity.ChatMsgTypeText
+		opt.Extra = jsonutil.Encode(model.TalkRecordExtraText{
+			Content: html.EscapeString(in.Body.Text),
+		})
+	case "code":
+		if in.Body.Code == "" || in.Body.Lang == "" {
+			return ctx.InvalidParams("代码内容不能为空")
+		}
+
+		opt.MsgType = entity.ChatMsgTypeCode
+		opt.Extra = jsonutil.Encode(model.TalkRecordExtraCode{
+			Lang: in.Body.Lang,
+			Code: in.Body.Code,
+		})
+	}
+
+	data, err := c.TalkBroadcastService.Send(ctx.Ctx(), opt)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(c.formatLog(data))
+}
+
+type BroadcastLogsRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"` // 群发列表ID
+}
+
+// Logs 群发记录
+func (c *Broadcast) Logs(ctx *core.Context) error {
+	in := &BroadcastLogsRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items, err := c.TalkBroadcastService.Logs(ctx.Ctx(), ctx.UserId(), in.Id)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(items, func(item *model.TalkBroadcastLog, index int) map[string]any {
+			return c.formatLog(item)
+		}),
+	})
+}
+
+func (c *Broadcast) formatLog(item *model.TalkBroadcastLog) map[string]any {
+	return map[string]any{
+		"id":           item.Id,
+		"broadcast_id": item.BroadcastId,
+		"msg_type":     item.MsgType,
+		"extra":        item.Extra,
+		"total":        item.Total,
+		"reached_ids":  sliceutil.ParseIds(item.ReachedIds),
+		"failed_ids":   sliceutil.ParseIds(item.FailedIds),
+		"created_at":   item.CreatedAt.Format(time.DateTime),
+	}
+}
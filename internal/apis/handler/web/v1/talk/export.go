@@ -0,0 +1,112 @@
+package talk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/pkg/filesystem"
+	"go-chat/internal/pkg/timeutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type Export struct {
+	TalkExportService service.ITalkExportService
+	Filesystem        filesystem.IFilesystem
+}
+
+type CreateExportRequest struct {
+	TalkMode  int    `json:"talk_mode" binding:"required,oneof=1 2"`                     // 对话类型 1:私聊 2:群聊
+	ToFromId  int    `json:"to_from_id" binding:"required,gt=0"`                         // 好友ID或群ID
+	Format    string `json:"format" binding:"required,oneof=json html txt"`              // 导出格式
+	StartTime string `json:"start_time" binding:"required,datetime=2006-01-02 15:04:05"` // 消息发送时间起始(包含)
+	EndTime   string `json:"end_time" binding:"required,datetime=2006-01-02 15:04:05"`   // 消息发送时间截止(不包含)
+}
+
+// Create 创建聊天记录导出任务
+func (c *Export) Create(ctx *core.Context) error {
+	in := &CreateExportRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	data, err := c.TalkExportService.Create(ctx.Ctx(), &service.TalkExportCreateOption{
+		UserId:    ctx.UserId(),
+		TalkMode:  in.TalkMode,
+		ToFromId:  in.ToFromId,
+		Format:    in.Format,
+		StartTime: timeutil.ParseDateTime(in.StartTime),
+		EndTime:   timeutil.ParseDateTime(in.EndTime),
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"id": data.Id})
+}
+
+// List 聊天记录导出任务列表
+func (c *Export) List(ctx *core.Context) error {
+	items, err := c.TalkExportService.List(ctx.Ctx(), ctx.UserId())
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(items, func(item *model.TalkExport, index int) map[string]any {
+			return map[string]any{
+				"id":          item.Id,
+				"talk_mode":   item.TalkMode,
+				"to_from_id":  item.ToFromId,
+				"format":      item.Format,
+				"start_time":  item.StartTime.Format(time.DateTime),
+				"end_time":    item.EndTime.Format(time.DateTime),
+				"status":      item.Status,
+				"message_num": item.MessageNum,
+				"file_num":    item.FileNum,
+				"size":        item.Size,
+				"reason":      item.Reason,
+				"created_at":  item.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type DownloadExportRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"`
+}
+
+// Download 下载聊天记录导出文件
+func (c *Export) Download(ctx *core.Context) error {
+	in := &DownloadExportRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	item, err := c.TalkExportService.FindCompleted(ctx.Ctx(), ctx.UserId(), in.Id)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	filename := fmt.Sprintf("chat-export-%d-%s.zip", item.Id, item.StartTime.Format("20060102"))
+	if item.TalkMode == entity.ChatGroupMode {
+		filename = fmt.Sprintf("group-export-%d-%s.zip", item.Id, item.StartTime.Format("20060102"))
+	}
+
+	switch c.Filesystem.Driver() {
+	case filesystem.LocalDriver:
+		filePath := c.Filesystem.(*filesystem.LocalFilesystem).Path(c.Filesystem.BucketPrivateName(), item.Path)
+		ctx.Context.FileAttachment(filePath, filename)
+	case filesystem.MinioDriver:
+		ctx.Context.Redirect(http.StatusFound, c.Filesystem.PrivateUrl(c.Filesystem.BucketPrivateName(), item.Path, filename, 60*time.Second))
+	default:
+		return ctx.Error(errors.New("未知文件驱动类型"))
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package talk
+
+import (
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type Favorite struct {
+	TalkFavoriteService service.ITalkFavoriteService
+}
+
+type CreateFavoriteRequest struct {
+	TalkMode int      `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	MsgId    string   `form:"msg_id" json:"msg_id" binding:"required"`
+	Tags     []string `form:"tags" json:"tags" binding:"max=10,dive,max=20"`
+}
+
+// Create 收藏消息
+func (c *Favorite) Create(ctx *core.Context) error {
+	in := &CreateFavoriteRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	data, err := c.TalkFavoriteService.Create(ctx.Ctx(), &service.TalkFavoriteCreateOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+		Tags:     in.Tags,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"id": data.Id})
+}
+
+type DeleteFavoriteRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"`
+}
+
+// Delete 删除收藏
+func (c *Favorite) Delete(ctx *core.Context) error {
+	in := &DeleteFavoriteRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkFavoriteService.Delete(ctx.Ctx(), ctx.UserId(), in.Id); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type UpdateFavoriteTagsRequest struct {
+	Id   int      `form:"id" json:"id" binding:"required,gt=0"`
+	Tags []string `form:"tags" json:"tags" binding:"max=10,dive,max=20"`
+}
+
+// UpdateTags 修改收藏标签
+func (c *Favorite) UpdateTags(ctx *core.Context) error {
+	in := &UpdateFavoriteTagsRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkFavoriteService.UpdateTags(ctx.Ctx(), ctx.UserId(), in.Id, in.Tags); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type ListFavoriteRequest struct {
+	MsgType int    `form:"msg_type" json:"msg_type" binding:"omitempty,oneof=1 2 3 6"` // 消息类型
+	Tag     string `form:"tag" json:"tag"`                                             // 标签
+	Cursor  int    `form:"cursor" json:"cursor" binding:"min=0,numeric"`               // 上次查询的游标
+	Limit   int    `form:"limit" json:"limit" binding:"required,numeric,max=100"`      // 数据行数
+}
+
+// List 收藏列表
+func (c *Favorite) List(ctx *core.Context) error {
+	in := &ListFavoriteRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items, err := c.TalkFavoriteService.List(ctx.Ctx(), &service.TalkFavoriteListOption{
+		UserId:  ctx.UserId(),
+		MsgType: in.MsgType,
+		Tag:     in.Tag,
+		Cursor:  in.Cursor,
+		Limit:   in.Limit,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	cursor := 0
+	if length := len(items); length > 0 {
+		cursor = items[length-1].Id
+	}
+
+	return ctx.Success(map[string]any{
+		"cursor": cursor,
+		"items": lo.Map(items, func(item *model.TalkMessageFavorite, index int) map[string]any {
+			return map[string]any{
+				"id":         item.Id,
+				"talk_mode":  item.TalkMode,
+				"to_from_id": item.ToFromId,
+				"msg_id":     item.MsgId,
+				"from_id":    item.FromId,
+				"msg_type":   item.MsgType,
+				"extra":      item.Extra,
+				"tags":       lo.Ternary(item.Tags == "", []string{}, strings.Split(item.Tags, ",")),
+				"send_time":  item.SendTime.Format(time.DateTime),
+				"created_at": item.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+// Tags 收藏标签列表
+func (c *Favorite) Tags(ctx *core.Context) error {
+	tags, err := c.TalkFavoriteService.Tags(ctx.Ctx(), ctx.UserId())
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"items": tags})
+}
+
+type SendFavoriteRequest struct {
+	Ids      []int `form:"ids" json:"ids" binding:"required,min=1,max=20"` // 收藏ID列表
+	UserIds  []int `form:"user_ids" json:"user_ids"`                       // 好友ID列表
+	GroupIds []int `form:"group_ids" json:"group_ids"`                     // 群ID列表
+}
+
+// Send 发送收藏到会话
+func (c *Favorite) Send(ctx *core.Context) error {
+	in := &SendFavoriteRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if len(in.UserIds) == 0 && len(in.GroupIds) == 0 {
+		return ctx.InvalidParams("请选择要发送的会话")
+	}
+
+	if err := c.TalkFavoriteService.Send(ctx.Ctx(), &service.TalkFavoriteSendOption{
+		UserId: ctx.UserId(),
+		Ids:    in.Ids,
+		Uids:   in.UserIds,
+		Gids:   in.GroupIds,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
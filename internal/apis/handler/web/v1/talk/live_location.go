@@ -0,0 +1,85 @@
+package talk
+
+import (
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/service"
+)
+
+type LiveLocation struct {
+	TalkLiveLocationService service.ITalkLiveLocationService
+}
+
+type LiveLocationStartRequest struct {
+	TalkMode    int    `json:"talk_mode" binding:"required,oneof=1 2"`       // 对话类型 1:私聊 2:群聊
+	ToFromId    int    `json:"to_from_id" binding:"required,gt=0"`           // 好友ID或群ID
+	Duration    int    `json:"duration" binding:"required,min=60,max=28800"` // 共享时长(秒)
+	Longitude   string `json:"longitude" binding:"required,numeric"`         // 经度
+	Latitude    string `json:"latitude" binding:"required,numeric"`          // 纬度
+	Description string `json:"description" binding:"max=255"`                // 位置描述
+}
+
+// Start 开始共享实时位置，位置更新通过 websocket 的 im.live.location.update 事件上报
+func (c *LiveLocation) Start(ctx *core.Context) error {
+	in := &LiveLocationStartRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	data, err := c.TalkLiveLocationService.Start(ctx.Ctx(), &service.TalkLiveLocationStartOption{
+		UserId:      ctx.UserId(),
+		TalkMode:    in.TalkMode,
+		ToFromId:    in.ToFromId,
+		Duration:    in.Duration,
+		Longitude:   in.Longitude,
+		Latitude:    in.Latitude,
+		Description: in.Description,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"id":         data.Id,
+		"expired_at": data.ExpiredAt.Format(time.DateTime),
+	})
+}
+
+type LiveLocationStopRequest struct {
+	Id int `json:"id" binding:"required,gt=0"` // 位置共享ID
+}
+
+// Stop 结束共享实时位置
+func (c *LiveLocation) Stop(ctx *core.Context) error {
+	in := &LiveLocationStopRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkLiveLocationService.Stop(ctx.Ctx(), ctx.UserId(), in.Id); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(nil)
+}
+
+type LiveLocationListRequest struct {
+	TalkMode int `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"` // 对话类型 1:私聊 2:群聊
+	ToFromId int `form:"to_from_id" json:"to_from_id" binding:"required,gt=0"`    // 好友ID或群ID
+}
+
+// List 会话中共享中的实时位置
+func (c *LiveLocation) List(ctx *core.Context) error {
+	in := &LiveLocationListRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items, err := c.TalkLiveLocationService.List(ctx.Ctx(), ctx.UserId(), in.TalkMode, in.ToFromId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"items": items})
+}
@@ -0,0 +1,73 @@
+package talk
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type Mention struct {
+	TalkMentionService service.ITalkMentionService
+}
+
+type ListMentionRequest struct {
+	Unread bool `form:"unread" json:"unread"`                                  // 仅查询未读
+	Cursor int  `form:"cursor" json:"cursor" binding:"min=0,numeric"`          // 上次查询的游标
+	Limit  int  `form:"limit" json:"limit" binding:"required,numeric,max=100"` // 数据行数
+}
+
+// List 提及我的消息列表
+func (c *Mention) List(ctx *core.Context) error {
+	in := &ListMentionRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	uid := ctx.UserId()
+
+	items, err := c.TalkMentionService.List(ctx.Ctx(), &service.TalkMentionListOption{
+		UserId: uid,
+		Unread: in.Unread,
+		Cursor: in.Cursor,
+		Limit:  in.Limit,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	unreadNum, err := c.TalkMentionService.UnreadNum(ctx.Ctx(), uid)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	cursor := 0
+	if length := len(items); length > 0 {
+		cursor = items[length-1].Id
+	}
+
+	return ctx.Success(map[string]any{
+		"cursor":     cursor,
+		"unread_num": unreadNum,
+		"items": lo.Map(items, func(item *model.TalkMentionItem, index int) map[string]any {
+			return map[string]any{
+				"id":           item.Id,
+				"group_id":     item.GroupId,
+				"group_name":   item.GroupName,
+				"group_avatar": item.GroupAvatar,
+				"msg_id":       item.MsgId,
+				"from_id":      item.FromId,
+				"nickname":     item.Nickname,
+				"avatar":       item.Avatar,
+				"msg_type":     item.MsgType,
+				"extra":        lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
+				"is_revoked":   item.IsRevoked,
+				"is_all":       item.IsAll,
+				"is_read":      item.IsRead,
+				"created_at":   item.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
@@ -1,15 +1,22 @@
 package talk
 
 import (
+	"html"
+	"time"
+
+	"github.com/samber/lo"
 	"go-chat/internal/pkg/core"
 	"go-chat/internal/pkg/filesystem"
+	"go-chat/internal/repository/model"
 	"go-chat/internal/service"
 )
 
 type Message struct {
-	TalkService service.ITalkService
-	AuthService service.IAuthService
-	Filesystem  filesystem.IFilesystem
+	TalkService        service.ITalkService
+	TalkRecordsService service.ITalkRecordService
+	TalkPinService     service.ITalkPinService
+	AuthService        service.IAuthService
+	Filesystem         filesystem.IFilesystem
 }
 
 type RevokeMessageRequest struct {
@@ -60,3 +67,256 @@ func (c *Message) Delete(ctx *core.Context) error {
 
 	return ctx.Success(nil)
 }
+
+type EditMessageRequest struct {
+	TalkMode int    `json:"talk_mode" binding:"required,oneof=1 2"`
+	MsgId    string `json:"msg_id" binding:"required"`
+	Body     struct {
+		Text     string `json:"text"`     // 文本消息内容
+		Mentions []int  `json:"mentions"` // 文本消息@用户ID列表
+		Code     string `json:"code"`     // 代码消息内容
+		Lang     string `json:"lang"`     // 代码消息语言
+		Items    []struct {
+			Type    int    `json:"type" binding:"required"`
+			Content string `json:"content" binding:"required"`
+		} `json:"items"` // 图文消息内容
+	} `json:"body" binding:"required"`
+}
+
+// Edit 编辑聊天消息
+func (c *Message) Edit(ctx *core.Context) error {
+	in := &EditMessageRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items := make([]*model.TalkRecordExtraMixedItem, 0, len(in.Body.Items))
+	for _, item := range in.Body.Items {
+		items = append(items, &model.TalkRecordExtraMixedItem{
+			Type:    item.Type,
+			Content: item.Content,
+		})
+	}
+
+	if err := c.TalkService.Edit(ctx.Ctx(), &service.TalkEditOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+		Content:  html.EscapeString(in.Body.Text),
+		Mentions: in.Body.Mentions,
+		Code:     in.Body.Code,
+		Lang:     in.Body.Lang,
+		Items:    items,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type EditRecordsRequest struct {
+	TalkMode int    `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	MsgId    string `form:"msg_id" json:"msg_id" binding:"required"`
+}
+
+// EditRecords 聊天消息编辑记录
+func (c *Message) EditRecords(ctx *core.Context) error {
+	in := &EditRecordsRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	records, err := c.TalkRecordsService.FindEditRecords(ctx.Ctx(), ctx.UserId(), in.TalkMode, in.MsgId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(records, func(item *model.TalkMessageEdit, index int) map[string]any {
+			return map[string]any{
+				"msg_type":  item.MsgType,
+				"extra":     item.Extra,
+				"edit_time": item.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type ReactionMessageRequest struct {
+	TalkMode int    `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	MsgId    string `form:"msg_id" json:"msg_id" binding:"required"`
+	Emoji    string `form:"emoji" json:"emoji" binding:"required,max=32"`
+}
+
+// AddReaction 添加消息表态
+func (c *Message) AddReaction(ctx *core.Context) error {
+	in := &ReactionMessageRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkService.AddReaction(ctx.Ctx(), &service.TalkReactionOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+		Emoji:    in.Emoji,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+// RemoveReaction 取消消息表态
+func (c *Message) RemoveReaction(ctx *core.Context) error {
+	in := &ReactionMessageRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkService.RemoveReaction(ctx.Ctx(), &service.TalkReactionOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+		Emoji:    in.Emoji,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type ReadUsersRequest struct {
+	MsgId string `form:"msg_id" json:"msg_id" binding:"required"`
+}
+
+// ReadUsers 群消息已读用户列表
+func (c *Message) ReadUsers(ctx *core.Context) error {
+	in := &ReadUsersRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	users, err := c.TalkService.FindGroupReadUsers(ctx.Ctx(), ctx.UserId(), in.MsgId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(users, func(item *model.TalkReadUser, index int) map[string]any {
+			return map[string]any{
+				"user_id":  item.UserId,
+				"nickname": item.Nickname,
+				"avatar":   item.Avatar,
+				"read_at":  item.ReadAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type PinMessageRequest struct {
+	TalkMode int    `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	MsgId    string `form:"msg_id" json:"msg_id" binding:"required"`
+}
+
+// Pin 置顶聊天消息
+func (c *Message) Pin(ctx *core.Context) error {
+	in := &PinMessageRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkPinService.Pin(ctx.Ctx(), &service.TalkPinOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+// Unpin 取消置顶聊天消息
+func (c *Message) Unpin(ctx *core.Context) error {
+	in := &PinMessageRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkPinService.Unpin(ctx.Ctx(), &service.TalkPinOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		MsgId:    in.MsgId,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type PinListRequest struct {
+	TalkMode int `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	ToFromId int `form:"to_from_id" json:"to_from_id" binding:"required,numeric,min=1"`
+}
+
+// PinList 会话置顶消息列表
+func (c *Message) PinList(ctx *core.Context) error {
+	in := &PinListRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	uid := ctx.UserId()
+	if err := c.AuthService.IsAuth(ctx.Ctx(), &service.AuthOption{
+		TalkType: in.TalkMode,
+		UserId:   uid,
+		ToFromId: in.ToFromId,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	pins, err := c.TalkPinService.List(ctx.Ctx(), uid, in.TalkMode, in.ToFromId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	msgIds := lo.Map(pins, func(item *model.TalkMessagePin, _ int) string {
+		return item.MsgId
+	})
+
+	records := make([]*model.TalkMessageRecord, 0)
+	if len(msgIds) > 0 {
+		records, err = c.TalkRecordsService.FindForwardRecords(ctx.Ctx(), uid, msgIds, in.TalkMode)
+		if err != nil {
+			return ctx.Error(err)
+		}
+	}
+
+	hashRecords := lo.KeyBy(records, func(item *model.TalkMessageRecord) string {
+		return item.MsgId
+	})
+
+	items := make([]map[string]any, 0, len(pins))
+	for _, pin := range pins {
+		record, ok := hashRecords[pin.MsgId]
+		if !ok || record.IsRevoked == model.Yes {
+			continue
+		}
+
+		items = append(items, map[string]any{
+			"msg_id":    record.MsgId,
+			"sequence":  record.Sequence,
+			"msg_type":  record.MsgType,
+			"from_id":   record.FromId,
+			"nickname":  record.Nickname,
+			"avatar":    record.Avatar,
+			"send_time": record.SendTime.Format(time.DateTime),
+			"extra":     record.Extra,
+			"pinned_by": pin.PinnedBy,
+			"pinned_at": pin.CreatedAt.Format(time.DateTime),
+		})
+	}
+
+	return ctx.Success(map[string]any{"items": items})
+}
@@ -5,6 +5,7 @@ import (
 	"html"
 
 	"github.com/gin-gonic/gin/binding"
+	"go-chat/internal/entity"
 	"go-chat/internal/pkg/core"
 	"go-chat/internal/pkg/logger"
 	"go-chat/internal/service"
@@ -14,8 +15,9 @@ import (
 var mapping map[string]func(ctx *core.Context) error
 
 type Publish struct {
-	AuthService    service.IAuthService
-	MessageService message.IService
+	AuthService       service.IAuthService
+	MessageService    message.IService
+	TalkUrgentService service.ITalkUrgentService
 }
 
 type BaseMessageRequest struct {
@@ -23,6 +25,8 @@ type BaseMessageRequest struct {
 	TalkMode int    `json:"talk_mode" binding:"required,gt=0"`  // 对话类型 1:私聊 2:群聊
 	ToFromId int    `json:"to_from_id" binding:"required,gt=0"` // 接受者ID (好友ID或者群ID)
 	QuoteId  string `json:"quote_id"`                           // 引用的消息ID
+	ThreadId string `json:"thread_id"`                          // 话题根消息ID(仅群聊)
+	IsUrgent bool   `json:"is_urgent"`                          // 是否为紧急消息(仅文本和图文消息)
 }
 
 // Send 发送消息接口
@@ -32,6 +36,24 @@ func (c *Publish) Send(ctx *core.Context) error {
 		return ctx.InvalidParams(err)
 	}
 
+	if in.ThreadId != "" && in.TalkMode != entity.ChatGroupMode {
+		return ctx.InvalidParams("仅群聊支持话题回复")
+	}
+
+	if in.IsUrgent {
+		if in.Type != "text" && in.Type != "mixed" {
+			return ctx.InvalidParams("紧急消息仅支持文本和图文消息")
+		}
+
+		if in.ThreadId != "" {
+			return ctx.InvalidParams("话题回复不支持紧急消息")
+		}
+
+		if err := c.TalkUrgentService.Allow(ctx.Ctx(), ctx.UserId(), in.TalkMode, in.ToFromId); err != nil {
+			return ctx.Error(err)
+		}
+	}
+
 	if err := c.AuthService.IsAuth(ctx.Ctx(), &service.AuthOption{
 		TalkType:          in.TalkMode,
 		UserId:            ctx.UserId(),
@@ -65,7 +87,9 @@ func (c *Publish) onSendText(ctx *core.Context) error {
 		ToFromId: in.ToFromId,
 		Content:  html.EscapeString(in.Body.Text),
 		QuoteId:  in.QuoteId,
+		ThreadId: in.ThreadId,
 		Mentions: in.Body.Mentions,
+		IsUrgent: in.IsUrgent,
 	})
 
 	if err != nil {
@@ -97,6 +121,7 @@ func (c *Publish) onSendImage(ctx *core.Context) error {
 		FromId:   ctx.UserId(),
 		ToFromId: in.ToFromId,
 		QuoteId:  in.QuoteId,
+		ThreadId: in.ThreadId,
 		Url:      in.Body.Url,
 		Width:    in.Body.Width,
 		Height:   in.Body.Height,
@@ -221,6 +246,7 @@ func (c *Publish) onSendCode(ctx *core.Context) error {
 		TalkMode: in.TalkMode,
 		FromId:   ctx.UserId(),
 		ToFromId: in.ToFromId,
+		ThreadId: in.ThreadId,
 		Code:     in.Body.Code,
 		Lang:     in.Body.Lang,
 	})
@@ -386,7 +412,9 @@ func (c *Publish) onMixedMessage(ctx *core.Context) error {
 		FromId:      ctx.UserId(),
 		ToFromId:    in.ToFromId,
 		QuoteId:     in.QuoteId,
+		ThreadId:    in.ThreadId,
 		MessageList: items,
+		IsUrgent:    in.IsUrgent,
 	})
 	if err != nil {
 		return ctx.Error(err)
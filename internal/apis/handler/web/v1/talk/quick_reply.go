@@ -0,0 +1,140 @@
+package talk
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type QuickReply struct {
+	TalkQuickReplyService service.ITalkQuickReplyService
+}
+
+type CreateQuickReplyRequest struct {
+	Scope   int    `json:"scope" binding:"required,oneof=1 2"`     // 可见范围 1:个人 2:部门
+	Title   string `json:"title" binding:"required,max=64"`        // 标题
+	MsgType int    `json:"msg_type" binding:"required,oneof=1 12"` // 消息类型 1:文本消息 12:图文消息
+	Content string `json:"content" binding:"required,max=5000"`    // 模板内容，支持 {nickname} {my_nickname} {date} {time} 变量
+}
+
+// Create 创建快捷回复
+func (c *QuickReply) Create(ctx *core.Context) error {
+	in := &CreateQuickReplyRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	data, err := c.TalkQuickReplyService.Create(ctx.Ctx(), &service.TalkQuickReplyOption{
+		UserId:  ctx.UserId(),
+		Scope:   in.Scope,
+		Title:   in.Title,
+		MsgType: in.MsgType,
+		Content: in.Content,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"id": data.Id})
+}
+
+type UpdateQuickReplyRequest struct {
+	Id      int    `json:"id" binding:"required,gt=0"`
+	Title   string `json:"title" binding:"required,max=64"`        // 标题
+	MsgType int    `json:"msg_type" binding:"required,oneof=1 12"` // 消息类型 1:文本消息 12:图文消息
+	Content string `json:"content" binding:"required,max=5000"`    // 模板内容
+}
+
+// Update 修改快捷回复
+func (c *QuickReply) Update(ctx *core.Context) error {
+	in := &UpdateQuickReplyRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	err := c.TalkQuickReplyService.Update(ctx.Ctx(), in.Id, &service.TalkQuickReplyOption{
+		UserId:  ctx.UserId(),
+		Title:   in.Title,
+		MsgType: in.MsgType,
+		Content: in.Content,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type DeleteQuickReplyRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"`
+}
+
+// Delete 删除快捷回复
+func (c *QuickReply) Delete(ctx *core.Context) error {
+	in := &DeleteQuickReplyRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkQuickReplyService.Delete(ctx.Ctx(), ctx.UserId(), in.Id); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+// List 快捷回复列表(个人及所在部门链路上的部门快捷回复)
+func (c *QuickReply) List(ctx *core.Context) error {
+	uid := ctx.UserId()
+
+	items, err := c.TalkQuickReplyService.List(ctx.Ctx(), uid)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(items, func(item *model.TalkQuickReply, index int) map[string]any {
+			return map[string]any{
+				"id":         item.Id,
+				"scope":      item.Scope,
+				"dept_id":    item.DeptId,
+				"title":      item.Title,
+				"msg_type":   item.MsgType,
+				"content":    item.Content,
+				"is_owner":   item.UserId == uid,
+				"updated_at": item.UpdatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type SendQuickReplyRequest struct {
+	Id       int    `json:"id" binding:"required,gt=0"`             // 快捷回复ID
+	TalkMode int    `json:"talk_mode" binding:"required,oneof=1 2"` // 对话类型 1:私聊 2:群聊
+	ToFromId int    `json:"to_from_id" binding:"required,gt=0"`     // 接受者ID (好友ID或者群ID)
+	QuoteId  string `json:"quote_id"`                               // 引用的消息ID
+}
+
+// Send 发送快捷回复
+func (c *QuickReply) Send(ctx *core.Context) error {
+	in := &SendQuickReplyRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	err := c.TalkQuickReplyService.Send(ctx.Ctx(), &service.TalkQuickReplySendOption{
+		UserId:   ctx.UserId(),
+		Id:       in.Id,
+		TalkMode: in.TalkMode,
+		ToFromId: in.ToFromId,
+		QuoteId:  in.QuoteId,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
@@ -2,8 +2,10 @@ package talk
 
 import (
 	"errors"
+	"html"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
@@ -13,6 +15,7 @@ import (
 	"go-chat/internal/pkg/jsonutil"
 	"go-chat/internal/pkg/strutil"
 	"go-chat/internal/pkg/timeutil"
+	"go-chat/internal/repository/cache"
 	"go-chat/internal/repository/model"
 	"go-chat/internal/repository/repo"
 	"go-chat/internal/service"
@@ -26,6 +29,7 @@ type Records struct {
 	GroupMemberService   service.IGroupMemberService
 	AuthService          service.IAuthService
 	Filesystem           filesystem.IFilesystem
+	UnreadStorage        *cache.UnreadStorage
 }
 
 type GetTalkRecordsRequest struct {
@@ -64,6 +68,7 @@ func (c *Records) GetRecords(ctx *core.Context) error {
 						Nickname:  "",
 						Avatar:    "",
 						IsRevoked: model.No,
+						IsEdited:  model.No,
 						SendTime:  timeutil.DateTime(),
 						Extra: model.TalkRecordExtraText{
 							Content: "暂无权限查看群消息",
@@ -92,6 +97,65 @@ func (c *Records) GetRecords(ctx *core.Context) error {
 		cursor = records[length-1].Sequence
 	}
 
+	return ctx.Success(map[string]any{
+		"cursor": cursor,
+		"items": lo.Map(records, func(item *model.TalkMessageRecord, index int) entity.ImMessagePayloadBody {
+			body := entity.ImMessagePayloadBody{
+				FromId:         item.FromId,
+				MsgId:          item.MsgId,
+				Sequence:       item.Sequence,
+				MsgType:        item.MsgType,
+				Nickname:       item.Nickname,
+				Avatar:         item.Avatar,
+				IsRevoked:      item.IsRevoked,
+				IsEdited:       item.IsEdited,
+				SendTime:       item.SendTime.Format(time.DateTime),
+				Extra:          lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
+				Quote:          item.Quote,
+				Reactions:      item.Reactions,
+				ThreadId:       item.ThreadId,
+				Thread:         item.Thread,
+				DeliveryStatus: item.DeliveryStatus,
+			}
+
+			if item.Urgent != nil {
+				body.IsUrgent = true
+				body.Urgent = item.Urgent
+			}
+
+			return body
+		}),
+	})
+}
+
+type GetThreadRecordsRequest struct {
+	MsgId  string `form:"msg_id" json:"msg_id" binding:"required"`               // 话题根消息ID
+	Cursor int    `form:"cursor" json:"cursor" binding:"min=0,numeric"`          // 上次查询的游标
+	Limit  int    `form:"limit" json:"limit" binding:"required,numeric,max=100"` // 数据行数
+}
+
+// GetThreadRecords 获取话题回复记录
+func (c *Records) GetThreadRecords(ctx *core.Context) error {
+	in := &GetThreadRecordsRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	records, err := c.TalkRecordsService.FindThreadRecords(ctx.Ctx(), &service.FindThreadRecordsOpt{
+		UserId: ctx.UserId(),
+		MsgId:  in.MsgId,
+		Cursor: in.Cursor,
+		Limit:  in.Limit,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	cursor := in.Cursor
+	if length := len(records); length > 0 {
+		cursor = records[length-1].Sequence
+	}
+
 	return ctx.Success(map[string]any{
 		"cursor": cursor,
 		"items": lo.Map(records, func(item *model.TalkMessageRecord, index int) entity.ImMessagePayloadBody {
@@ -103,14 +167,33 @@ func (c *Records) GetRecords(ctx *core.Context) error {
 				Nickname:  item.Nickname,
 				Avatar:    item.Avatar,
 				IsRevoked: item.IsRevoked,
+				IsEdited:  item.IsEdited,
 				SendTime:  item.SendTime.Format(time.DateTime),
 				Extra:     lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
 				Quote:     item.Quote,
+				Reactions: item.Reactions,
+				ThreadId:  item.ThreadId,
 			}
 		}),
 	})
 }
 
+type ClearThreadUnreadRequest struct {
+	MsgId string `form:"msg_id" json:"msg_id" binding:"required"` // 话题根消息ID
+}
+
+// ClearThreadUnread 清除话题未读数
+func (c *Records) ClearThreadUnread(ctx *core.Context) error {
+	in := &ClearThreadUnreadRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	c.UnreadStorage.ResetThread(ctx.Ctx(), ctx.UserId(), in.MsgId)
+
+	return ctx.Success(map[string]any{})
+}
+
 // SearchHistoryRecords 查询下会话记录
 func (c *Records) SearchHistoryRecords(ctx *core.Context) error {
 
@@ -174,22 +257,130 @@ func (c *Records) SearchHistoryRecords(ctx *core.Context) error {
 	return ctx.Success(map[string]any{
 		"cursor": cursor,
 		"items": lo.Map(records, func(item *model.TalkMessageRecord, index int) entity.ImMessagePayloadBody {
-			return entity.ImMessagePayloadBody{
-				FromId:    item.FromId,
-				MsgId:     item.MsgId,
-				Sequence:  item.Sequence,
-				MsgType:   item.MsgType,
-				Nickname:  item.Nickname,
-				Avatar:    item.Avatar,
-				IsRevoked: item.IsRevoked,
-				SendTime:  item.SendTime.Format(time.DateTime),
-				Extra:     lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
-				Quote:     item.Quote,
+			body := entity.ImMessagePayloadBody{
+				FromId:         item.FromId,
+				MsgId:          item.MsgId,
+				Sequence:       item.Sequence,
+				MsgType:        item.MsgType,
+				Nickname:       item.Nickname,
+				Avatar:         item.Avatar,
+				IsRevoked:      item.IsRevoked,
+				IsEdited:       item.IsEdited,
+				SendTime:       item.SendTime.Format(time.DateTime),
+				Extra:          lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
+				Quote:          item.Quote,
+				Reactions:      item.Reactions,
+				ThreadId:       item.ThreadId,
+				Thread:         item.Thread,
+				DeliveryStatus: item.DeliveryStatus,
 			}
+
+			if item.Urgent != nil {
+				body.IsUrgent = true
+				body.Urgent = item.Urgent
+			}
+
+			return body
 		}),
 	})
 }
 
+type SearchRecordsRequest struct {
+	Keyword   string `form:"keyword" json:"keyword" binding:"required,min=2,max=50"`                        // 搜索关键词
+	TalkMode  int    `form:"talk_mode" json:"talk_mode" binding:"omitempty,oneof=1 2"`                      // 对话类型
+	ToFromId  int    `form:"to_from_id" json:"to_from_id" binding:"omitempty,min=1"`                        // 接收者ID
+	FromId    int    `form:"from_id" json:"from_id" binding:"omitempty,min=1"`                              // 消息发送者ID
+	MsgType   int    `form:"msg_type" json:"msg_type" binding:"omitempty,oneof=1 2 12"`                     // 消息类型
+	StartTime string `form:"start_time" json:"start_time" binding:"omitempty,datetime=2006-01-02 15:04:05"` // 开始时间
+	EndTime   string `form:"end_time" json:"end_time" binding:"omitempty,datetime=2006-01-02 15:04:05"`     // 结束时间
+	Page      int    `form:"page" json:"page" binding:"omitempty,min=1"`                                    // 页码
+	Limit     int    `form:"limit" json:"limit" binding:"required,numeric,max=100"`                         // 数据行数
+}
+
+// SearchRecords 全文检索所有会话消息
+func (c *Records) SearchRecords(ctx *core.Context) error {
+	in := &SearchRecordsRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if in.ToFromId > 0 && in.TalkMode == 0 {
+		return ctx.InvalidParams("指定会话时对话类型不能为空")
+	}
+
+	opt := &service.SearchTalkRecordsOpt{
+		UserId:   ctx.UserId(),
+		Keyword:  in.Keyword,
+		TalkMode: in.TalkMode,
+		ToFromId: in.ToFromId,
+		FromId:   in.FromId,
+		MsgType:  in.MsgType,
+		Page:     max(in.Page, 1),
+		Limit:    in.Limit,
+	}
+
+	if in.StartTime != "" {
+		opt.StartTime = timeutil.ParseDateTime(in.StartTime)
+	}
+
+	if in.EndTime != "" {
+		opt.EndTime = timeutil.ParseDateTime(in.EndTime)
+	}
+
+	records, err := c.TalkRecordsService.SearchRecords(ctx.Ctx(), opt)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"page": opt.Page,
+		"items": lo.Map(records, func(item *model.TalkMessageRecord, index int) map[string]any {
+			return map[string]any{
+				"talk_mode":  item.TalkMode,
+				"to_from_id": item.ToFromId,
+				"msg_id":     item.MsgId,
+				"sequence":   item.Sequence,
+				"msg_type":   item.MsgType,
+				"from_id":    item.FromId,
+				"nickname":   item.Nickname,
+				"avatar":     item.Avatar,
+				"thread_id":  item.ThreadId,
+				"send_time":  item.SendTime.Format(time.DateTime),
+				"extra":      item.Extra,
+				"highlight":  strutil.Highlight(searchText(item.MsgType, item.Extra), in.Keyword, 30),
+			}
+		}),
+	})
+}
+
+// 获取消息中可检索的文本内容
+func searchText(msgType int, extra string) string {
+	switch msgType {
+	case entity.ChatMsgTypeText:
+		data := model.TalkRecordExtraText{}
+		_ = jsonutil.Decode(extra, &data)
+		return html.UnescapeString(data.Content)
+	case entity.ChatMsgTypeCode:
+		data := model.TalkRecordExtraCode{}
+		_ = jsonutil.Decode(extra, &data)
+		return data.Code
+	case entity.ChatMsgTypeMixed:
+		data := model.TalkRecordExtraMixed{}
+		_ = jsonutil.Decode(extra, &data)
+
+		items := make([]string, 0, len(data.Items))
+		for _, item := range data.Items {
+			if item.Type == entity.ChatMsgTypeText {
+				items = append(items, html.UnescapeString(item.Content))
+			}
+		}
+
+		return strings.Join(items, " ")
+	}
+
+	return ""
+}
+
 type GetForwardTalkRecordRequest struct {
 	TalkMode int      `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"` // 对话类型
 	MsgIds   []string `form:"msg_ids[]" json:"msg_ids" binding:"required"`
@@ -217,9 +408,13 @@ func (c *Records) GetForwardRecords(ctx *core.Context) error {
 				Nickname:  item.Nickname,
 				Avatar:    item.Avatar,
 				IsRevoked: item.IsRevoked,
+				IsEdited:  item.IsEdited,
 				SendTime:  item.SendTime.Format(time.DateTime),
 				Extra:     lo.Ternary(item.IsRevoked == model.Yes, "{}", item.Extra),
 				Quote:     item.Quote,
+				Reactions: item.Reactions,
+				ThreadId:  item.ThreadId,
+				Thread:    item.Thread,
 			}
 		}),
 	})
@@ -274,3 +469,97 @@ func (c *Records) Download(ctx *core.Context) error {
 
 	return nil
 }
+
+type SyncRecordsRequest struct {
+	Sequence      int64 `json:"sequence" binding:"min=0"`       // 私聊消息时序ID水位
+	EventSequence int64 `json:"event_sequence" binding:"min=0"` // 用户同步事件时序ID水位(会话设置等)
+	Groups        []struct {
+		GroupId       int   `json:"group_id" binding:"required,gt=0"` // 群ID
+		Sequence      int64 `json:"sequence" binding:"min=0"`         // 群消息时序ID水位
+		EventSequence int64 `json:"event_sequence" binding:"min=0"`   // 群同步事件时序ID水位
+	} `json:"groups" binding:"max=200,dive"`
+	Limit int `json:"limit" binding:"required,numeric,min=1,max=500"` // 每个会话返回的最大数据行数
+}
+
+// Sync 增量同步会话消息及变更事件
+func (c *Records) Sync(ctx *core.Context) error {
+	in := &SyncRecordsRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	groups := make(map[int]*service.TalkSyncCursor, len(in.Groups))
+	for _, group := range in.Groups {
+		groups[group.GroupId] = &service.TalkSyncCursor{Sequence: group.Sequence, EventSequence: group.EventSequence}
+	}
+
+	result, err := c.TalkRecordsService.Sync(ctx.Ctx(), &service.TalkSyncOpt{
+		UserId:        ctx.UserId(),
+		Sequence:      in.Sequence,
+		EventSequence: in.EventSequence,
+		Groups:        groups,
+		Limit:         in.Limit,
+	})
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"private": c.syncItem(result.Private),
+		"groups": lo.Map(result.Groups, func(item *service.TalkSyncItem, index int) map[string]any {
+			return c.syncItem(item)
+		}),
+	})
+}
+
+func (c *Records) syncItem(item *service.TalkSyncItem) map[string]any {
+	data := map[string]any{
+		"sequence":       item.Sequence,
+		"event_sequence": item.EventSequence,
+		"has_more":       item.HasMore,
+		"messages": lo.Map(item.Messages, func(record *model.TalkMessageRecord, index int) entity.ImMessagePayload {
+			return entity.ImMessagePayload{
+				TalkMode: record.TalkMode,
+				FromId:   record.FromId,
+				ToFromId: record.ToFromId,
+				Body: entity.ImMessagePayloadBody{
+					FromId:         record.FromId,
+					MsgId:          record.MsgId,
+					Sequence:       record.Sequence,
+					MsgType:        record.MsgType,
+					Nickname:       record.Nickname,
+					Avatar:         record.Avatar,
+					IsRevoked:      record.IsRevoked,
+					IsEdited:       record.IsEdited,
+					SendTime:       record.SendTime.Format(time.DateTime),
+					Extra:          lo.Ternary(record.IsRevoked == model.Yes, "{}", record.Extra),
+					Quote:          record.Quote,
+					Reactions:      record.Reactions,
+					ThreadId:       record.ThreadId,
+					Thread:         record.Thread,
+					DeliveryStatus: record.DeliveryStatus,
+				},
+			}
+		}),
+		"events": lo.Map(item.Events, func(event *model.TalkSyncEvent, index int) map[string]any {
+			payload := model.TalkSyncEventPayload{}
+			_ = jsonutil.Decode(event.Payload, &payload)
+
+			return map[string]any{
+				"event":      event.Event,
+				"sequence":   event.Sequence,
+				"talk_mode":  event.TalkMode,
+				"to_from_id": event.ToFromId,
+				"msg_ids":    lo.Ternary(payload.MsgIds == nil, []string{}, payload.MsgIds),
+				"value":      payload.Value,
+				"created_at": event.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	}
+
+	if item.GroupId > 0 {
+		data["group_id"] = item.GroupId
+	}
+
+	return data
+}
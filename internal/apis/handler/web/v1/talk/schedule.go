@@ -0,0 +1,159 @@
+package talk
+
+import (
+	"html"
+	"time"
+
+	"github.com/samber/lo"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/timeutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/service"
+)
+
+type Schedule struct {
+	AuthService         service.IAuthService
+	TalkScheduleService service.ITalkScheduleService
+}
+
+type CreateScheduleRequest struct {
+	Type     string `json:"type" binding:"required,oneof=text code"`                 // 消息类型 text:文本消息 code:代码消息
+	TalkMode int    `json:"talk_mode" binding:"required,oneof=1 2"`                  // 对话类型 1:私聊 2:群聊
+	ToFromId int    `json:"to_from_id" binding:"required,gt=0"`                      // 接受者ID (好友ID或者群ID)
+	QuoteId  string `json:"quote_id"`                                                // 引用的消息ID
+	SendAt   string `json:"send_at" binding:"required,datetime=2006-01-02 15:04:05"` // 计划发送时间
+	Body     struct {
+		Text     string `json:"text"`
+		Mentions []int  `json:"mentions"`
+		Code     string `json:"code"`
+		Lang     string `json:"lang"`
+	} `json:"body" binding:"required"`
+}
+
+// Create 创建定时消息
+func (c *Schedule) Create(ctx *core.Context) error {
+	in := &CreateScheduleRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	uid := ctx.UserId()
+	if err := c.AuthService.IsAuth(ctx.Ctx(), &service.AuthOption{
+		TalkType: in.TalkMode,
+		UserId:   uid,
+		ToFromId: in.ToFromId,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	opt := &service.TalkScheduleCreateOption{
+		UserId:   uid,
+		TalkMode: in.TalkMode,
+		ToFromId: in.ToFromId,
+		QuoteId:  in.QuoteId,
+		SendAt:   timeutil.ParseDateTime(in.SendAt),
+	}
+
+	switch in.Type {
+	case "text":
+		if in.Body.Text == "" {
+			return ctx.InvalidParams("消息内容不能为空")
+		}
+
+		opt.MsgType = entity.ChatMsgTypeText
+		opt.Extra = jsonutil.Encode(model.TalkRecordExtraText{
+			Content:  html.EscapeString(in.Body.Text),
+			Mentions: in.Body.Mentions,
+		})
+	case "code":
+		if in.Body.Code == "" || in.Body.Lang == "" {
+			return ctx.InvalidParams("代码内容不能为空")
+		}
+
+		opt.MsgType = entity.ChatMsgTypeCode
+		opt.Extra = jsonutil.Encode(model.TalkRecordExtraCode{
+			Lang: in.Body.Lang,
+			Code: in.Body.Code,
+		})
+	}
+
+	data, err := c.TalkScheduleService.Create(ctx.Ctx(), opt)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"id": data.Id})
+}
+
+type ListScheduleRequest struct {
+	Status int `form:"status" json:"status" binding:"omitempty,oneof=1 2 3 4 5"` // 发送状态
+}
+
+// List 定时消息列表
+func (c *Schedule) List(ctx *core.Context) error {
+	in := &ListScheduleRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items, err := c.TalkScheduleService.List(ctx.Ctx(), ctx.UserId(), in.Status)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{
+		"items": lo.Map(items, func(item *model.TalkMessageSchedule, index int) map[string]any {
+			return map[string]any{
+				"id":         item.Id,
+				"talk_mode":  item.TalkMode,
+				"to_from_id": item.ToFromId,
+				"msg_type":   item.MsgType,
+				"quote_id":   item.QuoteId,
+				"extra":      item.Extra,
+				"status":     item.Status,
+				"reason":     item.Reason,
+				"send_at":    item.SendAt.Format(time.DateTime),
+				"created_at": item.CreatedAt.Format(time.DateTime),
+			}
+		}),
+	})
+}
+
+type CancelScheduleRequest struct {
+	Id int `form:"id" json:"id" binding:"required,gt=0"`
+}
+
+// Cancel 取消定时消息
+func (c *Schedule) Cancel(ctx *core.Context) error {
+	in := &CancelScheduleRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkScheduleService.Cancel(ctx.Ctx(), ctx.UserId(), in.Id); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type RescheduleRequest struct {
+	Id     int    `form:"id" json:"id" binding:"required,gt=0"`
+	SendAt string `form:"send_at" json:"send_at" binding:"required,datetime=2006-01-02 15:04:05"` // 计划发送时间
+}
+
+// Reschedule 修改定时消息发送时间
+func (c *Schedule) Reschedule(ctx *core.Context) error {
+	in := &RescheduleRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkScheduleService.Reschedule(ctx.Ctx(), ctx.UserId(), in.Id, timeutil.ParseDateTime(in.SendAt)); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
@@ -30,6 +30,7 @@ type Session struct {
 	AuthService          service.IAuthService
 	ContactService       service.IContactService
 	ClientConnectService service.IClientConnectService
+	MessageTtlService    service.ITalkMessageTtlService
 }
 
 // Create 创建会话列表
@@ -201,16 +202,18 @@ func (c *Session) List(ctx *core.Context) error {
 	items := make([]*web.TalkSessionItem, 0)
 	for _, item := range data {
 		value := &web.TalkSessionItem{
-			Id:        int32(item.Id),
-			TalkMode:  int32(item.TalkMode),
-			ToFromId:  int32(item.ToFromId),
-			IsTop:     int32(item.IsTop),
-			IsDisturb: int32(item.IsDisturb),
-			IsRobot:   int32(item.IsRobot),
-			Avatar:    item.Avatar,
-			MsgText:   "...",
-			UpdatedAt: timeutil.FormatDatetime(item.UpdatedAt),
-			UnreadNum: int32(c.UnreadStorage.Get(ctx.Ctx(), uid, item.TalkMode, item.ToFromId)),
+			Id:         int32(item.Id),
+			TalkMode:   int32(item.TalkMode),
+			ToFromId:   int32(item.ToFromId),
+			IsTop:      int32(item.IsTop),
+			IsDisturb:  int32(item.IsDisturb),
+			IsRobot:    int32(item.IsRobot),
+			Avatar:     item.Avatar,
+			MsgText:    "...",
+			UpdatedAt:  timeutil.FormatDatetime(item.UpdatedAt),
+			UnreadNum:  int32(c.UnreadStorage.Get(ctx.Ctx(), uid, item.TalkMode, item.ToFromId)),
+			Draft:      item.Draft,
+			MentionNum: int32(item.MentionNum),
 		}
 
 		if item.TalkMode == entity.ChatPrivateMode {
@@ -240,7 +243,83 @@ func (c *Session) ClearUnreadMessage(ctx *core.Context) error {
 		return ctx.InvalidParams(err)
 	}
 
-	c.UnreadStorage.Reset(ctx.Ctx(), ctx.UserId(), int(in.TalkMode), int(in.ToFromId))
+	if err := c.TalkService.ClearUnreadMessage(ctx.Ctx(), ctx.UserId(), int(in.TalkMode), int(in.ToFromId)); err != nil {
+		return ctx.Error(err)
+	}
 
 	return ctx.Success(&web.TalkSessionClearUnreadNumResponse{})
 }
+
+type TalkSessionDraftRequest struct {
+	TalkMode int    `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	ToFromId int    `form:"to_from_id" json:"to_from_id" binding:"required,numeric,gt=0"`
+	Content  string `form:"content" json:"content" binding:"max=5000"` // 草稿内容(为空表示清除草稿)
+}
+
+// SaveDraft 保存会话草稿
+func (c *Session) SaveDraft(ctx *core.Context) error {
+	in := &TalkSessionDraftRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkSessionService.SaveDraft(ctx.Ctx(), &service.TalkSessionDraftOpt{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		ToFromId: in.ToFromId,
+		Content:  strings.TrimSpace(in.Content),
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
+
+type TalkMessageTtlRequest struct {
+	TalkMode int `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	ToFromId int `form:"to_from_id" json:"to_from_id" binding:"required,numeric,gt=0"`
+}
+
+// MessageTtl 获取会话消息自毁时长
+func (c *Session) MessageTtl(ctx *core.Context) error {
+	in := &TalkMessageTtlRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	ttl, err := c.MessageTtlService.Get(ctx.Ctx(), ctx.UserId(), in.TalkMode, in.ToFromId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"ttl": ttl})
+}
+
+type TalkMessageTtlUpdateRequest struct {
+	TalkMode int `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"`
+	ToFromId int `form:"to_from_id" json:"to_from_id" binding:"required,numeric,gt=0"`
+	Ttl      int `form:"ttl" json:"ttl" binding:"oneof=0 3600 86400 604800"` // 消息自毁时长(秒)[0:关闭;3600:1小时;86400:1天;604800:7天;]
+}
+
+// UpdateMessageTtl 设置会话消息自毁时长
+func (c *Session) UpdateMessageTtl(ctx *core.Context) error {
+	in := &TalkMessageTtlUpdateRequest{}
+	if err := ctx.Context.ShouldBind(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if in.TalkMode == entity.ChatPrivateMode && in.ToFromId == ctx.UserId() {
+		return ctx.Error(entity.ErrPermissionDenied)
+	}
+
+	if err := c.MessageTtlService.Update(ctx.Ctx(), &service.TalkMessageTtlOption{
+		UserId:   ctx.UserId(),
+		TalkMode: in.TalkMode,
+		ToFromId: in.ToFromId,
+		Ttl:      in.Ttl,
+	}); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{})
+}
@@ -0,0 +1,48 @@
+package talk
+
+import (
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/service"
+)
+
+type Urgent struct {
+	TalkUrgentService service.ITalkUrgentService
+}
+
+type UrgentAckRequest struct {
+	MsgId string `json:"msg_id" binding:"required"` // 紧急消息ID
+}
+
+// Ack 确认紧急消息，确认后不再重复提醒
+func (c *Urgent) Ack(ctx *core.Context) error {
+	in := &UrgentAckRequest{}
+	if err := ctx.Context.ShouldBindJSON(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	if err := c.TalkUrgentService.Ack(ctx.Ctx(), ctx.UserId(), in.MsgId); err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(nil)
+}
+
+type UrgentAcksRequest struct {
+	TalkMode int    `form:"talk_mode" json:"talk_mode" binding:"required,oneof=1 2"` // 对话类型 1:私聊 2:群聊
+	MsgId    string `form:"msg_id" json:"msg_id" binding:"required"`                 // 紧急消息ID
+}
+
+// Acks 发送者查看紧急消息的确认情况
+func (c *Urgent) Acks(ctx *core.Context) error {
+	in := &UrgentAcksRequest{}
+	if err := ctx.Context.ShouldBindQuery(in); err != nil {
+		return ctx.InvalidParams(err)
+	}
+
+	items, err := c.TalkUrgentService.Acks(ctx.Ctx(), ctx.UserId(), in.TalkMode, in.MsgId)
+	if err != nil {
+		return ctx.Error(err)
+	}
+
+	return ctx.Success(map[string]any{"items": items})
+}
@@ -30,6 +30,14 @@ var ProviderSet = wire.NewSet(
 	wire.Struct(new(talk.Message), "*"),
 	wire.Struct(new(talk.Records), "*"),
 	wire.Struct(new(talk.Publish), "*"),
+	wire.Struct(new(talk.Schedule), "*"),
+	wire.Struct(new(talk.Export), "*"),
+	wire.Struct(new(talk.Broadcast), "*"),
+	wire.Struct(new(talk.QuickReply), "*"),
+	wire.Struct(new(talk.Urgent), "*"),
+	wire.Struct(new(talk.LiveLocation), "*"),
+	wire.Struct(new(talk.Favorite), "*"),
+	wire.Struct(new(talk.Mention), "*"),
 
 	wire.Struct(new(article.Article), "*"),
 	wire.Struct(new(article.Annex), "*"),
@@ -117,23 +117,96 @@ func RegisterWebRoute(secret string, router *gin.Engine, handler *web.Handler, s
 
 		talk := v1.Group("/talk").Use(authorize)
 		{
-			talk.GET("/list", core.HandlerFunc(handler.V1.Talk.List))                                   // 会话列表
-			talk.POST("/create", core.HandlerFunc(handler.V1.Talk.Create))                              // 创建会话
-			talk.POST("/delete", core.HandlerFunc(handler.V1.Talk.Delete))                              // 删除会话
-			talk.POST("/topping", core.HandlerFunc(handler.V1.Talk.Top))                                // 置顶会话
-			talk.POST("/disturb", core.HandlerFunc(handler.V1.Talk.Disturb))                            // 会话免打扰
-			talk.GET("/records", core.HandlerFunc(handler.V1.TalkRecords.GetRecords))                   // 会话面板记录
-			talk.GET("/history-records", core.HandlerFunc(handler.V1.TalkRecords.SearchHistoryRecords)) // 历史会话记录
-			talk.GET("/forward-records", core.HandlerFunc(handler.V1.TalkRecords.GetForwardRecords))    // 会话转发记录
-			talk.GET("/file-download", core.HandlerFunc(handler.V1.TalkRecords.Download))               // 下载文件
-			talk.POST("/clear-unread", core.HandlerFunc(handler.V1.Talk.ClearUnreadMessage))            // 清除会话未读数
+			talk.GET("/list", core.HandlerFunc(handler.V1.Talk.List))                                     // 会话列表
+			talk.POST("/create", core.HandlerFunc(handler.V1.Talk.Create))                                // 创建会话
+			talk.POST("/delete", core.HandlerFunc(handler.V1.Talk.Delete))                                // 删除会话
+			talk.POST("/topping", core.HandlerFunc(handler.V1.Talk.Top))                                  // 置顶会话
+			talk.POST("/disturb", core.HandlerFunc(handler.V1.Talk.Disturb))                              // 会话免打扰
+			talk.POST("/draft/save", core.HandlerFunc(handler.V1.Talk.SaveDraft))                         // 保存会话草稿
+			talk.GET("/message-ttl", core.HandlerFunc(handler.V1.Talk.MessageTtl))                        // 获取消息自毁时长
+			talk.POST("/message-ttl/update", core.HandlerFunc(handler.V1.Talk.UpdateMessageTtl))          // 设置消息自毁时长
+			talk.GET("/records", core.HandlerFunc(handler.V1.TalkRecords.GetRecords))                     // 会话面板记录
+			talk.GET("/history-records", core.HandlerFunc(handler.V1.TalkRecords.SearchHistoryRecords))   // 历史会话记录
+			talk.GET("/search-records", core.HandlerFunc(handler.V1.TalkRecords.SearchRecords))           // 全文检索会话消息
+			talk.GET("/mentions", core.HandlerFunc(handler.V1.TalkMention.List))                          // 提及我的消息
+			talk.POST("/sync", core.HandlerFunc(handler.V1.TalkRecords.Sync))                             // 增量同步会话消息
+			talk.GET("/forward-records", core.HandlerFunc(handler.V1.TalkRecords.GetForwardRecords))      // 会话转发记录
+			talk.GET("/file-download", core.HandlerFunc(handler.V1.TalkRecords.Download))                 // 下载文件
+			talk.POST("/clear-unread", core.HandlerFunc(handler.V1.Talk.ClearUnreadMessage))              // 清除会话未读数
+			talk.GET("/thread/records", core.HandlerFunc(handler.V1.TalkRecords.GetThreadRecords))        // 话题回复记录
+			talk.POST("/thread/clear-unread", core.HandlerFunc(handler.V1.TalkRecords.ClearThreadUnread)) // 清除话题未读数
 		}
 
 		talkMessage := v1.Group("/talk/message").Use(authorize)
 		{
-			talkMessage.POST("/send", core.HandlerFunc(handler.V1.Message.Send))         // 发送文本消息
-			talkMessage.POST("/revoke", core.HandlerFunc(handler.V1.TalkMessage.Revoke)) // 撤销聊天消息
-			talkMessage.POST("/delete", core.HandlerFunc(handler.V1.TalkMessage.Delete)) // 删除聊天消息
+			talkMessage.POST("/send", core.HandlerFunc(handler.V1.Message.Send))                          // 发送文本消息
+			talkMessage.POST("/revoke", core.HandlerFunc(handler.V1.TalkMessage.Revoke))                  // 撤销聊天消息
+			talkMessage.POST("/delete", core.HandlerFunc(handler.V1.TalkMessage.Delete))                  // 删除聊天消息
+			talkMessage.POST("/edit", core.HandlerFunc(handler.V1.TalkMessage.Edit))                      // 编辑聊天消息
+			talkMessage.GET("/edit-records", core.HandlerFunc(handler.V1.TalkMessage.EditRecords))        // 聊天消息编辑记录
+			talkMessage.POST("/reaction/add", core.HandlerFunc(handler.V1.TalkMessage.AddReaction))       // 添加消息表态
+			talkMessage.POST("/reaction/remove", core.HandlerFunc(handler.V1.TalkMessage.RemoveReaction)) // 取消消息表态
+			talkMessage.GET("/read-users", core.HandlerFunc(handler.V1.TalkMessage.ReadUsers))            // 群消息已读用户列表
+			talkMessage.POST("/pin", core.HandlerFunc(handler.V1.TalkMessage.Pin))                        // 置顶聊天消息
+			talkMessage.POST("/unpin", core.HandlerFunc(handler.V1.TalkMessage.Unpin))                    // 取消置顶聊天消息
+			talkMessage.GET("/pin/list", core.HandlerFunc(handler.V1.TalkMessage.PinList))                // 会话置顶消息列表
+		}
+
+		talkSchedule := v1.Group("/talk/message/schedule").Use(authorize)
+		{
+			talkSchedule.POST("/create", core.HandlerFunc(handler.V1.TalkSchedule.Create))         // 创建定时消息
+			talkSchedule.GET("/list", core.HandlerFunc(handler.V1.TalkSchedule.List))              // 定时消息列表
+			talkSchedule.POST("/cancel", core.HandlerFunc(handler.V1.TalkSchedule.Cancel))         // 取消定时消息
+			talkSchedule.POST("/reschedule", core.HandlerFunc(handler.V1.TalkSchedule.Reschedule)) // 修改定时消息发送时间
+		}
+
+		talkExport := v1.Group("/talk/export").Use(authorize)
+		{
+			talkExport.POST("/create", core.HandlerFunc(handler.V1.TalkExport.Create))    // 创建聊天记录导出任务
+			talkExport.GET("/list", core.HandlerFunc(handler.V1.TalkExport.List))         // 聊天记录导出任务列表
+			talkExport.GET("/download", core.HandlerFunc(handler.V1.TalkExport.Download)) // 下载聊天记录导出文件
+		}
+
+		talkBroadcast := v1.Group("/talk/broadcast").Use(authorize)
+		{
+			talkBroadcast.POST("/create", core.HandlerFunc(handler.V1.TalkBroadcast.Create)) // 创建群发列表
+			talkBroadcast.POST("/update", core.HandlerFunc(handler.V1.TalkBroadcast.Update)) // 修改群发列表
+			talkBroadcast.POST("/delete", core.HandlerFunc(handler.V1.TalkBroadcast.Delete)) // 删除群发列表
+			talkBroadcast.GET("/list", core.HandlerFunc(handler.V1.TalkBroadcast.List))      // 群发列表
+			talkBroadcast.POST("/send", core.HandlerFunc(handler.V1.TalkBroadcast.Send))     // 向群发列表发送消息
+			talkBroadcast.GET("/logs", core.HandlerFunc(handler.V1.TalkBroadcast.Logs))      // 群发记录
+		}
+
+		talkQuickReply := v1.Group("/talk/quick-reply").Use(authorize)
+		{
+			talkQuickReply.POST("/create", core.HandlerFunc(handler.V1.TalkQuickReply.Create)) // 创建快捷回复
+			talkQuickReply.POST("/update", core.HandlerFunc(handler.V1.TalkQuickReply.Update)) // 修改快捷回复
+			talkQuickReply.POST("/delete", core.HandlerFunc(handler.V1.TalkQuickReply.Delete)) // 删除快捷回复
+			talkQuickReply.GET("/list", core.HandlerFunc(handler.V1.TalkQuickReply.List))      // 快捷回复列表
+			talkQuickReply.POST("/send", core.HandlerFunc(handler.V1.TalkQuickReply.Send))     // 发送快捷回复
+		}
+
+		talkUrgent := v1.Group("/talk/message/urgent").Use(authorize)
+		{
+			talkUrgent.POST("/ack", core.HandlerFunc(handler.V1.TalkUrgent.Ack))  // 确认紧急消息
+			talkUrgent.GET("/acks", core.HandlerFunc(handler.V1.TalkUrgent.Acks)) // 紧急消息确认情况
+		}
+
+		talkLiveLocation := v1.Group("/talk/live-location").Use(authorize)
+		{
+			talkLiveLocation.POST("/start", core.HandlerFunc(handler.V1.TalkLiveLocation.Start)) // 开始共享实时位置
+			talkLiveLocation.POST("/stop", core.HandlerFunc(handler.V1.TalkLiveLocation.Stop))   // 结束共享实时位置
+			talkLiveLocation.GET("/list", core.HandlerFunc(handler.V1.TalkLiveLocation.List))    // 会话中共享中的实时位置
+		}
+
+		talkFavorite := v1.Group("/talk/favorite").Use(authorize)
+		{
+			talkFavorite.GET("/list", core.HandlerFunc(handler.V1.TalkFavorite.List))               // 收藏列表
+			talkFavorite.GET("/tags", core.HandlerFunc(handler.V1.TalkFavorite.Tags))               // 收藏标签列表
+			talkFavorite.POST("/create", core.HandlerFunc(handler.V1.TalkFavorite.Create))          // 收藏消息
+			talkFavorite.POST("/delete", core.HandlerFunc(handler.V1.TalkFavorite.Delete))          // 删除收藏
+			talkFavorite.POST("/tags/update", core.HandlerFunc(handler.V1.TalkFavorite.UpdateTags)) // 修改收藏标签
+			talkFavorite.POST("/send", core.HandlerFunc(handler.V1.TalkFavorite.Send))              // 发送收藏到会话
 		}
 
 		emoticon := v1.Group("/emoticon").Use(authorize)
@@ -2,28 +2,93 @@ package business
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/redis/go-redis/v9"
+	"go-chat/config"
 	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/bus"
 	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/repository/cache"
+	"go-chat/internal/repository/repo"
 )
 
 type PushMessage struct {
-	Redis *redis.Client
+	Bus             bus.IBus
+	Config          *config.Config
+	ClientStorage   *cache.ClientStorage
+	GroupMemberRepo *repo.GroupMember
 }
 
+// Push 发布到指定主题(entity.ImTopicChat 为广播到所有节点)
 func (m *PushMessage) Push(ctx context.Context, topic string, body *entity.SubscribeMessage) error {
-	m.Redis.Publish(ctx, topic, jsonutil.Encode(body))
-	return nil
+	return m.Bus.Publish(ctx, topic, jsonutil.Marshal(body))
 }
 
 func (m *PushMessage) MultiPush(ctx context.Context, topic string, items []*entity.SubscribeMessage) error {
-	pipe := m.Redis.Pipeline()
+	payloads := make([][]byte, 0, len(items))
+	for _, body := range items {
+		payloads = append(payloads, jsonutil.Marshal(body))
+	}
+
+	return m.Bus.Publish(ctx, topic, payloads...)
+}
+
+// PushUsers 推送给指定用户，仅发布到用户在线节点的私有主题，用户均不在线时不推送
+func (m *PushMessage) PushUsers(ctx context.Context, uids []int, body *entity.SubscribeMessage) error {
+	return m.MultiPushUsers(ctx, uids, []*entity.SubscribeMessage{body})
+}
 
+// MultiPushUsers 批量推送给指定用户，同一节点的消息一次发布以保证顺序
+func (m *PushMessage) MultiPushUsers(ctx context.Context, uids []int, items []*entity.SubscribeMessage) error {
+	sids, err := m.ClientStorage.GetServerIds(ctx, entity.ImChannelChat, uids)
+	if err != nil {
+		// 无法确定用户所在节点时退化为广播
+		return m.MultiPush(ctx, entity.ImTopicChat, items)
+	}
+
+	if len(sids) == 0 {
+		return nil
+	}
+
+	payloads := make([][]byte, 0, len(items))
 	for _, body := range items {
-		pipe.Publish(ctx, topic, jsonutil.Encode(body))
+		payloads = append(payloads, jsonutil.Marshal(body))
+	}
+
+	for _, sid := range sids {
+		if err := m.Bus.Publish(ctx, fmt.Sprintf(entity.ImTopicChatPrivate, sid), payloads...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PushGroup 推送群聊事件，群成员数超过 broadcast_group_size 时广播到所有节点，否则仅发布到成员在线的节点
+func (m *PushMessage) PushGroup(ctx context.Context, groupId int, body *entity.SubscribeMessage) error {
+	return m.MultiPushGroup(ctx, groupId, []*entity.SubscribeMessage{body})
+}
+
+// MultiPushGroup 批量推送群聊事件
+func (m *PushMessage) MultiPushGroup(ctx context.Context, groupId int, items []*entity.SubscribeMessage) error {
+	uids := m.GroupMemberRepo.GetMemberIds(ctx, groupId)
+	if len(uids) > m.Config.Server.GetBroadcastGroupSize() {
+		return m.MultiPush(ctx, entity.ImTopicChat, items)
+	}
+
+	return m.MultiPushUsers(ctx, uids, items)
+}
+
+// PushTalk 推送会话事件，私聊推送给会话双方，群聊推送给群成员
+func (m *PushMessage) PushTalk(ctx context.Context, talkMode int, fromId int, toFromId int, body *entity.SubscribeMessage) error {
+	return m.MultiPushTalk(ctx, talkMode, fromId, toFromId, []*entity.SubscribeMessage{body})
+}
+
+// MultiPushTalk 批量推送会话事件
+func (m *PushMessage) MultiPushTalk(ctx context.Context, talkMode int, fromId int, toFromId int, items []*entity.SubscribeMessage) error {
+	if talkMode == entity.ChatGroupMode {
+		return m.MultiPushGroup(ctx, toFromId, items)
 	}
 
-	_, err := pipe.Exec(ctx)
-	return err
+	return m.MultiPushUsers(ctx, []int{fromId, toFromId}, items)
 }
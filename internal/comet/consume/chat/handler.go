@@ -15,14 +15,17 @@ import (
 var handlers map[string]func(ctx context.Context, data []byte)
 
 type Handler struct {
-	Config               *config.Config
-	OrganizeRepo         *repo.Organize
-	UserRepo             *repo.Users
-	Source               *repo.Source
-	TalkRecordsService   service.ITalkRecordService
-	ContactService       service.IContactService
-	ClientConnectService service.IClientConnectService
-	RoomStorage          *socket.RoomStorage
+	Config                  *config.Config
+	OrganizeRepo            *repo.Organize
+	UserRepo                *repo.Users
+	TalkMessageReactionRepo *repo.TalkMessageReaction
+	Source                  *repo.Source
+	TalkRecordsService      service.ITalkRecordService
+	TalkService             service.ITalkService
+	ContactService          service.IContactService
+	ClientConnectService    service.IClientConnectService
+	RoomStorage             *socket.RoomStorage
+	LiveLocationTimer       *LiveLocationTimer
 }
 
 func (h *Handler) init() {
@@ -31,6 +34,15 @@ func (h *Handler) init() {
 	handlers[entity.SubEventImMessage] = h.onConsumeTalk
 	handlers[entity.SubEventImMessageKeyboard] = h.onConsumeTalkKeyboard
 	handlers[entity.SubEventImMessageRevoke] = h.onConsumeTalkRevoke
+	handlers[entity.SubEventImMessageEdit] = h.onConsumeTalkEdit
+	handlers[entity.SubEventImMessageReaction] = h.onConsumeTalkReaction
+	handlers[entity.SubEventImMessageRead] = h.onConsumeTalkRead
+	handlers[entity.SubEventImMessageDelivered] = h.onConsumeTalkDelivered
+	handlers[entity.SubEventImMessagePin] = h.onConsumeTalkPin
+	handlers[entity.SubEventImMessagePreview] = h.onConsumeTalkPreview
+	handlers[entity.SubEventImMessageUrgent] = h.onConsumeTalkUrgent
+	handlers[entity.SubEventImLiveLocation] = h.onConsumeLiveLocation
+	handlers[entity.SubEventImTalkDraft] = h.onConsumeTalkDraft
 	handlers[entity.SubEventContactStatus] = h.onConsumeContactStatus
 	handlers[entity.SubEventContactApply] = h.onConsumeContactApply
 	handlers[entity.SubEventGroupJoin] = h.onConsumeGroupJoin
@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/timewheel"
+	"go-chat/internal/service"
+)
+
+// LiveLocationTimer 位置共享到期时间轮
+//
+// 每个节点都会收到位置共享开始通知并加入时间轮，到期后由抢占成功的节点结束共享
+type LiveLocationTimer struct {
+	service   service.ITalkLiveLocationService
+	timeWheel *timewheel.SimpleTimeWheel[int]
+}
+
+func NewLiveLocationTimer(service service.ITalkLiveLocationService) *LiveLocationTimer {
+	timer := &LiveLocationTimer{service: service}
+	timer.timeWheel = timewheel.NewSimpleTimeWheel[int](1*time.Second, 60, timer.handle)
+	return timer
+}
+
+// Setup 启动时间轮，并恢复服务重启前共享中的位置
+func (l *LiveLocationTimer) Setup(ctx context.Context) error {
+
+	log.Println("Start LiveLocationTimer")
+
+	go l.timeWheel.Start()
+
+	items, err := l.service.FindAllSharing(ctx)
+	if err != nil {
+		logger.Errorf("LiveLocationTimer FindAllSharing err: %s", err.Error())
+	}
+
+	for _, item := range items {
+		l.Add(item.Id, item.ExpiredAt)
+	}
+
+	<-ctx.Done()
+
+	l.timeWheel.Stop()
+
+	return nil
+}
+
+// Add 添加位置共享到期任务
+func (l *LiveLocationTimer) Add(id int, expiredAt time.Time) {
+	l.timeWheel.Add(strconv.Itoa(id), id, time.Until(expiredAt))
+}
+
+func (l *LiveLocationTimer) handle(_ *timewheel.SimpleTimeWheel[int], _ string, id int) {
+	if err := l.service.Expire(context.Background(), id); err != nil {
+		logger.Errorf("LiveLocationTimer Expire %d err: %s", id, err.Error())
+	}
+}
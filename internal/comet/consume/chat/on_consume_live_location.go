@@ -0,0 +1,60 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 实时位置共享
+func (h *Handler) onConsumeLiveLocation(ctx context.Context, body []byte) {
+	var in entity.SubEventLiveLocationPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeLiveLocation Unmarshal err: %s", err.Error())
+		return
+	}
+
+	if in.Action == "start" {
+		h.LiveLocationTimer.Add(in.Id, time.Unix(in.ExpiredAt, 0))
+	}
+
+	var clientIds []int64
+	if in.TalkMode == entity.ChatGroupMode {
+		clientIds = h.RoomStorage.GetClientIDAll(int32(in.ToFromId))
+	} else {
+		for _, uid := range []int{in.UserId, in.ToFromId} {
+			ids, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), uid)
+			clientIds = append(clientIds, ids...)
+		}
+	}
+
+	if len(clientIds) == 0 {
+		return
+	}
+
+	c := socket.NewSenderContent()
+	c.SetReceive(clientIds...)
+
+	// 位置更新频繁且只关心最新位置，无需客户端确认
+	if in.Action != "update" {
+		c.SetAck(true)
+	}
+
+	c.SetMessage(entity.PushEventImLiveLocation, entity.ImLiveLocationPayload{
+		Id:        in.Id,
+		Action:    in.Action,
+		TalkMode:  in.TalkMode,
+		FromId:    in.UserId,
+		ToFromId:  in.ToFromId,
+		Longitude: in.Longitude,
+		Latitude:  in.Latitude,
+		ExpiredAt: in.ExpiredAt,
+	})
+
+	socket.Session.Chat.Write(c)
+}
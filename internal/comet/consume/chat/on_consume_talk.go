@@ -49,9 +49,11 @@ func (h *Handler) onConsumeTalkPrivateMessage(ctx context.Context, in entity.Sub
 		MsgType:   message.MsgType,
 		FromId:    message.FromId,
 		IsRevoked: message.IsRevoked,
+		IsEdited:  model.No,
 		SendTime:  message.CreatedAt.Format(time.DateTime),
 		Extra:     message.Extra,
 		Quote:     message.Quote,
+		IsUrgent:  in.IsUrgent,
 	}
 
 	if body.FromId > 0 {
@@ -68,6 +70,17 @@ func (h *Handler) onConsumeTalkPrivateMessage(ctx context.Context, in entity.Sub
 	c.SetReceive(clientIds...)
 	c.SetAck(true)
 
+	if message.UserId == message.FromId {
+		body.DeliveryStatus = model.MessageDeliverySent
+	} else if message.FromId > 0 {
+		// 接收者客户端确认收到消息后标记为已送达
+		c.SetAckCallback(func(_ int64, uid int) {
+			if err := h.TalkService.MarkDelivered(context.Background(), uid, message.OrgMsgId); err != nil {
+				logger.Errorf("onConsumeTalkPrivateMessage MarkDelivered err: %s", err.Error())
+			}
+		})
+	}
+
 	c.SetMessage(entity.PushEventImMessage, entity.ImMessagePayload{
 		TalkMode: entity.ChatPrivateMode,
 		ToFromId: message.ToFromId,
@@ -97,9 +110,12 @@ func (h *Handler) onConsumeTalkGroupMessage(ctx context.Context, in entity.SubEv
 		MsgType:   message.MsgType,
 		FromId:    message.FromId,
 		IsRevoked: message.IsRevoked,
+		IsEdited:  model.No,
 		SendTime:  message.SendTime.Format(time.DateTime),
 		Extra:     message.Extra,
 		Quote:     message.Quote,
+		ThreadId:  message.ThreadId,
+		IsUrgent:  in.IsUrgent,
 	}
 
 	if data.FromId > 0 {
@@ -0,0 +1,38 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+	"go-chat/internal/repository/model"
+)
+
+// 聊天消息送达
+func (h *Handler) onConsumeTalkDelivered(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkDeliveredPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkDelivered Unmarshal err: %s", err.Error())
+		return
+	}
+
+	clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), in.UserId)
+	if len(clientIds) == 0 {
+		return
+	}
+
+	c := socket.NewSenderContent()
+	c.SetReceive(clientIds...)
+	c.SetMessage(entity.PushEventImMessageDelivered, entity.ImMessageDeliveredPayload{
+		TalkMode:    entity.ChatPrivateMode,
+		ToFromId:    in.ToFromId,
+		MsgId:       in.MsgId,
+		Status:      model.MessageDeliveryDelivered,
+		DeliveredAt: in.DeliveredAt,
+	})
+
+	socket.Session.Chat.Write(c)
+}
@@ -0,0 +1,36 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 会话草稿同步
+func (h *Handler) onConsumeTalkDraft(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkDraftPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkDraft Unmarshal err: %s", err.Error())
+		return
+	}
+
+	clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), in.UserId)
+	if len(clientIds) == 0 {
+		return
+	}
+
+	c := socket.NewSenderContent()
+	c.SetReceive(clientIds...)
+	c.SetMessage(entity.PushEventImTalkDraft, entity.ImTalkDraftPayload{
+		TalkMode:  in.TalkMode,
+		ToFromId:  in.ToFromId,
+		Content:   in.Content,
+		UpdatedAt: in.UpdatedAt,
+	})
+
+	socket.Session.Chat.Write(c)
+}
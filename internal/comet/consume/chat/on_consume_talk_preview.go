@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 聊天消息链接预览
+func (h *Handler) onConsumeTalkPreview(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkPreviewPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkPreview Unmarshal err: %s", err.Error())
+		return
+	}
+
+	if in.TalkMode == entity.ChatPrivateMode {
+		record, err := h.TalkRecordsService.FindPrivateRecordByMsgId(ctx, in.MsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkPreview FindPrivateRecordByMsgId err: %s", err.Error())
+			return
+		}
+
+		records, err := h.TalkRecordsService.FindAllPrivateRecordByOriMsgId(ctx, record.OrgMsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkPreview FindAllPrivateRecordByOriMsgId err: %s", err.Error())
+			return
+		}
+
+		for _, record := range records {
+			clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), record.UserId)
+			if len(clientIds) == 0 {
+				continue
+			}
+
+			c := socket.NewSenderContent()
+			c.SetReceive(clientIds...)
+			c.SetMessage(entity.PushEventImMessagePreview, entity.ImMessagePreviewPayload{
+				TalkMode: entity.ChatPrivateMode,
+				FromId:   record.FromId,
+				ToFromId: record.ToFromId,
+				MsgId:    record.MsgId,
+				Extra:    record.Extra,
+			})
+
+			socket.Session.Chat.Write(c)
+		}
+
+	} else if in.TalkMode == entity.ChatGroupMode {
+		record, err := h.TalkRecordsService.FindTalkGroupRecord(ctx, in.MsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkPreview FindTalkGroupRecord err: %s", err.Error())
+			return
+		}
+
+		clientIds := h.RoomStorage.GetClientIDAll(int32(record.ToFromId))
+		if len(clientIds) == 0 {
+			return
+		}
+
+		c := socket.NewSenderContent()
+		c.SetReceive(clientIds...)
+		c.SetMessage(entity.PushEventImMessagePreview, entity.ImMessagePreviewPayload{
+			TalkMode: record.TalkMode,
+			FromId:   record.FromId,
+			ToFromId: record.ToFromId,
+			MsgId:    record.MsgId,
+			Extra:    record.Extra,
+		})
+
+		socket.Session.Chat.Write(c)
+	}
+}
@@ -0,0 +1,96 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 聊天消息表态
+func (h *Handler) onConsumeTalkReaction(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkReactionPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkReaction Unmarshal err: %s", err.Error())
+		return
+	}
+
+	if in.TalkMode == entity.ChatPrivateMode {
+		record, err := h.TalkRecordsService.FindPrivateRecordByMsgId(ctx, in.MsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkReaction FindPrivateRecordByMsgId err: %s", err.Error())
+			return
+		}
+
+		records, err := h.TalkRecordsService.FindAllPrivateRecordByOriMsgId(ctx, record.OrgMsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkReaction FindAllPrivateRecordByOriMsgId err: %s", err.Error())
+			return
+		}
+
+		msgIds := make([]string, 0, len(records))
+		for _, record := range records {
+			msgIds = append(msgIds, record.MsgId)
+		}
+
+		reactions, err := h.TalkMessageReactionRepo.FindCountByMsgIds(ctx, msgIds)
+		if err != nil {
+			logger.Errorf("onConsumeTalkReaction FindCountByMsgIds err: %s", err.Error())
+			return
+		}
+
+		for _, record := range records {
+			clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), record.UserId)
+			if len(clientIds) == 0 {
+				continue
+			}
+
+			c := socket.NewSenderContent()
+			c.SetAck(true)
+			c.SetReceive(clientIds...)
+			c.SetMessage(entity.PushEventImMessageReaction, entity.ImMessageReactionPayload{
+				TalkMode:  entity.ChatPrivateMode,
+				FromId:    record.FromId,
+				ToFromId:  record.ToFromId,
+				MsgId:     record.MsgId,
+				UserId:    in.UserId,
+				Emoji:     in.Emoji,
+				Action:    in.Action,
+				Reactions: reactions[record.MsgId],
+			})
+
+			socket.Session.Chat.Write(c)
+		}
+
+	} else if in.TalkMode == entity.ChatGroupMode {
+		record, err := h.TalkRecordsService.FindTalkGroupRecord(ctx, in.MsgId)
+		if err != nil {
+			logger.Errorf("onConsumeTalkReaction FindTalkGroupRecord err: %s", err.Error())
+			return
+		}
+
+		clientIds := h.RoomStorage.GetClientIDAll(int32(record.ToFromId))
+		if len(clientIds) == 0 {
+			return
+		}
+
+		c := socket.NewSenderContent()
+		c.SetAck(true)
+		c.SetReceive(clientIds...)
+		c.SetMessage(entity.PushEventImMessageReaction, entity.ImMessageReactionPayload{
+			TalkMode:  record.TalkMode,
+			FromId:    record.FromId,
+			ToFromId:  record.ToFromId,
+			MsgId:     record.MsgId,
+			UserId:    in.UserId,
+			Emoji:     in.Emoji,
+			Action:    in.Action,
+			Reactions: record.Reactions,
+		})
+
+		socket.Session.Chat.Write(c)
+	}
+}
@@ -0,0 +1,47 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 聊天消息已读回执
+func (h *Handler) onConsumeTalkRead(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkReadPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkRead Unmarshal err: %s", err.Error())
+		return
+	}
+
+	// 私聊通知对方，群聊通知已读区间内的消息发送者
+	uids := in.SenderIds
+	if in.TalkMode == entity.ChatPrivateMode {
+		uids = []int{in.ToFromId}
+	}
+
+	for _, uid := range uids {
+		clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), uid)
+		if len(clientIds) == 0 {
+			continue
+		}
+
+		c := socket.NewSenderContent()
+		c.SetAck(true)
+		c.SetReceive(clientIds...)
+		c.SetMessage(entity.PushEventImMessageRead, entity.ImMessageReadPayload{
+			TalkMode: in.TalkMode,
+			UserId:   in.UserId,
+			ToFromId: in.ToFromId,
+			MsgId:    in.MsgId,
+			Sequence: in.Sequence,
+			ReadTime: in.ReadTime,
+		})
+
+		socket.Session.Chat.Write(c)
+	}
+}
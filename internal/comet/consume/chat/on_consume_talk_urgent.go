@@ -0,0 +1,44 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/server"
+)
+
+// 紧急消息重复提醒
+func (h *Handler) onConsumeTalkUrgent(ctx context.Context, body []byte) {
+	var in entity.SubEventTalkUrgentPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		logger.Errorf("[ChatSubscribe] onConsumeTalkUrgent Unmarshal err: %s", err.Error())
+		return
+	}
+
+	clientIds, _ := h.ClientConnectService.GetUidFromClientIds(ctx, server.ID(), socket.Session.Chat.Name(), in.UserId)
+	if len(clientIds) == 0 {
+		return
+	}
+
+	payload := entity.ImMessageUrgentPayload{
+		TalkMode:  in.TalkMode,
+		FromId:    in.FromId,
+		ToFromId:  in.ToFromId,
+		MsgId:     in.MsgId,
+		NotifyNum: in.NotifyNum,
+	}
+
+	if user, err := h.UserRepo.FindByIdWithCache(ctx, in.FromId); err == nil {
+		payload.Nickname = user.Nickname
+	}
+
+	c := socket.NewSenderContent()
+	c.SetAck(true)
+	c.SetReceive(clientIds...)
+	c.SetMessage(entity.PushEventImMessageUrgent, payload)
+
+	socket.Session.Chat.Write(c)
+}
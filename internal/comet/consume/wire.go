@@ -9,6 +9,7 @@ import (
 var ProviderSet = wire.NewSet(
 	NewChatSubscribe,
 	wire.Struct(new(chat.Handler), "*"),
+	chat.NewLiveLocationTimer,
 
 	NewExampleSubscribe,
 	example.NewHandler,
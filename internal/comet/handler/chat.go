@@ -1,19 +1,28 @@
 package handler
 
 import (
+	"io"
 	"log"
+	"net/http"
 
 	"go-chat/internal/comet/handler/event"
 
 	"go-chat/internal/pkg/core"
+	"go-chat/internal/pkg/core/errorx"
 	"go-chat/internal/pkg/core/socket"
 	"go-chat/internal/pkg/core/socket/adapter"
+	"go-chat/internal/pkg/core/socket/adapter/encoding"
+	"go-chat/internal/repository/cache"
 	"go-chat/internal/service"
 )
 
+// ErrSseNotFound 客户端收到该错误后需重新建立 SSE 连接
+var ErrSseNotFound = errorx.New(404, "连接不存在或已断开")
+
 type ChatChannel struct {
 	Storage service.IClientConnectService
 	Event   *event.ChatEvent
+	Replay  *cache.ReplayStorage
 }
 
 // Conn 初始化连接
@@ -24,15 +33,75 @@ func (c *ChatChannel) Conn(ctx *core.Context) error {
 		return err
 	}
 
-	return c.NewClient(ctx.UserId(), conn)
+	return c.NewClient(ctx.UserId(), conn, newChatResume(ctx))
+}
+
+// SseConn 初始化 SSE 连接(websocket 被拦截时的降级方案)
+func (c *ChatChannel) SseConn(ctx *core.Context) error {
+	conn, err := adapter.NewSseAdapter(ctx.Context.Writer, ctx.Context.Request, ctx.UserId())
+	if err != nil {
+		log.Printf("sse connect error: %s", err.Error())
+		return err
+	}
+
+	if err := c.NewClient(ctx.UserId(), conn, newChatResume(ctx)); err != nil {
+		log.Printf("sse connect error: %s", err.Error())
+		_ = conn.Close()
+		return nil
+	}
+
+	// 保持请求直至连接断开
+	conn.Wait()
+
+	return nil
+}
+
+// SseMessage 接收 SSE 客户端上行消息，消息格式与 websocket 文本帧一致
+func (c *ChatChannel) SseMessage(ctx *core.Context) error {
+	conn, ok := adapter.GetSseAdapter(ctx.Context.Param("id"), ctx.UserId())
+	if !ok {
+		return ctx.Error(ErrSseNotFound)
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(ctx.Context.Writer, ctx.Context.Request.Body, encoding.MaxMessageSize))
+	if err != nil {
+		return ctx.InvalidParams(err.Error())
+	}
+
+	if err := conn.Push(data); err != nil {
+		return ctx.Error(ErrSseNotFound)
+	}
+
+	return ctx.Success(nil)
+}
+
+// ChatResume 断线重连参数
+type ChatResume struct {
+	Enable      bool   // 客户端支持断线重连补发(resume=1)，携带会话标识时视为支持
+	Session     string // 恢复的会话标识
+	LastEventId string // 客户端已收到的最后事件ID
+}
+
+func newChatResume(ctx *core.Context) ChatResume {
+	session := ctx.Context.Query("session")
+
+	return ChatResume{
+		Enable:      ctx.Context.Query("resume") == "1" || session != "",
+		Session:     session,
+		LastEventId: ctx.Context.Query("last_event_id"),
+	}
 }
 
-func (c *ChatChannel) NewClient(uid int, conn socket.IConn) error {
+func (c *ChatChannel) NewClient(uid int, conn socket.IConn, resume ChatResume) error {
 	return socket.NewClient(conn, &socket.ClientOption{
-		Uid:     uid,
-		Channel: socket.Session.Chat,
-		Storage: c.Storage,
-		Buffer:  10,
+		Uid:         uid,
+		Channel:     socket.Session.Chat,
+		Storage:     c.Storage,
+		Buffer:      10,
+		Replay:      c.Replay,
+		Resume:      resume.Enable,
+		Session:     resume.Session,
+		LastEventId: resume.LastEventId,
 	}, socket.NewEvent(
 		// 连接成功回调
 		socket.WithOpenEvent(c.Event.OnOpen),
@@ -17,16 +17,18 @@ type handle func(ctx context.Context, client socket.IClient, data []byte)
 var handlers map[string]handle
 
 type Handler struct {
-	Redis         *redis.Client
-	Source        *repo.Source
-	MemberService service.IGroupMemberService
-	PushMessage   *business.PushMessage
+	Redis                   *redis.Client
+	Source                  *repo.Source
+	MemberService           service.IGroupMemberService
+	PushMessage             *business.PushMessage
+	TalkLiveLocationService service.ITalkLiveLocationService
 }
 
 func (h *Handler) init() {
 	handlers = make(map[string]handle)
 	// 注册自定义绑定事件
 	handlers["im.message.keyboard"] = h.onKeyboardMessage
+	handlers["im.live.location.update"] = h.onLiveLocationMessage
 }
 
 func (h *Handler) Call(ctx context.Context, client socket.IClient, event string, data []byte) {
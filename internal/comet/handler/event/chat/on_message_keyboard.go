@@ -25,7 +25,7 @@ func (h *Handler) onKeyboardMessage(ctx context.Context, c socket.IClient, data
 		return
 	}
 
-	_ = h.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+	_ = h.PushMessage.PushUsers(ctx, []int{in.Payload.ToFromId}, &entity.SubscribeMessage{
 		Event: entity.SubEventImMessageKeyboard,
 		Payload: jsonutil.Encode(entity.SubEventImMessageKeyboardPayload{
 			FromId:   c.Uid(),
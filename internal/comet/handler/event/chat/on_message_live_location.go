@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"go-chat/internal/pkg/core/socket"
+	"go-chat/internal/service"
+)
+
+type LiveLocationMessage struct {
+	Event   string `json:"event"`
+	Payload struct {
+		Id        int    `json:"id"`
+		Longitude string `json:"longitude"`
+		Latitude  string `json:"latitude"`
+	} `json:"payload"`
+}
+
+// onLiveLocationMessage 实时位置上报事件
+func (h *Handler) onLiveLocationMessage(ctx context.Context, c socket.IClient, data []byte) {
+	var in LiveLocationMessage
+	if err := json.Unmarshal(data, &in); err != nil {
+		log.Println("Chat onLiveLocationMessage Err: ", err)
+		return
+	}
+
+	if in.Payload.Id <= 0 || in.Payload.Longitude == "" || in.Payload.Latitude == "" {
+		return
+	}
+
+	err := h.TalkLiveLocationService.Update(ctx, &service.TalkLiveLocationUpdateOption{
+		UserId:    c.Uid(),
+		Id:        in.Payload.Id,
+		Longitude: in.Payload.Longitude,
+		Latitude:  in.Payload.Latitude,
+	})
+	if err != nil {
+		log.Println("Chat onLiveLocationMessage Err: ", err)
+	}
+}
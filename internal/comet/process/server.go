@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"sync"
 
+	"go-chat/internal/comet/consume/chat"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -16,8 +17,9 @@ type IServer interface {
 
 // SubServers 订阅的服务列表
 type SubServers struct {
-	HealthSubscribe  *HealthSubscribe  // 注册健康上报
-	MessageSubscribe *MessageSubscribe // 注册消息订阅
+	HealthSubscribe   *HealthSubscribe        // 注册健康上报
+	MessageSubscribe  *MessageSubscribe       // 注册消息订阅
+	LiveLocationTimer *chat.LiveLocationTimer // 位置共享到期时间轮
 	//QueueSubscribe   *QueueSubscribe   // 消息队列服务
 }
 
@@ -5,24 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"time"
 
-	"github.com/redis/go-redis/v9"
-	"github.com/sourcegraph/conc/pool"
 	"go-chat/internal/comet/consume"
 	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/bus"
 	"go-chat/internal/pkg/server"
 	"go-chat/internal/pkg/utils"
 )
 
 type MessageSubscribe struct {
-	redis          *redis.Client
+	bus            bus.IBus
 	defaultConsume *consume.ChatSubscribe
 	exampleConsume *consume.ExampleSubscribe
 }
 
-func NewMessageSubscribe(redis *redis.Client, defaultConsume *consume.ChatSubscribe, exampleConsume *consume.ExampleSubscribe) *MessageSubscribe {
-	return &MessageSubscribe{redis: redis, defaultConsume: defaultConsume, exampleConsume: exampleConsume}
+func NewMessageSubscribe(bus bus.IBus, defaultConsume *consume.ChatSubscribe, exampleConsume *consume.ExampleSubscribe) *MessageSubscribe {
+	return &MessageSubscribe{bus: bus, defaultConsume: defaultConsume, exampleConsume: exampleConsume}
 }
 
 type IConsume interface {
@@ -43,26 +41,21 @@ func (m *MessageSubscribe) Setup(ctx context.Context) error {
 }
 
 func (m *MessageSubscribe) subscribe(ctx context.Context, topic []string, consume IConsume) {
-	sub := m.redis.Subscribe(ctx, topic...)
-	defer func() {
-		_ = sub.Close()
-	}()
-
-	worker := pool.New().WithMaxGoroutines(10)
-
-	for data := range sub.Channel(redis.WithChannelHealthCheckInterval(10 * time.Second)) {
-		data := data
-		worker.Go(func() {
-			m.handle(data, consume)
-		})
+	// 每个节点使用独立的临时分组，广播主题的消息所有节点均会收到
+	group := bus.EphemeralGroup(fmt.Sprintf("comet.%s", server.ID()))
+
+	err := m.bus.Subscribe(ctx, group, topic, func(_ context.Context, msg *bus.Message) error {
+		m.handle(msg.Payload, consume)
+		return nil
+	})
+	if err != nil {
+		log.Println("MessageSubscribe Subscribe Err: ", err.Error())
 	}
-
-	worker.Wait()
 }
 
-func (m *MessageSubscribe) handle(data *redis.Message, consume IConsume) {
+func (m *MessageSubscribe) handle(data []byte, consume IConsume) {
 	var in entity.SubscribeMessage
-	if err := json.Unmarshal([]byte(data.Payload), &in); err != nil {
+	if err := json.Unmarshal(data, &in); err != nil {
 		log.Println("SubscribeContent Unmarshal Err: ", err.Error())
 		return
 	}
@@ -37,6 +37,10 @@ func NewRouter(conf *config.Config, handle *handler.Handler, storage *cache.JwtT
 	router.GET("/wss/default.io", authorize, core.HandlerFunc(handle.Chat.Conn))
 	router.GET("/wss/example.io", authorize, core.HandlerFunc(handle.Example.Conn))
 
+	// websocket 被拦截时的降级连接，下行使用 SSE，上行通过 POST 提交
+	router.GET("/wss/default.sse", authorize, core.HandlerFunc(handle.Chat.SseConn))
+	router.POST("/wss/default.sse/:id", authorize, core.HandlerFunc(handle.Chat.SseMessage))
+
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, map[string]any{"ok": "success"})
 	})
@@ -31,6 +31,7 @@ type AppProvider struct {
 	Coroutine *process.Server
 	Handler   *handler.Handler
 	Providers *provider.Providers
+	TcpServer *TcpServer
 }
 
 func Run(ctx *cli.Context, app *AppProvider) error {
@@ -83,6 +84,11 @@ func start(c chan os.Signal, eg *errgroup.Group, ctx context.Context, app *AppPr
 		return nil
 	})
 
+	// 启动 TCP 服务
+	eg.Go(func() error {
+		return app.TcpServer.Start(ctx)
+	})
+
 	eg.Go(func() (err error) {
 		defer func() {
 			log.Println("Shutting down serv...")
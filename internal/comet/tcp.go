@@ -0,0 +1,127 @@
+package comet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"go-chat/config"
+	"go-chat/internal/comet/handler"
+	"go-chat/internal/pkg/core/middleware"
+	"go-chat/internal/pkg/core/socket/adapter"
+	"go-chat/internal/repository/cache"
+)
+
+// 客户端建立连接后需在该时间内发送握手帧
+const tcpHandshakeTimeout = 10 * time.Second
+
+// TcpServer TCP 长连接服务(桌面端及 IoT 设备使用)
+//
+// 消息帧格式见 adapter/encoding，连接建立后的第一帧为握手帧:
+//
+//	{"token":"jwt token","channel":"chat","resume":true,"session":"","last_event_id":""}
+//
+// 握手成功后与 websocket 客户端一样注册到 chat 渠道，收发相同的 im.* 事件
+type TcpServer struct {
+	Config  *config.Config
+	Handler *handler.Handler
+	Storage *cache.JwtTokenStorage
+}
+
+type TcpHandshake struct {
+	Token       string `json:"token"`
+	Channel     string `json:"channel"`
+	Resume      bool   `json:"resume"`
+	Session     string `json:"session"`
+	LastEventId string `json:"last_event_id"`
+}
+
+// Start 启动 TCP 服务，未配置端口时不启动
+func (t *TcpServer) Start(ctx context.Context) error {
+	if t.Config.Server.Tcp <= 0 {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", t.Config.Server.Tcp))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Tcp Listen Port :%d", t.Config.Server.Tcp)
+
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+
+			log.Printf("tcp accept error: %s", err.Error())
+			continue
+		}
+
+		go t.handle(ctx, conn)
+	}
+}
+
+func (t *TcpServer) handle(ctx context.Context, conn net.Conn) {
+	tcpConn, err := adapter.NewTcpAdapter(conn)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(tcpHandshakeTimeout))
+
+	session, in, err := t.handshake(ctx, tcpConn)
+	if err != nil {
+		_ = tcpConn.Write([]byte(fmt.Sprintf(`{"event":"connect.error","payload":{"message":%q}}`, err.Error())))
+		_ = tcpConn.Close()
+		return
+	}
+
+	// 握手完成后由心跳检测管理连接存活
+	_ = conn.SetReadDeadline(time.Time{})
+
+	if err := t.Handler.Chat.NewClient(session.Uid, tcpConn, handler.ChatResume{
+		Enable:      in.Resume || in.Session != "",
+		Session:     in.Session,
+		LastEventId: in.LastEventId,
+	}); err != nil {
+		log.Printf("tcp connect error: %s", err.Error())
+		_ = tcpConn.Close()
+	}
+}
+
+// 读取握手帧并校验授权信息
+func (t *TcpServer) handshake(ctx context.Context, conn *adapter.TcpAdapter) (*middleware.JSession, *TcpHandshake, error) {
+	data, err := conn.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	in := &TcpHandshake{}
+	if err := json.Unmarshal(data, in); err != nil {
+		return nil, nil, errors.New("握手数据格式错误")
+	}
+
+	if in.Channel != "" && in.Channel != "chat" {
+		return nil, nil, fmt.Errorf("不支持的渠道: %s", in.Channel)
+	}
+
+	session, err := middleware.VerifyToken(ctx, "api", t.Config.Jwt.Secret, in.Token, t.Storage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return session, in, nil
+}
@@ -31,6 +31,8 @@ var ProviderSet = wire.NewSet(
 	event.ProviderSet,
 	consume.ProviderSet,
 
+	wire.Struct(new(TcpServer), "*"),
+
 	// AppProvider
 	wire.Struct(new(AppProvider), "*"),
 )
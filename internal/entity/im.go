@@ -25,16 +25,23 @@ type ImMessagePayload struct {
 }
 
 type ImMessagePayloadBody struct {
-	MsgId     string `json:"msg_id"`
-	Sequence  int    `json:"sequence"`
-	MsgType   int    `json:"msg_type"`
-	FromId    int    `json:"from_id"` // 发送者ID
-	Nickname  string `json:"nickname"`
-	Avatar    string `json:"avatar"`
-	IsRevoked int    `json:"is_revoked"`
-	SendTime  string `json:"send_time"`
-	Extra     any    `json:"extra"` // 额外参数
-	Quote     any    `json:"quote"` // 额外参数
+	MsgId          string `json:"msg_id"`
+	Sequence       int    `json:"sequence"`
+	MsgType        int    `json:"msg_type"`
+	FromId         int    `json:"from_id"` // 发送者ID
+	Nickname       string `json:"nickname"`
+	Avatar         string `json:"avatar"`
+	IsRevoked      int    `json:"is_revoked"`
+	IsEdited       int    `json:"is_edited"`
+	SendTime       string `json:"send_time"`
+	Extra          any    `json:"extra"`                     // 额外参数
+	Quote          any    `json:"quote"`                     // 额外参数
+	Reactions      any    `json:"reactions"`                 // 表态统计
+	ThreadId       string `json:"thread_id"`                 // 话题根消息ID
+	Thread         any    `json:"thread"`                    // 话题信息
+	DeliveryStatus int    `json:"delivery_status,omitempty"` // 投递状态[1:已发送;2:已送达;3:已读;](仅私聊)
+	IsUrgent       bool   `json:"is_urgent,omitempty"`       // 是否为紧急消息(客户端需忽略免打扰设置)
+	Urgent         any    `json:"urgent,omitempty"`          // 紧急消息提醒状态(仅接收者)
 }
 
 // ImContactApplyPayload
@@ -83,3 +90,94 @@ type ImMessageRevokePayload struct {
 	MsgId    string `json:"msg_id"`
 	Remark   string `json:"remark"`
 }
+
+// ImMessageEditPayload im.message.edit
+type ImMessageEditPayload struct {
+	TalkMode int    `json:"talk_mode"`
+	FromId   int    `json:"from_id"`
+	ToFromId int    `json:"to_from_id"`
+	MsgId    string `json:"msg_id"`
+	MsgType  int    `json:"msg_type"`
+	Extra    any    `json:"extra"`
+	EditTime string `json:"edit_time"`
+}
+
+// ImMessagePreviewPayload im.message.preview
+type ImMessagePreviewPayload struct {
+	TalkMode int    `json:"talk_mode"`
+	FromId   int    `json:"from_id"`
+	ToFromId int    `json:"to_from_id"`
+	MsgId    string `json:"msg_id"`
+	Extra    any    `json:"extra"`
+}
+
+// ImMessageUrgentPayload im.message.urgent
+type ImMessageUrgentPayload struct {
+	TalkMode  int    `json:"talk_mode"`
+	FromId    int    `json:"from_id"`
+	ToFromId  int    `json:"to_from_id"`
+	MsgId     string `json:"msg_id"`
+	Nickname  string `json:"nickname"`
+	NotifyNum int    `json:"notify_num"`
+}
+
+// ImLiveLocationPayload im.live.location
+type ImLiveLocationPayload struct {
+	Id        int    `json:"id"`
+	Action    string `json:"action"`
+	TalkMode  int    `json:"talk_mode"`
+	FromId    int    `json:"from_id"`
+	ToFromId  int    `json:"to_from_id"`
+	Longitude string `json:"longitude"`
+	Latitude  string `json:"latitude"`
+	ExpiredAt int64  `json:"expired_at"`
+}
+
+// ImMessageReactionPayload im.message.reaction
+type ImMessageReactionPayload struct {
+	TalkMode  int    `json:"talk_mode"`
+	FromId    int    `json:"from_id"`
+	ToFromId  int    `json:"to_from_id"`
+	MsgId     string `json:"msg_id"`
+	UserId    int    `json:"user_id"`
+	Emoji     string `json:"emoji"`
+	Action    int    `json:"action"`
+	Reactions any    `json:"reactions"`
+}
+
+// ImMessagePinPayload im.message.pin
+type ImMessagePinPayload struct {
+	TalkMode int    `json:"talk_mode"`
+	FromId   int    `json:"from_id"`
+	ToFromId int    `json:"to_from_id"`
+	MsgId    string `json:"msg_id"`
+	UserId   int    `json:"user_id"`
+	Action   int    `json:"action"`
+}
+
+// ImTalkDraftPayload im.talk.draft
+type ImTalkDraftPayload struct {
+	TalkMode  int    `json:"talk_mode"`
+	ToFromId  int    `json:"to_from_id"`
+	Content   string `json:"content"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ImMessageDeliveredPayload im.message.delivered
+type ImMessageDeliveredPayload struct {
+	TalkMode    int    `json:"talk_mode"`
+	ToFromId    int    `json:"to_from_id"`
+	MsgId       string `json:"msg_id"`
+	Status      int    `json:"status"`
+	DeliveredAt string `json:"delivered_at"`
+}
+
+// ImMessageReadPayload im.message.read
+type ImMessageReadPayload struct {
+	TalkMode int    `json:"talk_mode"`
+	UserId   int    `json:"user_id"`
+	ToFromId int    `json:"to_from_id"`
+	MsgId    string `json:"msg_id"`
+	Sequence int64  `json:"sequence"`
+	ReadTime string `json:"read_time"`
+}
@@ -1,13 +1,22 @@
 package entity
 
 const (
-	SubEventImMessage         = "sub.im.message"          // 对话消息通知
-	SubEventImMessageKeyboard = "sub.im.message.keyboard" // 键盘输入事件通知
-	SubEventImMessageRevoke   = "sub.im.message.revoke"   // 聊天消息撤销通知
-	SubEventContactStatus     = "sub.im.contact.status"   // 用户在线状态通知
-	SubEventContactApply      = "sub.im.contact.apply"    // 好友申请消息通知
-	SubEventGroupJoin         = "sub.im.group.join"       // 邀请加入群聊通知
-	SubEventGroupApply        = "sub.im.group.apply"      // 入群申请通知
+	SubEventImMessage          = "sub.im.message"           // 对话消息通知
+	SubEventImMessageKeyboard  = "sub.im.message.keyboard"  // 键盘输入事件通知
+	SubEventImMessageRevoke    = "sub.im.message.revoke"    // 聊天消息撤销通知
+	SubEventImMessageEdit      = "sub.im.message.edit"      // 聊天消息编辑通知
+	SubEventImMessageReaction  = "sub.im.message.reaction"  // 聊天消息表态通知
+	SubEventImMessageRead      = "sub.im.message.read"      // 聊天消息已读通知
+	SubEventImMessageDelivered = "sub.im.message.delivered" // 聊天消息送达通知
+	SubEventImMessagePin       = "sub.im.message.pin"       // 聊天消息置顶通知
+	SubEventImMessagePreview   = "sub.im.message.preview"   // 聊天消息链接预览通知
+	SubEventImMessageUrgent    = "sub.im.message.urgent"    // 紧急消息重复提醒通知
+	SubEventImLiveLocation     = "sub.im.live.location"     // 实时位置共享通知
+	SubEventImTalkDraft        = "sub.im.talk.draft"        // 会话草稿同步通知
+	SubEventContactStatus      = "sub.im.contact.status"    // 用户在线状态通知
+	SubEventContactApply       = "sub.im.contact.apply"     // 好友申请消息通知
+	SubEventGroupJoin          = "sub.im.group.join"        // 邀请加入群聊通知
+	SubEventGroupApply         = "sub.im.group.apply"       // 入群申请通知
 )
 
 type SubscribeMessage struct {
@@ -16,8 +25,9 @@ type SubscribeMessage struct {
 }
 
 type SubEventImMessagePayload struct {
-	TalkMode int    `json:"talk_mode"` // 1 单聊 2 群聊
-	Message  string `json:"message"`   // json 字符串
+	TalkMode int    `json:"talk_mode"`           // 1 单聊 2 群聊
+	Message  string `json:"message"`             // json 字符串
+	IsUrgent bool   `json:"is_urgent,omitempty"` // 是否为紧急消息
 }
 
 type SubEventGroupJoinPayload struct {
@@ -52,3 +62,73 @@ type SubEventTalkRevokePayload struct {
 	MsgId    string `json:"msg_id"`    // 消息ID
 	Remark   string `json:"remark"`
 }
+
+type SubEventTalkEditPayload struct {
+	TalkMode int    `json:"talk_mode"` // 1单聊 2群聊
+	MsgId    string `json:"msg_id"`    // 消息ID
+}
+
+type SubEventTalkPreviewPayload struct {
+	TalkMode int    `json:"talk_mode"` // 1单聊 2群聊
+	MsgId    string `json:"msg_id"`    // 消息ID
+}
+
+type SubEventTalkReactionPayload struct {
+	TalkMode int    `json:"talk_mode"` // 1单聊 2群聊
+	MsgId    string `json:"msg_id"`    // 消息ID
+	UserId   int    `json:"user_id"`   // 表态用户ID
+	Emoji    string `json:"emoji"`     // 表情
+	Action   int    `json:"action"`    // 1 添加 2 取消
+}
+
+type SubEventTalkReadPayload struct {
+	TalkMode  int    `json:"talk_mode"`  // 1单聊 2群聊
+	UserId    int    `json:"user_id"`    // 已读用户ID
+	ToFromId  int    `json:"to_from_id"` // 接收者ID（用户ID 或 群ID）
+	MsgId     string `json:"msg_id"`     // 已读的最后一条消息ID(私聊为发送者的消息ID)
+	Sequence  int64  `json:"sequence"`   // 已读消息时序ID(私聊为发送者的消息时序ID)
+	SenderIds []int  `json:"sender_ids"` // 需要通知的消息发送者ID(群聊)
+	ReadTime  string `json:"read_time"`  // 已读时间
+}
+
+type SubEventTalkDeliveredPayload struct {
+	UserId      int    `json:"user_id"`      // 消息发送者ID
+	ToFromId    int    `json:"to_from_id"`   // 消息接收者ID
+	MsgId       string `json:"msg_id"`       // 发送者信箱中的消息ID
+	DeliveredAt string `json:"delivered_at"` // 送达时间
+}
+
+type SubEventTalkPinPayload struct {
+	TalkMode int    `json:"talk_mode"` // 1单聊 2群聊
+	MsgId    string `json:"msg_id"`    // 消息ID
+	UserId   int    `json:"user_id"`   // 操作人ID
+	Action   int    `json:"action"`    // 1 置顶 2 取消置顶
+}
+
+type SubEventTalkDraftPayload struct {
+	UserId    int    `json:"user_id"`    // 用户ID
+	TalkMode  int    `json:"talk_mode"`  // 1单聊 2群聊
+	ToFromId  int    `json:"to_from_id"` // 接收者ID（用户ID 或 群ID）
+	Content   string `json:"content"`    // 草稿内容(为空表示已清除)
+	UpdatedAt string `json:"updated_at"` // 更新时间
+}
+
+type SubEventTalkUrgentPayload struct {
+	UserId    int    `json:"user_id"`    // 接收者ID
+	TalkMode  int    `json:"talk_mode"`  // 1单聊 2群聊
+	ToFromId  int    `json:"to_from_id"` // 接收者视角的会话ID
+	FromId    int    `json:"from_id"`    // 发送者ID
+	MsgId     string `json:"msg_id"`     // 接收者看到的消息ID
+	NotifyNum int    `json:"notify_num"` // 第几次重复提醒
+}
+
+type SubEventLiveLocationPayload struct {
+	Id        int    `json:"id"`         // 位置共享ID
+	Action    string `json:"action"`     // start:开始共享 update:位置更新 end:结束共享
+	UserId    int    `json:"user_id"`    // 共享者ID
+	TalkMode  int    `json:"talk_mode"`  // 1单聊 2群聊
+	ToFromId  int    `json:"to_from_id"` // 好友ID或群ID
+	Longitude string `json:"longitude"`  // 经度
+	Latitude  string `json:"latitude"`   // 纬度
+	ExpiredAt int64  `json:"expired_at"` // 共享结束时间(时间戳)
+}
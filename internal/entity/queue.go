@@ -1,5 +1,19 @@
 package entity
 
 const (
-	LoginTopic = "im.user.login"
+	LoginTopic       = "im.user.login"
+	LinkPreviewTopic = "im.message.link_preview"
+	TalkExportTopic  = "im.talk.export"
 )
+
+// LinkPreviewPayload 链接预览生成任务
+type LinkPreviewPayload struct {
+	TalkMode int    `json:"talk_mode"` // 1单聊 2群聊
+	MsgId    string `json:"msg_id"`    // 消息ID(私聊为 org_msg_id)
+	Url      string `json:"url"`       // 链接地址
+}
+
+// TalkExportPayload 聊天记录导出任务
+type TalkExportPayload struct {
+	ExportId int `json:"export_id"` // 导出任务ID
+}
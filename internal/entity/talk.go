@@ -7,12 +7,21 @@ const (
 )
 
 const (
-	PushEventImMessage         = "im.message"          // 对话消息推送
-	PushEventImMessageKeyboard = "im.message.keyboard" // 键盘输入事件推送
-	PushEventImMessageRevoke   = "im.message.revoke"   // 聊天消息撤销推送
-	PushEventContactApply      = "im.contact.apply"    // 好友申请消息推送
-	PushEventContactStatus     = "im.contact.status"   // 用户在线状态推送
-	PushEventGroupApply        = "im.group.apply"      // 用户在线状态推送
+	PushEventImMessage          = "im.message"           // 对话消息推送
+	PushEventImMessageKeyboard  = "im.message.keyboard"  // 键盘输入事件推送
+	PushEventImMessageRevoke    = "im.message.revoke"    // 聊天消息撤销推送
+	PushEventImMessageEdit      = "im.message.edit"      // 聊天消息编辑推送
+	PushEventImMessageReaction  = "im.message.reaction"  // 聊天消息表态推送
+	PushEventImMessageRead      = "im.message.read"      // 聊天消息已读推送
+	PushEventImMessageDelivered = "im.message.delivered" // 聊天消息送达推送
+	PushEventImMessagePin       = "im.message.pin"       // 聊天消息置顶推送
+	PushEventImMessagePreview   = "im.message.preview"   // 聊天消息链接预览推送
+	PushEventImMessageUrgent    = "im.message.urgent"    // 紧急消息重复提醒推送(客户端需忽略免打扰设置)
+	PushEventImLiveLocation     = "im.live.location"     // 实时位置共享推送
+	PushEventImTalkDraft        = "im.talk.draft"        // 会话草稿同步推送
+	PushEventContactApply       = "im.contact.apply"     // 好友申请消息推送
+	PushEventContactStatus      = "im.contact.status"    // 用户在线状态推送
+	PushEventGroupApply         = "im.group.apply"       // 用户在线状态推送
 )
 
 // IM消息类型
@@ -46,6 +55,8 @@ const (
 	ChatMsgSysGroupMemberCancelMuted = 1110 // 群成员解除禁言
 	ChatMsgSysGroupNotice            = 1111 // 编辑群公告
 	ChatMsgSysGroupTransfer          = 1113 // 变更群主
+	ChatMsgSysMessagePinned          = 1114 // 置顶消息
+	ChatMsgSysMessageTtl             = 1115 // 消息自毁时长变更
 )
 
 var ChatMsgTypeMapping = map[int]string{
@@ -71,6 +82,8 @@ var ChatMsgTypeMapping = map[int]string{
 	ChatMsgSysGroupCancelMuted:       "[群解除禁言消息]",
 	ChatMsgSysGroupMemberMuted:       "[群成员禁言消息]",
 	ChatMsgSysGroupMemberCancelMuted: "[群成员解除禁言消息]",
+	ChatMsgSysMessagePinned:          "[置顶消息]",
+	ChatMsgSysMessageTtl:             "[消息自毁设置]",
 }
 
 type TalkLastMessage struct {
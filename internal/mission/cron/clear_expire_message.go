@@ -0,0 +1,297 @@
+package cron
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/crontab"
+	"go-chat/internal/pkg/filesystem"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+var _ crontab.ICrontab = (*ClearExpireMessage)(nil)
+
+// ClearExpireMessage 清除已开启消息自毁会话中的过期消息
+type ClearExpireMessage struct {
+	DB         *gorm.DB
+	Filesystem filesystem.IFilesystem
+}
+
+type expireMessageFile struct {
+	Bucket string
+	Object string
+	Key    string // 附件引用标识(talk_message_file.object)
+}
+
+func (c *ClearExpireMessage) Name() string {
+	return "expire.message.clear"
+}
+
+// Spec 配置定时任务规则
+// 每5分钟执行一次
+func (c *ClearExpireMessage) Spec() string {
+	return "*/5 * * * *"
+}
+
+func (c *ClearExpireMessage) Enable() bool {
+	return true
+}
+
+func (c *ClearExpireMessage) Do(ctx context.Context) error {
+
+	if err := c.clearPrivate(ctx); err != nil {
+		return err
+	}
+
+	return c.clearGroup(ctx)
+}
+
+// 删除私聊过期消息(双方会话的自毁时长一致，按各自信箱分别清理)
+func (c *ClearExpireMessage) clearPrivate(ctx context.Context) error {
+	lastId, size := 0, 100
+
+	for {
+		items := make([]*model.TalkSession, 0)
+
+		err := c.DB.WithContext(ctx).Model(&model.TalkSession{}).Where("id > ? and talk_mode = ? and message_ttl > 0", lastId, entity.ChatPrivateMode).Order("id asc").Limit(size).Scan(&items).Error
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			expireAt := time.Now().Add(-time.Duration(item.MessageTtl) * time.Second)
+
+			err := c.clearMessages(ctx, model.TalkMessageFileSourcePrivate, func(limit int) ([]*model.TalkMessageRecord, error) {
+				list := make([]*model.TalkMessageRecord, 0)
+				err := c.DB.WithContext(ctx).Model(&model.TalkUserMessage{}).Select("msg_id", "msg_type", "extra").
+					Where("user_id = ? and to_from_id = ? and send_time <= ?", item.UserId, item.ToFromId, expireAt).
+					Order("id asc").Limit(limit).Scan(&list).Error
+				return list, err
+			}, func(tx *gorm.DB, msgIds []string) error {
+				return tx.Delete(&model.TalkUserMessage{}, "user_id = ? and msg_id in ?", item.UserId, msgIds).Error
+			})
+			if err != nil {
+				logger.Errorf("clear expire message user_id:%d to_from_id:%d error: %s", item.UserId, item.ToFromId, err.Error())
+			}
+		}
+
+		if len(items) < size {
+			break
+		}
+
+		lastId = items[size-1].Id
+	}
+
+	return nil
+}
+
+// 删除群聊过期消息
+func (c *ClearExpireMessage) clearGroup(ctx context.Context) error {
+	lastId, size := 0, 100
+
+	for {
+		items := make([]*model.Group, 0)
+
+		err := c.DB.WithContext(ctx).Model(&model.Group{}).Where("id > ? and is_dismiss = ? and message_ttl > 0", lastId, model.No).Order("id asc").Limit(size).Scan(&items).Error
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			expireAt := time.Now().Add(-time.Duration(item.MessageTtl) * time.Second)
+
+			err := c.clearMessages(ctx, model.TalkMessageFileSourceGroup, func(limit int) ([]*model.TalkMessageRecord, error) {
+				list := make([]*model.TalkMessageRecord, 0)
+				err := c.DB.WithContext(ctx).Model(&model.TalkGroupMessage{}).Select("msg_id", "msg_type", "extra").
+					Where("group_id = ? and send_time <= ?", item.Id, expireAt).
+					Order("id asc").Limit(limit).Scan(&list).Error
+				return list, err
+			}, func(tx *gorm.DB, msgIds []string) error {
+				if err := tx.Delete(&model.TalkGroupMessage{}, "msg_id in ?", msgIds).Error; err != nil {
+					return err
+				}
+
+				if err := tx.Delete(&model.TalkGroupMessageDel{}, "msg_id in ?", msgIds).Error; err != nil {
+					return err
+				}
+
+				return tx.Delete(&model.TalkGroupThread{}, "msg_id in ?", msgIds).Error
+			})
+			if err != nil {
+				logger.Errorf("clear expire message group_id:%d error: %s", item.Id, err.Error())
+			}
+		}
+
+		if len(items) < size {
+			break
+		}
+
+		lastId = items[size-1].Id
+	}
+
+	return nil
+}
+
+// 分批删除过期消息及其关联数据，并清理不再被引用的附件
+func (c *ClearExpireMessage) clearMessages(ctx context.Context, source int, find func(limit int) ([]*model.TalkMessageRecord, error), remove func(tx *gorm.DB, msgIds []string) error) error {
+	limit := 100
+
+	for {
+		list, err := find(limit)
+		if err != nil {
+			return err
+		}
+
+		if len(list) == 0 {
+			return nil
+		}
+
+		msgIds := make([]string, 0, len(list))
+		files := make([]*expireMessageFile, 0)
+		for _, value := range list {
+			msgIds = append(msgIds, value.MsgId)
+
+			files = append(files, c.messageFiles(value.MsgType, value.Extra)...)
+		}
+
+		err = c.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := remove(tx, msgIds); err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.TalkMessagePin{}, "msg_id in ?", msgIds).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.TalkMessageReaction{}, "msg_id in ?", msgIds).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.TalkMessageMention{}, "msg_id in ?", msgIds).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.TalkMessageUrgent{}, "msg_id in ?", msgIds).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Delete(&model.TalkMessageFile{}, "source = ? and source_id in ?", source, msgIds).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(&model.TalkMessageEdit{}, "msg_id in ?", msgIds).Error
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := c.deleteFiles(ctx, files); err != nil {
+			return err
+		}
+
+		if len(list) < limit {
+			return nil
+		}
+	}
+}
+
+// 解析消息附件的存储位置
+func (c *ClearExpireMessage) messageFiles(msgType int, extra string) []*expireMessageFile {
+	files := make([]*expireMessageFile, 0)
+
+	for _, key := range model.TalkMessageFileObjects(msgType, extra) {
+		if msgType == entity.ChatMsgTypeFile {
+			files = append(files, &expireMessageFile{Bucket: c.Filesystem.BucketPrivateName(), Object: key, Key: key})
+			continue
+		}
+
+		// 仅清理聊天上传的多媒体文件，表情包等公共资源不做处理
+		_, object, ok := strings.Cut("/"+key, "/"+c.Filesystem.BucketPublicName()+"/")
+		if !ok || !strings.HasPrefix(object, "media/") {
+			continue
+		}
+
+		files = append(files, &expireMessageFile{Bucket: c.Filesystem.BucketPublicName(), Object: object, Key: key})
+	}
+
+	return files
+}
+
+// 删除不再被引用的附件
+func (c *ClearExpireMessage) deleteFiles(ctx context.Context, files []*expireMessageFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	// 引用表创建前上传的附件缺少完整的引用记录，无法确认是否仍被其它消息引用，不做删除
+	trackedAt, err := c.findTrackedAt(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		ok, err := c.isReferenced(ctx, file.Key)
+		if err != nil {
+			logger.Errorf("clear expire message file %s error: %s", file.Object, err.Error())
+			continue
+		}
+
+		if ok {
+			continue
+		}
+
+		stat, err := c.Filesystem.Stat(file.Bucket, file.Object)
+		if err != nil {
+			logger.Warnf("clear expire message stat file %s error: %s", file.Object, err.Error())
+			continue
+		}
+
+		if !stat.LastModTime.After(trackedAt) {
+			continue
+		}
+
+		if err := c.Filesystem.Delete(file.Bucket, file.Object); err != nil {
+			logger.Warnf("clear expire message delete file %s error: %s", file.Object, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// 获取附件引用表的创建时间，即开始记录附件引用的时间
+func (c *ClearExpireMessage) findTrackedAt(ctx context.Context) (time.Time, error) {
+	var createdAt sql.NullTime
+
+	err := c.DB.WithContext(ctx).
+		Raw("SELECT CREATE_TIME FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", model.TalkMessageFile{}.TableName()).
+		Row().Scan(&createdAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !createdAt.Valid {
+		return time.Time{}, errors.New("talk_message_file create time not found")
+	}
+
+	return createdAt.Time, nil
+}
+
+// 附件可能被转发或收藏，仍存在引用时不删除文件
+func (c *ClearExpireMessage) isReferenced(ctx context.Context, key string) (bool, error) {
+	err := c.DB.WithContext(ctx).Select("id").Where("object = ?", key).Take(&model.TalkMessageFile{}).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/filesystem"
+	"go-chat/internal/pkg/sqlmock"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+// testFilesystem 记录删除的文件，modTime 为文件的最后修改时间
+type testFilesystem struct {
+	filesystem.IFilesystem
+	modTime time.Time
+	deleted []string
+}
+
+func (f *testFilesystem) BucketPublicName() string  { return "im-static" }
+func (f *testFilesystem) BucketPrivateName() string { return "im-private" }
+
+func (f *testFilesystem) Stat(_ string, objectName string) (*filesystem.FileStatInfo, error) {
+	return &filesystem.FileStatInfo{Name: objectName, LastModTime: f.modTime}, nil
+}
+
+func (f *testFilesystem) Delete(bucketName string, objectName string) error {
+	f.deleted = append(f.deleted, bucketName+"/"+objectName)
+	return nil
+}
+
+// 附件引用表创建时间
+var testTrackedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+
+func newClearExpireMessage(t *testing.T, fs *testFilesystem) (*ClearExpireMessage, *sqlmock.Mock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	mock.ExpectQuery("information_schema.TABLES", []string{"CREATE_TIME"}, []any{testTrackedAt})
+
+	return &ClearExpireMessage{DB: db, Filesystem: fs}, mock
+}
+
+func clearExpireMessage(t *testing.T, c *ClearExpireMessage, records ...*model.TalkMessageRecord) {
+	found := false
+	err := c.clearMessages(context.Background(), model.TalkMessageFileSourceGroup, func(limit int) ([]*model.TalkMessageRecord, error) {
+		if found {
+			return nil, nil
+		}
+
+		found = true
+		return records, nil
+	}, func(tx *gorm.DB, msgIds []string) error {
+		return tx.Delete(&model.TalkGroupMessage{}, "msg_id in ?", msgIds).Error
+	})
+	assert.NoError(t, err)
+}
+
+func TestClearExpireMessageDeleteUrgent(t *testing.T) {
+	c, mock := newClearExpireMessage(t, &testFilesystem{})
+	clearExpireMessage(t, c, &model.TalkMessageRecord{MsgId: "msg", MsgType: entity.ChatMsgTypeText, Extra: "{}"})
+
+	// 过期消息的紧急提醒随消息一并删除，避免定时任务继续提醒
+	assert.NotEmpty(t, mock.Find("DELETE FROM `talk_group_message`"))
+
+	items := mock.Find("DELETE FROM `talk_message_urgent`")
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, []any{"msg"}, items[0].Args)
+	}
+}
+
+func TestClearExpireMessageDeleteFile(t *testing.T) {
+	record := &model.TalkMessageRecord{MsgId: "msg", MsgType: entity.ChatMsgTypeImage, Extra: `{"url":"http://127.0.0.1:9000/im-static/media/20231017/a.png"}`}
+
+	// 附件不再被引用时删除文件
+	fs := &testFilesystem{modTime: testTrackedAt.Add(time.Hour)}
+	c, mock := newClearExpireMessage(t, fs)
+	clearExpireMessage(t, c, record)
+	assert.Equal(t, []string{"im-static/media/20231017/a.png"}, fs.deleted)
+
+	items := mock.Find("DELETE FROM `talk_message_file`")
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, []any{int64(model.TalkMessageFileSourceGroup), "msg"}, items[0].Args)
+	}
+
+	items = mock.Find("FROM `talk_message_file`")
+	if assert.NotEmpty(t, items) {
+		assert.Contains(t, items[len(items)-1].Query, "WHERE object = ?")
+		assert.Equal(t, "im-static/media/20231017/a.png", items[len(items)-1].Args[0])
+	}
+
+	// 附件仍被转发或收藏引用时保留文件
+	fs = &testFilesystem{modTime: testTrackedAt.Add(time.Hour)}
+	c, mock = newClearExpireMessage(t, fs)
+	mock.ExpectQuery("FROM `talk_message_file`", []string{"id"}, []any{int64(1)})
+	clearExpireMessage(t, c, record)
+	assert.Empty(t, fs.deleted)
+
+	// 引用表创建前上传的附件缺少引用记录，保留文件
+	fs = &testFilesystem{modTime: testTrackedAt.Add(-time.Hour)}
+	c, _ = newClearExpireMessage(t, fs)
+	clearExpireMessage(t, c, record)
+	assert.Empty(t, fs.deleted)
+}
+
+func TestClearExpireMessageDeleteMixedFile(t *testing.T) {
+	fs := &testFilesystem{modTime: testTrackedAt.Add(time.Hour)}
+	c, _ := newClearExpireMessage(t, fs)
+
+	clearExpireMessage(t, c, &model.TalkMessageRecord{
+		MsgId:   "msg",
+		MsgType: entity.ChatMsgTypeMixed,
+		Extra:   `{"items":[{"type":1,"content":"hello"},{"type":3,"content":"http://127.0.0.1:9000/im-static/media/20231017/a.png"},{"type":3,"content":"http://127.0.0.1:9000/im-static/media/20231017/b.png"}]}`,
+	})
+
+	assert.Equal(t, []string{"im-static/media/20231017/a.png", "im-static/media/20231017/b.png"}, fs.deleted)
+}
@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core/crontab"
+	"go-chat/internal/service"
+)
+
+var _ crontab.ICrontab = (*NotifyUrgentMessage)(nil)
+
+type NotifyUrgentMessage struct {
+	TalkUrgentService service.ITalkUrgentService
+}
+
+func (c *NotifyUrgentMessage) Name() string {
+	return "urgent.message.notify"
+}
+
+// Spec 配置定时任务规则
+// 每分钟执行一次
+func (c *NotifyUrgentMessage) Spec() string {
+	return "* * * * *"
+}
+
+func (c *NotifyUrgentMessage) Enable() bool {
+	return true
+}
+
+func (c *NotifyUrgentMessage) Do(ctx context.Context) error {
+	return c.TalkUrgentService.Dispatch(ctx)
+}
@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core/crontab"
+	"go-chat/internal/service"
+)
+
+var _ crontab.ICrontab = (*RecoverTalkExport)(nil)
+
+type RecoverTalkExport struct {
+	TalkExportService service.ITalkExportService
+}
+
+func (c *RecoverTalkExport) Name() string {
+	return "talk.export.recover"
+}
+
+// Spec 配置定时任务规则
+// 每10分钟执行一次
+func (c *RecoverTalkExport) Spec() string {
+	return "*/10 * * * *"
+}
+
+func (c *RecoverTalkExport) Enable() bool {
+	return true
+}
+
+func (c *RecoverTalkExport) Do(ctx context.Context) error {
+	return c.TalkExportService.Recover(ctx)
+}
@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core/crontab"
+	"go-chat/internal/service"
+)
+
+var _ crontab.ICrontab = (*SendScheduleMessage)(nil)
+
+type SendScheduleMessage struct {
+	TalkScheduleService service.ITalkScheduleService
+}
+
+func (c *SendScheduleMessage) Name() string {
+	return "schedule.message.send"
+}
+
+// Spec 配置定时任务规则
+// 每分钟执行一次
+func (c *SendScheduleMessage) Spec() string {
+	return "* * * * *"
+}
+
+func (c *SendScheduleMessage) Enable() bool {
+	return true
+}
+
+func (c *SendScheduleMessage) Do(ctx context.Context) error {
+	return c.TalkScheduleService.Dispatch(ctx)
+}
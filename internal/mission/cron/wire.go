@@ -3,10 +3,14 @@ package cron
 import "github.com/google/wire"
 
 type Crontab struct {
-	ClearWsCache      *ClearWsCache
-	ClearArticle      *ClearArticle
-	ClearTmpFile      *ClearTmpFile
-	ClearExpireServer *ClearExpireServer
+	ClearWsCache        *ClearWsCache
+	ClearArticle        *ClearArticle
+	ClearTmpFile        *ClearTmpFile
+	ClearExpireServer   *ClearExpireServer
+	SendScheduleMessage *SendScheduleMessage
+	ClearExpireMessage  *ClearExpireMessage
+	NotifyUrgentMessage *NotifyUrgentMessage
+	RecoverTalkExport   *RecoverTalkExport
 }
 
 var ProviderSet = wire.NewSet(
@@ -14,5 +18,9 @@ var ProviderSet = wire.NewSet(
 	wire.Struct(new(ClearTmpFile), "*"),
 	wire.Struct(new(ClearWsCache), "*"),
 	wire.Struct(new(ClearExpireServer), "*"),
+	wire.Struct(new(SendScheduleMessage), "*"),
+	wire.Struct(new(ClearExpireMessage), "*"),
+	wire.Struct(new(NotifyUrgentMessage), "*"),
+	wire.Struct(new(RecoverTalkExport), "*"),
 	wire.Struct(new(Crontab), "*"),
 )
@@ -2,30 +2,33 @@ package mission
 
 import (
 	"context"
-	"time"
 
-	"github.com/redis/go-redis/v9"
 	"github.com/urfave/cli/v2"
+	"go-chat/internal/entity"
 	"go-chat/internal/mission/queue"
+	"go-chat/internal/pkg/core/bus"
 )
 
 type QueueProvider struct {
 	Consumers *queue.Consumers
-	Redis     *redis.Client
+	Bus       bus.IBus
 }
 
 func Queue(ctx *cli.Context, app *QueueProvider) error {
-	topics := []string{"im.user.login"}
+	topics := []string{entity.LoginTopic, entity.LinkPreviewTopic, entity.TalkExportTopic}
 
-	sub := app.Redis.Subscribe(ctx.Context, topics...)
-	defer sub.Close()
-
-	for data := range sub.Channel(redis.WithChannelHealthCheckInterval(10 * time.Second)) {
-		switch data.Channel {
-		case "im.user.login":
-			_ = app.Consumers.UserLoginConsumer.Do(context.Background(), []byte(data.Payload), 1)
+	// 多个 queue 进程使用同一分组，每条消息仅由其中一个进程处理
+	// 消息总线按主题独立限制并发，抓取网页、导出记录等耗时任务不会阻塞登录消息
+	return app.Bus.Subscribe(ctx.Context, "queue", topics, func(ctx context.Context, msg *bus.Message) error {
+		switch msg.Topic {
+		case entity.LoginTopic:
+			return app.Consumers.UserLoginConsumer.Do(ctx, msg.Payload, msg.Attempts)
+		case entity.LinkPreviewTopic:
+			return app.Consumers.LinkPreviewConsumer.Do(ctx, msg.Payload, msg.Attempts)
+		case entity.TalkExportTopic:
+			return app.Consumers.TalkExportConsumer.Do(ctx, msg.Payload, msg.Attempts)
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"go-chat/internal/business"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/consumer"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/unfurl"
+	"go-chat/internal/repository/cache"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"gorm.io/gorm"
+)
+
+var _ consumer.IConsumerHandle = (*LinkPreviewConsumer)(nil)
+
+// LinkPreviewConsumer 生成文本消息中的链接预览
+type LinkPreviewConsumer struct {
+	TalkRecordFriendRepo *repo.TalkUserMessage
+	TalkRecordGroupRepo  *repo.TalkGroupMessage
+	LinkPreviewStorage   *cache.LinkPreviewStorage
+	Unfurler             *unfurl.Unfurler
+	PushMessage          *business.PushMessage
+}
+
+func (l *LinkPreviewConsumer) Touch() bool {
+	return false
+}
+
+func (l *LinkPreviewConsumer) Topic() string {
+	return entity.LinkPreviewTopic
+}
+
+func (l *LinkPreviewConsumer) Channel() string {
+	return "default"
+}
+
+func (l *LinkPreviewConsumer) Do(ctx context.Context, msg []byte, attempts uint16) error {
+	var in entity.LinkPreviewPayload
+	if err := json.Unmarshal(msg, &in); err != nil {
+		return err
+	}
+
+	preview := l.findPreview(ctx, in.Url)
+	if preview == nil {
+		return nil
+	}
+
+	var (
+		record *model.TalkMessageRecord
+		err    error
+	)
+
+	switch in.TalkMode {
+	case entity.ChatPrivateMode:
+		record, err = l.attachPrivate(ctx, in.MsgId, in.Url, preview)
+	case entity.ChatGroupMode:
+		record, err = l.attachGroup(ctx, in.MsgId, in.Url, preview)
+	}
+
+	if err != nil || record == nil {
+		return err
+	}
+
+	return l.PushMessage.PushTalk(ctx, in.TalkMode, record.FromId, record.ToFromId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessagePreview,
+		Payload: jsonutil.Encode(entity.SubEventTalkPreviewPayload{
+			TalkMode: in.TalkMode,
+			MsgId:    record.MsgId,
+		}),
+	})
+}
+
+// 获取链接预览，优先读取缓存
+func (l *LinkPreviewConsumer) findPreview(ctx context.Context, url string) *unfurl.Preview {
+	if preview, ok := l.LinkPreviewStorage.Get(ctx, url); ok {
+		return preview
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	preview, err := l.Unfurler.Unfurl(timeout, url)
+	if err != nil {
+		logger.Warnf("link preview unfurl url:%s err:%s", url, err.Error())
+	}
+
+	_ = l.LinkPreviewStorage.Set(ctx, url, preview)
+
+	return preview
+}
+
+// 私聊消息双方各存一份，需同步更新
+func (l *LinkPreviewConsumer) attachPrivate(ctx context.Context, orgMsgId string, url string, preview *unfurl.Preview) (*model.TalkMessageRecord, error) {
+	record, err := l.TalkRecordFriendRepo.FindByWhere(ctx, "org_msg_id = ?", orgMsgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	extra, ok := l.attach(record.MsgType, record.IsRevoked, record.Extra, url, preview)
+	if !ok {
+		return nil, nil
+	}
+
+	// 以原内容作为更新条件，避免覆盖并发编辑的内容(extra 为 json 类型，需转换后比较)
+	rows, err := l.TalkRecordFriendRepo.UpdateByWhere(ctx, map[string]any{"extra": extra}, "org_msg_id = ? and extra = CAST(? AS JSON)", orgMsgId, record.Extra)
+	if err != nil || rows == 0 {
+		return nil, err
+	}
+
+	return &model.TalkMessageRecord{MsgId: record.MsgId, FromId: record.UserId, ToFromId: record.ToFromId}, nil
+}
+
+func (l *LinkPreviewConsumer) attachGroup(ctx context.Context, msgId string, url string, preview *unfurl.Preview) (*model.TalkMessageRecord, error) {
+	record, err := l.TalkRecordGroupRepo.FindByMsgId(ctx, msgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	extra, ok := l.attach(record.MsgType, record.IsRevoked, record.Extra, url, preview)
+	if !ok {
+		return nil, nil
+	}
+
+	rows, err := l.TalkRecordGroupRepo.UpdateByWhere(ctx, map[string]any{"extra": extra}, "msg_id = ? and extra = CAST(? AS JSON)", msgId, record.Extra)
+	if err != nil || rows == 0 {
+		return nil, err
+	}
+
+	return &model.TalkMessageRecord{MsgId: record.MsgId, FromId: record.FromId, ToFromId: record.GroupId}, nil
+}
+
+// 将预览写入文本消息扩展字段，消息已撤回或链接已被编辑移除时不处理
+func (l *LinkPreviewConsumer) attach(msgType int, isRevoked int, extra string, url string, preview *unfurl.Preview) (string, bool) {
+	if msgType != entity.ChatMsgTypeText || isRevoked == model.Yes {
+		return "", false
+	}
+
+	var text model.TalkRecordExtraText
+	if err := jsonutil.Decode(extra, &text); err != nil || text.Preview != nil || !strings.Contains(text.Content, url) {
+		return "", false
+	}
+
+	text.Preview = &model.TalkRecordExtraLinkPreview{
+		Url:         url,
+		Title:       preview.Title,
+		Description: preview.Description,
+		Image:       preview.Image,
+		SiteName:    preview.SiteName,
+	}
+
+	return jsonutil.Encode(text), true
+}
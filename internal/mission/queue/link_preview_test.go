@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/sqlmock"
+	"go-chat/internal/pkg/unfurl"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+)
+
+func TestLinkPreviewAttachPrivate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	columns := []string{"id", "msg_id", "org_msg_id", "user_id", "to_from_id", "msg_type", "is_revoked", "extra"}
+
+	// MySQL 读取的 json 字段为规范化格式，与写入的字符串不一定完全一致
+	origin := `{"content": "see https://example.com", "mentions": []}`
+	mock.ExpectQuery("FROM `talk_user_message`", columns, []any{int64(1), "msg", "org", int64(1), int64(2), int64(entity.ChatMsgTypeText), int64(model.No), origin})
+	mock.ExpectExec("UPDATE `talk_user_message`", 1)
+
+	consumer := &LinkPreviewConsumer{TalkRecordFriendRepo: repo.NewTalkRecordFriend(db)}
+
+	record, err := consumer.attachPrivate(context.Background(), "org", "https://example.com", &unfurl.Preview{Title: "Example"})
+	assert.NoError(t, err)
+	if assert.NotNil(t, record) {
+		assert.Equal(t, "msg", record.MsgId)
+		assert.Equal(t, 1, record.FromId)
+		assert.Equal(t, 2, record.ToFromId)
+	}
+
+	// 以原内容作为更新条件，json 字段需转换后比较，避免覆盖并发编辑的内容
+	items := mock.Find("UPDATE `talk_user_message`")
+	if !assert.Len(t, items, 1) {
+		return
+	}
+
+	assert.Contains(t, items[0].Query, "WHERE org_msg_id = ? and extra = CAST(? AS JSON)")
+	assert.Equal(t, "org", items[0].Args[len(items[0].Args)-2])
+	assert.Equal(t, origin, items[0].Args[len(items[0].Args)-1])
+
+	extra := items[0].Args[0].(string)
+
+	var text model.TalkRecordExtraText
+	assert.NoError(t, jsonutil.Decode(extra, &text))
+	assert.Equal(t, "see https://example.com", text.Content)
+	if assert.NotNil(t, text.Preview) {
+		assert.Equal(t, "Example", text.Preview.Title)
+	}
+
+	// 已生成预览的消息不重复更新
+	mock.ExpectQuery("FROM `talk_user_message`", columns, []any{int64(1), "msg", "org", int64(1), int64(2), int64(entity.ChatMsgTypeText), int64(model.No), extra})
+
+	record, err = consumer.attachPrivate(context.Background(), "org", "https://example.com", &unfurl.Preview{Title: "Other"})
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	assert.Len(t, mock.Find("UPDATE `talk_user_message`"), 1)
+}
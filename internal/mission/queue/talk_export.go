@@ -0,0 +1,38 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/consumer"
+	"go-chat/internal/service"
+)
+
+var _ consumer.IConsumerHandle = (*TalkExportConsumer)(nil)
+
+// TalkExportConsumer 执行聊天记录导出任务
+type TalkExportConsumer struct {
+	TalkExportService service.ITalkExportService
+}
+
+func (t *TalkExportConsumer) Touch() bool {
+	return false
+}
+
+func (t *TalkExportConsumer) Topic() string {
+	return entity.TalkExportTopic
+}
+
+func (t *TalkExportConsumer) Channel() string {
+	return "default"
+}
+
+func (t *TalkExportConsumer) Do(ctx context.Context, msg []byte, attempts uint16) error {
+	var in entity.TalkExportPayload
+	if err := json.Unmarshal(msg, &in); err != nil {
+		return err
+	}
+
+	return t.TalkExportService.Process(ctx, in.ExportId)
+}
@@ -3,10 +3,14 @@ package queue
 import "github.com/google/wire"
 
 type Consumers struct {
-	UserLoginConsumer *UserLoginConsumer
+	UserLoginConsumer   *UserLoginConsumer
+	LinkPreviewConsumer *LinkPreviewConsumer
+	TalkExportConsumer  *TalkExportConsumer
 }
 
 var ProviderSet = wire.NewSet(
 	wire.Struct(new(Consumers), "*"),
 	wire.Struct(new(UserLoginConsumer), "*"),
+	wire.Struct(new(LinkPreviewConsumer), "*"),
+	wire.Struct(new(TalkExportConsumer), "*"),
 )
@@ -226,6 +226,7 @@ CREATE TABLE IF NOT EXISTS `group`
     `is_overt`   tinyint unsigned  NOT NULL DEFAULT '2' COMMENT '是否公开可见[1:是;2:否;]',
     `is_mute`    tinyint unsigned  NOT NULL DEFAULT '2' COMMENT '是否全员禁言 [1:是;2:否;] 提示:不包含群主或管理员',
     `is_dismiss` tinyint unsigned  NOT NULL DEFAULT '2' COMMENT '是否已解散[1:是;2:否;]',
+    `message_ttl` int unsigned     NOT NULL DEFAULT '0' COMMENT '消息自毁时长(秒)[0:关闭;]',
     `creator_id` int unsigned      NOT NULL COMMENT '创建者ID(群主ID)',
     `created_at` datetime          NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at` datetime          NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
@@ -425,14 +426,18 @@ CREATE TABLE IF NOT EXISTS `talk_group_message`
     `is_revoked` tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否撤回[1:是;2:否;]',
     `extra`      json             NOT NULL COMMENT '消息扩展字段',
     `quote`      json             NOT NULL COMMENT '引用消息',
+    `thread_id`  varchar(64)      NOT NULL DEFAULT '' COMMENT '话题根消息ID',
     `send_time`  datetime         NOT NULL COMMENT '发送时间',
+    `search_text` text GENERATED ALWAYS AS (case `msg_type` when 1 then json_unquote(json_extract(`extra`, '$.content')) when 2 then json_unquote(json_extract(`extra`, '$.code')) when 12 then json_unquote(json_extract(`extra`, '$.items[*].content')) end) STORED COMMENT '全文检索内容',
     `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     PRIMARY KEY (`id`),
     UNIQUE KEY `uk_group_id_sequence` (`group_id`, `sequence`) USING BTREE,
     UNIQUE KEY `uk_msgid` (`msg_id`),
+    KEY `idx_thread_id_sequence` (`thread_id`, `sequence`) USING BTREE,
     KEY `idx_updated_at` (`updated_at`) USING BTREE,
-    KEY `idx_created_at` (`created_at`) USING BTREE
+    KEY `idx_created_at` (`created_at`) USING BTREE,
+    FULLTEXT KEY `ft_search_text` (`search_text`) WITH PARSER ngram
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci COMMENT ='群聊消息记录表';;
@@ -451,6 +456,307 @@ CREATE TABLE IF NOT EXISTS `talk_group_message_del`
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci COMMENT ='群聊消息记录表-删除记录关系表';;
 
+CREATE TABLE IF NOT EXISTS `talk_group_thread`
+(
+    `id`            int unsigned NOT NULL AUTO_INCREMENT COMMENT '话题ID',
+    `group_id`      int unsigned NOT NULL COMMENT '群组ID',
+    `msg_id`        varchar(64)  NOT NULL COMMENT '话题根消息ID',
+    `reply_count`   int unsigned NOT NULL DEFAULT '0' COMMENT '回复数',
+    `last_reply_at` datetime     NOT NULL COMMENT '最后回复时间',
+    `created_at`    datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at`    datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_msg_id` (`msg_id`) USING BTREE,
+    KEY `idx_group_id` (`group_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='群聊话题表';;
+
+CREATE TABLE IF NOT EXISTS `talk_read_receipt`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '用户ID',
+    `talk_mode`  tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `sequence`   bigint unsigned  NOT NULL DEFAULT '0' COMMENT '已读消息时序ID',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '已读时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_talk_mode_to_from_id` (`user_id`, `talk_mode`, `to_from_id`) USING BTREE,
+    KEY `idx_talk_mode_to_from_id_sequence` (`talk_mode`, `to_from_id`, `sequence`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='会话消息已读回执表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_schedule`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '发送者ID',
+    `talk_mode`  tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `msg_type`   int unsigned     NOT NULL COMMENT '消息类型',
+    `quote_id`   varchar(64)      NOT NULL DEFAULT '' COMMENT '引用消息ID',
+    `extra`      json             NOT NULL COMMENT '消息扩展字段',
+    `status`     tinyint unsigned NOT NULL DEFAULT '1' COMMENT '发送状态[1:待发送;2:发送中;3:已发送;4:已取消;5:发送失败;]',
+    `send_at`    datetime         NOT NULL COMMENT '计划发送时间',
+    `reason`     varchar(255)     NOT NULL DEFAULT '' COMMENT '发送失败原因',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`) USING BTREE,
+    KEY `idx_status_send_at` (`status`, `send_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='定时消息表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_pin`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `talk_mode`  tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `user_id`    int unsigned     NOT NULL DEFAULT '0' COMMENT '消息所属用户ID(群聊为0)',
+    `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `msg_id`     varchar(64)      NOT NULL COMMENT '消息ID',
+    `pinned_by`  int unsigned     NOT NULL COMMENT '置顶操作人ID',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '置顶时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_msg_id` (`msg_id`) USING BTREE,
+    KEY `idx_talk_mode_to_from_id_user_id` (`talk_mode`, `to_from_id`, `user_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='会话置顶消息表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_favorite`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '收藏ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '用户ID',
+    `talk_mode`  tinyint unsigned NOT NULL COMMENT '来源对话类型[1:私信;2:群聊;]',
+    `to_from_id` int unsigned     NOT NULL COMMENT '来源会话ID（用户ID 或 群ID）',
+    `msg_id`     varchar(64)      NOT NULL COMMENT '来源消息ID',
+    `from_id`    int unsigned     NOT NULL COMMENT '消息发送者ID',
+    `msg_type`   int unsigned     NOT NULL COMMENT '消息类型',
+    `extra`      json             NOT NULL COMMENT '消息扩展字段快照',
+    `tags`       varchar(255)     NOT NULL DEFAULT '' COMMENT '标签(多个以逗号分隔)',
+    `send_time`  datetime         NOT NULL COMMENT '消息发送时间',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '收藏时间',
+    `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_msg_id` (`user_id`, `msg_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='消息收藏表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_file`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `source`     tinyint unsigned NOT NULL COMMENT '引用来源[1:私聊消息;2:群聊消息;3:消息收藏;]',
+    `source_id`  varchar(64)      NOT NULL COMMENT '来源ID(消息ID 或 收藏ID)',
+    `object`     varchar(255)     NOT NULL COMMENT '附件标识(文件路径 或 多媒体地址路径)',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_source_source_id_object` (`source`, `source_id`, `object`) USING BTREE,
+    KEY `idx_object` (`object`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='消息附件引用表';;
+
+CREATE TABLE IF NOT EXISTS `talk_draft`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '用户ID',
+    `talk_mode`  tinyint unsigned NOT NULL DEFAULT '1' COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `content`    text             NOT NULL COMMENT '草稿内容',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_talk_mode_to_from_id` (`user_id`, `talk_mode`, `to_from_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='会话草稿表';;
+
+CREATE TABLE IF NOT EXISTS `talk_sync_event`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned     NOT NULL DEFAULT '0' COMMENT '用户ID(群事件为0)',
+    `group_id`   int unsigned     NOT NULL DEFAULT '0' COMMENT '群ID(用户事件为0)',
+    `sequence`   bigint           NOT NULL COMMENT '时序ID(同步事件独立发号)',
+    `event`      varchar(32)      NOT NULL COMMENT '事件类型',
+    `talk_mode`  tinyint unsigned NOT NULL DEFAULT '1' COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `payload`    json             NOT NULL COMMENT '事件内容',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_group_id_sequence` (`user_id`, `group_id`, `sequence`) USING BTREE,
+    KEY `idx_created_at` (`created_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='多端同步事件表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_mention`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '被提及的用户ID',
+    `group_id`   int unsigned     NOT NULL COMMENT '群ID',
+    `msg_id`     varchar(64)      NOT NULL COMMENT '消息ID',
+    `from_id`    int unsigned     NOT NULL COMMENT '消息发送者ID',
+    `is_all`     tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否@所有人[1:是;2:否;]',
+    `is_read`    tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否已读[1:是;2:否;]',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_msg_id` (`user_id`, `msg_id`) USING BTREE,
+    KEY `idx_user_id_group_id_is_read` (`user_id`, `group_id`, `is_read`) USING BTREE,
+    KEY `idx_msg_id` (`msg_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='群聊@提及索引表';;
+
+CREATE TABLE IF NOT EXISTS `talk_export`
+(
+    `id`          int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`     int unsigned     NOT NULL COMMENT '导出用户ID',
+    `talk_mode`   tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id`  int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `format`      varchar(10)      NOT NULL COMMENT '导出格式[json;html;txt;]',
+    `start_time`  datetime         NOT NULL COMMENT '消息发送时间起始(包含)',
+    `end_time`    datetime         NOT NULL COMMENT '消息发送时间截止(不包含)',
+    `status`      tinyint unsigned NOT NULL DEFAULT '1' COMMENT '导出状态[1:待处理;2:处理中;3:已完成;4:导出失败;]',
+    `message_num` int unsigned     NOT NULL DEFAULT '0' COMMENT '导出消息数',
+    `file_num`    int unsigned     NOT NULL DEFAULT '0' COMMENT '打包附件数',
+    `path`        varchar(255)     NOT NULL DEFAULT '' COMMENT '压缩包路径(私有桶)',
+    `size`        int unsigned     NOT NULL DEFAULT '0' COMMENT '压缩包大小',
+    `reason`      varchar(255)     NOT NULL DEFAULT '' COMMENT '导出失败原因',
+    `created_at`  datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at`  datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='聊天记录导出任务表';;
+
+CREATE TABLE IF NOT EXISTS `talk_broadcast`
+(
+    `id`         int unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`    int unsigned NOT NULL COMMENT '创建者ID',
+    `name`       varchar(64)  NOT NULL COMMENT '列表名称',
+    `user_ids`   text         NOT NULL COMMENT '接收者ID(多个以逗号分隔)',
+    `created_at` datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='群发列表';;
+
+CREATE TABLE IF NOT EXISTS `talk_broadcast_log`
+(
+    `id`           int unsigned NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`      int unsigned NOT NULL COMMENT '发送者ID',
+    `broadcast_id` int unsigned NOT NULL COMMENT '群发列表ID',
+    `msg_type`     int unsigned NOT NULL COMMENT '消息类型',
+    `extra`        json         NOT NULL COMMENT '消息扩展字段',
+    `total`        int unsigned NOT NULL DEFAULT '0' COMMENT '接收者总数',
+    `reached_ids`  text         NOT NULL COMMENT '发送成功的接收者ID(多个以逗号分隔)',
+    `failed_ids`   text         NOT NULL COMMENT '发送失败的接收者ID(多个以逗号分隔)',
+    `created_at`   datetime     NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '发送时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id_broadcast_id` (`user_id`, `broadcast_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='群发记录表';;
+
+CREATE TABLE IF NOT EXISTS `talk_quick_reply`
+(
+    `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `scope`      tinyint unsigned NOT NULL DEFAULT '1' COMMENT '可见范围[1:个人;2:部门;]',
+    `user_id`    int unsigned     NOT NULL COMMENT '创建者ID',
+    `dept_id`    int unsigned     NOT NULL DEFAULT '0' COMMENT '所属部门ID(个人快捷回复为0)',
+    `title`      varchar(64)      NOT NULL COMMENT '标题',
+    `msg_type`   int unsigned     NOT NULL COMMENT '消息类型[1:文本消息;12:图文消息;]',
+    `content`    text             NOT NULL COMMENT '模板内容(图文消息为 JSON 数组)',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`) USING BTREE,
+    KEY `idx_dept_id` (`dept_id`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='快捷回复表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_urgent`
+(
+    `id`             int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `talk_mode`      tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `msg_id`         varchar(64)      NOT NULL COMMENT '接收者看到的消息ID',
+    `org_msg_id`     varchar(64)      NOT NULL COMMENT '原始消息ID(私聊为 org_msg_id，群聊与 msg_id 相同)',
+    `user_id`        int unsigned     NOT NULL COMMENT '接收者ID',
+    `to_from_id`     int unsigned     NOT NULL COMMENT '接收者视角的会话ID(私聊为发送者ID，群聊为群ID)',
+    `from_id`        int unsigned     NOT NULL COMMENT '发送者ID',
+    `is_acked`       tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否已确认[1:是;2:否;]',
+    `notify_num`     int unsigned     NOT NULL DEFAULT '0' COMMENT '已重复提醒次数',
+    `next_notify_at` datetime         NOT NULL COMMENT '下次提醒时间',
+    `acked_at`       datetime                  DEFAULT NULL COMMENT '确认时间',
+    `created_at`     datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_id_msg_id` (`user_id`, `msg_id`) USING BTREE,
+    KEY `idx_org_msg_id` (`org_msg_id`) USING BTREE,
+    KEY `idx_is_acked_next_notify_at` (`is_acked`, `next_notify_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='紧急消息提醒表';;
+
+CREATE TABLE IF NOT EXISTS `talk_live_location`
+(
+    `id`          int unsigned     NOT NULL AUTO_INCREMENT COMMENT '自增ID',
+    `user_id`     int unsigned     NOT NULL COMMENT '共享者ID',
+    `talk_mode`   tinyint unsigned NOT NULL COMMENT '对话类型[1:私信;2:群聊;]',
+    `to_from_id`  int unsigned     NOT NULL COMMENT '接收者ID（用户ID 或 群ID）',
+    `duration`    int unsigned     NOT NULL COMMENT '共享时长(秒)',
+    `status`      tinyint unsigned NOT NULL DEFAULT '1' COMMENT '共享状态[1:共享中;2:已结束;]',
+    `longitude`   varchar(32)      NOT NULL DEFAULT '' COMMENT '最后位置经度',
+    `latitude`    varchar(32)      NOT NULL DEFAULT '' COMMENT '最后位置纬度',
+    `description` varchar(255)     NOT NULL DEFAULT '' COMMENT '位置描述',
+    `expired_at`  datetime         NOT NULL COMMENT '共享结束时间',
+    `ended_at`    datetime                  DEFAULT NULL COMMENT '实际结束时间',
+    `created_at`  datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `updated_at`  datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_talk_mode_to_from_id` (`talk_mode`, `to_from_id`) USING BTREE,
+    KEY `idx_status_expired_at` (`status`, `expired_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='实时位置共享表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_edit`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '编辑记录ID',
+    `talk_mode`  tinyint unsigned NOT NULL DEFAULT '1' COMMENT '对话类型[1:私信;2:群聊;]',
+    `msg_id`     varchar(64)      NOT NULL COMMENT '消息ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '编辑者ID',
+    `msg_type`   int unsigned     NOT NULL DEFAULT '1' COMMENT '消息类型',
+    `extra`      json             NOT NULL COMMENT '编辑前的消息扩展字段',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '编辑时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_msg_id` (`msg_id`) USING BTREE,
+    KEY `idx_created_at` (`created_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='聊天消息编辑记录表';;
+
+CREATE TABLE IF NOT EXISTS `talk_message_reaction`
+(
+    `id`         bigint unsigned  NOT NULL AUTO_INCREMENT COMMENT '表态记录ID',
+    `talk_mode`  tinyint unsigned NOT NULL DEFAULT '1' COMMENT '对话类型[1:私信;2:群聊;]',
+    `msg_id`     varchar(64)      NOT NULL COMMENT '消息ID',
+    `user_id`    int unsigned     NOT NULL COMMENT '表态用户ID',
+    `emoji`      varchar(32)      NOT NULL COMMENT '表情',
+    `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_msg_id_user_id_emoji` (`msg_id`, `user_id`, `emoji`) USING BTREE,
+    KEY `idx_created_at` (`created_at`) USING BTREE
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_general_ci COMMENT ='聊天消息表态记录表';;
+
 CREATE TABLE IF NOT EXISTS `talk_session`
 (
     `id`         int unsigned     NOT NULL AUTO_INCREMENT COMMENT '聊天列表ID',
@@ -461,6 +767,7 @@ CREATE TABLE IF NOT EXISTS `talk_session`
     `is_disturb` tinyint unsigned NOT NULL DEFAULT '2' COMMENT '消息免打扰[1:是;2:否]',
     `is_delete`  tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否删除[1:是;2:否]',
     `is_robot`   tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否机器人[1:是;2:否]',
+    `message_ttl` int unsigned    NOT NULL DEFAULT '0' COMMENT '消息自毁时长(秒)[0:关闭;]',
     `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     PRIMARY KEY (`id`),
@@ -484,9 +791,11 @@ CREATE TABLE IF NOT EXISTS `talk_user_message`
     `to_from_id` int unsigned     NOT NULL COMMENT '接收者ID',
     `is_revoked` tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否撤回[1:是;2:否;]',
     `is_deleted` tinyint unsigned NOT NULL DEFAULT '2' COMMENT '是否删除[1:是;2:否;]',
+    `delivery_status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '投递状态[1:已发送;2:已送达;3:已读;]',
     `extra`      json             NOT NULL COMMENT '消息扩展字段',
     `quote`      json             NOT NULL COMMENT '引用消息',
     `send_time`  datetime         NOT NULL COMMENT '发送时间',
+    `search_text` text GENERATED ALWAYS AS (case `msg_type` when 1 then json_unquote(json_extract(`extra`, '$.content')) when 2 then json_unquote(json_extract(`extra`, '$.code')) when 12 then json_unquote(json_extract(`extra`, '$.items[*].content')) end) STORED COMMENT '全文检索内容',
     `created_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `updated_at` datetime         NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     PRIMARY KEY (`id`),
@@ -494,7 +803,8 @@ CREATE TABLE IF NOT EXISTS `talk_user_message`
     UNIQUE KEY `uk_msgid` (`msg_id`) USING BTREE,
     KEY `idx_created_at` (`created_at`) USING BTREE,
     KEY `idx_updated_at` (`updated_at`) USING BTREE,
-    KEY `idx_org_msg_id` (`org_msg_id`)
+    KEY `idx_org_msg_id` (`org_msg_id`),
+    FULLTEXT KEY `ft_search_text` (`search_text`) WITH PARSER ngram
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_general_ci COMMENT ='私有消息记录表';;
@@ -0,0 +1,67 @@
+package bus
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// 消息总线驱动
+const (
+	DriverRedis  = "redis"  // Redis 发布订阅，消息至多投递一次
+	DriverStream = "stream" // Redis Streams 消费分组，消息至少投递一次
+	DriverNsq    = "nsq"    // NSQ，消息至少投递一次
+)
+
+// 消息处理失败后的最大投递次数，超过后丢弃
+const maxAttempts = 10
+
+// 每个主题并发处理的消息数
+const concurrency = 10
+
+const ephemeralSuffix = "#ephemeral"
+
+type Message struct {
+	Topic    string
+	Payload  []byte
+	Attempts uint16 // 第几次投递
+}
+
+// Handler 消息处理函数，返回错误时消息稍后重新投递(仅支持重试的驱动)
+type Handler func(ctx context.Context, msg *Message) error
+
+// IBus 消息总线
+type IBus interface {
+	// Publish 发布消息到指定主题
+	Publish(ctx context.Context, topic string, payloads ...[]byte) error
+	// Subscribe 订阅主题并阻塞处理消息，直到 ctx 结束
+	//
+	// 每个主题独立并发处理，耗时较长的主题不会阻塞其它主题的消息
+	//
+	// 同一分组内的订阅者竞争消费，不同分组各自收到全部消息(Redis 发布订阅不区分分组，所有订阅者均收到全部消息)
+	Subscribe(ctx context.Context, group string, topics []string, handler Handler) error
+}
+
+// EphemeralGroup 临时消费分组，订阅结束后删除，用于节点独享的分组(如 comet 节点的分组)
+func EphemeralGroup(name string) string {
+	return name + ephemeralSuffix
+}
+
+func isEphemeral(group string) bool {
+	return strings.HasSuffix(group, ephemeralSuffix)
+}
+
+// 每个主题启动独立的订阅，阻塞至全部订阅结束
+func subscribeEach(topics []string, fn func(topic string)) {
+	var wg sync.WaitGroup
+
+	for _, topic := range topics {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(topic)
+		}()
+	}
+
+	wg.Wait()
+}
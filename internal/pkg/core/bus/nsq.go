@@ -0,0 +1,131 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/nsqio/go-nsq"
+	"go-chat/internal/pkg/core/consumer"
+)
+
+var _ IBus = (*NsqBus)(nil)
+
+// NsqBus 基于 NSQ，分组对应 NSQ channel，临时分组使用 NSQ 临时 channel
+//
+// NSQ 主题名称仅允许 [.a-zA-Z0-9_-]，主题中的 : 转换为 . (如 im:message:chat:all 对应 im.message.chat.all)
+type NsqBus struct {
+	addr     string
+	producer *nsq.Producer
+}
+
+func NewNsqBus(addr string) (*NsqBus, error) {
+	producer, err := nsq.NewProducer(addr, nsq.NewConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return &NsqBus{addr: addr, producer: producer}, nil
+}
+
+func (n *NsqBus) Publish(_ context.Context, topic string, payloads ...[]byte) error {
+	name, err := nsqTopic(topic)
+	if err != nil {
+		return err
+	}
+
+	if len(payloads) == 1 {
+		return n.producer.Publish(name, payloads[0])
+	}
+
+	return n.producer.MultiPublish(name, payloads)
+}
+
+func (n *NsqBus) Subscribe(ctx context.Context, group string, topics []string, handler Handler) error {
+	conf := nsq.NewConfig()
+	conf.MaxInFlight = concurrency
+	conf.MaxAttempts = maxAttempts
+
+	consumers := make([]*nsq.Consumer, 0, len(topics))
+	defer func() {
+		for _, c := range consumers {
+			c.Stop()
+			<-c.StopChan
+		}
+	}()
+
+	strategy := &consumer.BackoffStrategy{}
+	for _, topic := range topics {
+		name, err := nsqTopic(topic)
+		if err != nil {
+			return err
+		}
+
+		c, err := nsq.NewConsumer(name, group, conf)
+		if err != nil {
+			return err
+		}
+
+		c.SetLoggerLevel(nsq.LogLevelWarning)
+		c.AddConcurrentHandlers(nsq.HandlerFunc(func(message *nsq.Message) error {
+			message.DisableAutoResponse()
+
+			// 处理耗时较长的消息定时告知 nsqd 仍在处理，避免超时重新投递
+			ticker := time.NewTicker(30 * time.Second)
+			defer ticker.Stop()
+
+			done := make(chan struct{})
+			defer close(done)
+
+			go func() {
+				for {
+					select {
+					case <-ticker.C:
+						message.Touch()
+					case <-done:
+						return
+					}
+				}
+			}()
+
+			err := handler(context.WithoutCancel(ctx), &Message{Topic: topic, Payload: message.Body, Attempts: message.Attempts})
+			if err == nil {
+				message.Finish()
+				return nil
+			}
+
+			log.Printf("bus nsq handle topic:%s attempts:%d err:%s", topic, message.Attempts, err.Error())
+
+			delay := strategy.Calculate(int(message.Attempts))
+			if delay < 0 || message.Attempts >= maxAttempts {
+				message.Finish()
+				return nil
+			}
+
+			message.RequeueWithoutBackoff(delay)
+			return nil
+		}), concurrency)
+
+		if err := c.ConnectToNSQD(n.addr); err != nil {
+			return err
+		}
+
+		consumers = append(consumers, c)
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// 转换为 NSQ 允许的主题名称
+func nsqTopic(topic string) (string, error) {
+	name := strings.ReplaceAll(topic, ":", ".")
+	if !nsq.IsValidTopicName(name) {
+		return "", fmt.Errorf("invalid nsq topic name: %s", topic)
+	}
+
+	return name, nil
+}
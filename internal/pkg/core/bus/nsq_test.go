@@ -0,0 +1,38 @@
+package bus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nsqio/go-nsq"
+	"github.com/stretchr/testify/assert"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/server"
+)
+
+func TestNsqTopic(t *testing.T) {
+	topics := []string{
+		entity.ImTopicChat,
+		fmt.Sprintf(entity.ImTopicChatPrivate, server.ID()),
+		entity.LoginTopic,
+		entity.LinkPreviewTopic,
+		entity.TalkExportTopic,
+	}
+
+	for _, topic := range topics {
+		name, err := nsqTopic(topic)
+		assert.NoError(t, err, topic)
+		assert.True(t, nsq.IsValidTopicName(name), name)
+	}
+
+	name, _ := nsqTopic(entity.ImTopicChat)
+	assert.Equal(t, "im.message.chat.all", name)
+
+	_, err := nsqTopic("im message")
+	assert.Error(t, err)
+}
+
+func TestNsqChannel(t *testing.T) {
+	assert.True(t, nsq.IsValidChannelName("queue"))
+	assert.True(t, nsq.IsValidChannelName(EphemeralGroup(fmt.Sprintf("comet.%s", server.ID()))))
+}
@@ -0,0 +1,72 @@
+package bus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sourcegraph/conc/pool"
+)
+
+var _ IBus = (*RedisBus)(nil)
+
+// RedisBus 基于 Redis 发布订阅，不持久化消息，订阅者离线期间的消息将丢失
+type RedisBus struct {
+	redis *redis.Client
+}
+
+func NewRedisBus(redis *redis.Client) *RedisBus {
+	return &RedisBus{redis: redis}
+}
+
+func (r *RedisBus) Publish(ctx context.Context, topic string, payloads ...[]byte) error {
+	if len(payloads) == 1 {
+		return r.redis.Publish(ctx, topic, payloads[0]).Err()
+	}
+
+	pipe := r.redis.Pipeline()
+	for _, payload := range payloads {
+		pipe.Publish(ctx, topic, payload)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisBus) Subscribe(ctx context.Context, _ string, topics []string, handler Handler) error {
+	subscribeEach(topics, func(topic string) {
+		r.subscribe(ctx, topic, handler)
+	})
+
+	return nil
+}
+
+func (r *RedisBus) subscribe(ctx context.Context, topic string, handler Handler) {
+	sub := r.redis.Subscribe(ctx, topic)
+	defer func() {
+		_ = sub.Close()
+	}()
+
+	worker := pool.New().WithMaxGoroutines(concurrency)
+	defer worker.Wait()
+
+	ch := sub.Channel(redis.WithChannelHealthCheckInterval(10 * time.Second))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			worker.Go(func() {
+				msg := &Message{Topic: data.Channel, Payload: []byte(data.Payload), Attempts: 1}
+				if err := handler(context.WithoutCancel(ctx), msg); err != nil {
+					log.Printf("bus redis handle topic:%s err:%s", data.Channel, err.Error())
+				}
+			})
+		}
+	}
+}
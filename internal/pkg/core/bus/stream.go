@@ -0,0 +1,257 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sourcegraph/conc/pool"
+	"go-chat/internal/pkg/server"
+)
+
+var _ IBus = (*StreamBus)(nil)
+
+const (
+	streamField     = "payload"
+	streamMaxLen    = 10000          // 默认每个主题保留的消息数
+	streamExpire    = 24 * time.Hour // 主题超过该时长没有新消息时删除(如已下线节点的私有主题)
+	streamBlock     = 5 * time.Second
+	streamClaimIdle = time.Minute      // 消息超过该时长未确认时重新投递
+	streamClaimTick = 30 * time.Second // 检查未确认消息的间隔
+)
+
+// StreamBus 基于 Redis Streams 消费分组，消息处理成功后确认，处理失败或消费者异常退出时重新投递
+type StreamBus struct {
+	redis  *redis.Client
+	maxLen int64
+}
+
+func NewStreamBus(redis *redis.Client, maxLen int64) *StreamBus {
+	if maxLen <= 0 {
+		maxLen = streamMaxLen
+	}
+
+	return &StreamBus{redis: redis, maxLen: maxLen}
+}
+
+func (s *StreamBus) Publish(ctx context.Context, topic string, payloads ...[]byte) error {
+	pipe := s.redis.Pipeline()
+	for _, payload := range payloads {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: topic,
+			MaxLen: s.maxLen,
+			Approx: true,
+			Values: map[string]any{streamField: payload},
+		})
+	}
+
+	pipe.Expire(ctx, topic, streamExpire)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *StreamBus) Subscribe(ctx context.Context, group string, topics []string, handler Handler) error {
+	for _, topic := range topics {
+		if err := s.createGroup(ctx, group, topic); err != nil {
+			return err
+		}
+	}
+
+	subscribeEach(topics, func(topic string) {
+		sub := &streamSubscriber{
+			bus:      s,
+			topic:    topic,
+			group:    group,
+			consumer: server.ID(),
+			handler:  handler,
+			ctx:      context.WithoutCancel(ctx),
+			worker:   pool.New().WithMaxGoroutines(concurrency),
+		}
+
+		sub.run(ctx)
+	})
+
+	return nil
+}
+
+func (s *StreamBus) createGroup(ctx context.Context, group string, topic string) error {
+	err := s.redis.XGroupCreateMkStream(ctx, topic, group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	return nil
+}
+
+type streamSubscriber struct {
+	bus      *StreamBus
+	topic    string
+	group    string
+	consumer string
+	handler  Handler
+	ctx      context.Context
+	worker   *pool.Pool
+	inflight sync.Map // 处理中的消息ID
+}
+
+func (s *streamSubscriber) run(ctx context.Context) {
+	if isEphemeral(s.group) {
+		defer func() {
+			_ = s.bus.redis.XGroupDestroy(context.Background(), s.topic, s.group).Err()
+		}()
+	}
+
+	defer s.worker.Wait()
+
+	// 处理中的消息定时续期，不受读取循环阻塞的影响
+	go func() {
+		ticker := time.NewTicker(streamClaimTick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.touch()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	lastClaim := time.Now()
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(lastClaim) >= streamClaimTick {
+			s.claim()
+			lastClaim = time.Now()
+		}
+
+		items, err := s.bus.redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    s.group,
+			Consumer: s.consumer,
+			Streams:  []string{s.topic, ">"},
+			Count:    100,
+			Block:    streamBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+
+			// 主题过期删除后分组随之删除，需重新创建
+			if strings.HasPrefix(err.Error(), "NOGROUP") {
+				_ = s.bus.createGroup(ctx, s.group, s.topic)
+				continue
+			}
+
+			log.Printf("bus stream read topic:%s group:%s err:%s", s.topic, s.group, err.Error())
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, item := range items {
+			for _, msg := range item.Messages {
+				s.dispatch(msg, 1)
+			}
+		}
+	}
+}
+
+func (s *streamSubscriber) dispatch(msg redis.XMessage, attempts uint16) {
+	if _, ok := s.inflight.LoadOrStore(msg.ID, struct{}{}); ok {
+		return
+	}
+
+	s.worker.Go(func() {
+		defer s.inflight.Delete(msg.ID)
+
+		payload, _ := msg.Values[streamField].(string)
+
+		err := s.handler(s.ctx, &Message{Topic: s.topic, Payload: []byte(payload), Attempts: attempts})
+		if err != nil {
+			log.Printf("bus stream handle topic:%s id:%s attempts:%d err:%s", s.topic, msg.ID, attempts, err.Error())
+			return
+		}
+
+		_ = s.bus.redis.XAck(s.ctx, s.topic, s.group, msg.ID).Err()
+	})
+}
+
+// 重新投递超时未确认的消息
+func (s *streamSubscriber) claim() {
+	pending, err := s.bus.redis.XPendingExt(s.ctx, &redis.XPendingExtArgs{
+		Stream: s.topic,
+		Group:  s.group,
+		Idle:   streamClaimIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  100,
+	}).Result()
+	if err != nil {
+		return
+	}
+
+	ids := make([]string, 0, len(pending))
+	attempts := make(map[string]uint16, len(pending))
+	for _, item := range pending {
+		if _, ok := s.inflight.Load(item.ID); ok {
+			continue
+		}
+
+		if item.RetryCount >= maxAttempts {
+			log.Printf("bus stream drop topic:%s id:%s attempts:%d", s.topic, item.ID, item.RetryCount)
+			_ = s.bus.redis.XAck(s.ctx, s.topic, s.group, item.ID).Err()
+			continue
+		}
+
+		ids = append(ids, item.ID)
+		attempts[item.ID] = uint16(item.RetryCount + 1)
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	items, err := s.bus.redis.XClaim(s.ctx, &redis.XClaimArgs{
+		Stream:   s.topic,
+		Group:    s.group,
+		Consumer: s.consumer,
+		MinIdle:  streamClaimIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return
+	}
+
+	for _, msg := range items {
+		s.dispatch(msg, attempts[msg.ID])
+	}
+}
+
+// 重置处理中消息的空闲时间，避免耗时较长的消息被重复投递
+func (s *streamSubscriber) touch() {
+	ids := make([]string, 0)
+	s.inflight.Range(func(key, _ any) bool {
+		ids = append(ids, key.(string))
+		return true
+	})
+
+	if len(ids) == 0 {
+		return
+	}
+
+	_ = s.bus.redis.XClaimJustID(s.ctx, &redis.XClaimArgs{
+		Stream:   s.topic,
+		Group:    s.group,
+		Consumer: s.consumer,
+		Messages: ids,
+	}).Err()
+}
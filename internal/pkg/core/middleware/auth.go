@@ -16,6 +16,8 @@ const JWTSessionConst = "__JWT_SESSION__"
 
 var (
 	ErrNoAuthorize = errors.New("授权异常，请登录后操作! ")
+	ErrBlackList   = errors.New("请登录再试")
+	ErrParseToken  = errors.New("解析 jwt 失败")
 )
 
 type IStorage interface {
@@ -35,35 +37,43 @@ func Auth(secret string, guard string, storage IStorage) gin.HandlerFunc {
 		// 获取请求头中的 Authorization 字段, 并去除 Bearer 前缀，获取token
 		token := AuthHeaderToken(c)
 
-		// 验证token
-		claims, err := verify(guard, secret, token)
+		session, err := VerifyToken(c.Request.Context(), guard, secret, token, storage)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": 401, "message": err.Error()})
 			return
 		}
 
-		if storage.IsBlackList(c.Request.Context(), token) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "请登录再试"})
-			return
-		}
-
-		uid, err := strconv.Atoi(claims.ID)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"code": 500, "message": "解析 jwt 失败"})
-			return
-		}
-
 		// 设置jwt session到gin框架的context中, 用于后续的请求
-		c.Set(JWTSessionConst, &JSession{
-			Uid:       uid,
-			Token:     token,
-			ExpiresAt: claims.ExpiresAt.Unix(),
-		})
+		c.Set(JWTSessionConst, session)
 
 		c.Next()
 	}
 }
 
+// VerifyToken 验证 token 并返回登录会话，用于 HTTP 以外的连接(如 TCP 握手)复用授权校验
+func VerifyToken(ctx context.Context, guard string, secret string, token string, storage IStorage) (*JSession, error) {
+	// 验证token
+	claims, err := verify(guard, secret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if storage.IsBlackList(ctx, token) {
+		return nil, ErrBlackList
+	}
+
+	uid, err := strconv.Atoi(claims.ID)
+	if err != nil {
+		return nil, ErrParseToken
+	}
+
+	return &JSession{
+		Uid:       uid,
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt.Unix(),
+	}, nil
+}
+
 func AuthHeaderToken(c *gin.Context) string {
 	token := c.GetHeader("Authorization")
 	token = strings.TrimSpace(strings.TrimPrefix(token, "Bearer"))
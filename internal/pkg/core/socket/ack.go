@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"sync"
 	"time"
 
 	"go-chat/internal/pkg/timewheel"
@@ -14,6 +15,7 @@ var ack *AckBuffer
 // AckBuffer Ack 确认缓冲区
 type AckBuffer struct {
 	timeWheel *timewheel.SimpleTimeWheel[*AckBufferContent]
+	callbacks sync.Map // 客户端确认回调 ackid => func()
 }
 
 type AckBufferContent struct {
@@ -45,9 +47,28 @@ func (a *AckBuffer) insert(ackKey string, value *AckBufferContent) {
 
 func (a *AckBuffer) delete(ackKey string) {
 	a.timeWheel.Remove(ackKey)
+
+	if fn, ok := a.callbacks.LoadAndDelete(ackKey); ok {
+		go fn.(func())()
+	}
 }
 
-func (a *AckBuffer) handle(_ *timewheel.SimpleTimeWheel[*AckBufferContent], _ string, bufferContent *AckBufferContent) {
+// 注册客户端确认回调
+func (a *AckBuffer) register(ackKey string, fn func()) {
+	a.callbacks.LoadOrStore(ackKey, fn)
+}
+
+// 最后一次推送后仍等待客户端确认，超时后清理回调
+func (a *AckBuffer) expire(ackKey string) {
+	a.timeWheel.Add(ackKey, &AckBufferContent{}, time.Duration(5)*time.Second)
+}
+
+func (a *AckBuffer) handle(_ *timewheel.SimpleTimeWheel[*AckBufferContent], ackKey string, bufferContent *AckBufferContent) {
+
+	if bufferContent.response == nil {
+		a.callbacks.Delete(ackKey)
+		return
+	}
 
 	ch, ok := Session.Channel(bufferContent.channel)
 	if !ok {
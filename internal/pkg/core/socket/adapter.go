@@ -12,3 +12,9 @@ type IConn interface {
 	// Network 网络协议类型
 	Network() string
 }
+
+// ISubprotocol 支持协商子协议的连接(如 websocket)，用于选择消息编解码器
+type ISubprotocol interface {
+	// Subprotocol 握手时协商的子协议
+	Subprotocol() string
+}
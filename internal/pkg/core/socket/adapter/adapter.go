@@ -4,4 +4,11 @@ package adapter
 const (
 	NetworkWss = "wss"
 	NetworkTcp = "tcp"
+	NetworkSse = "sse"
+)
+
+// 子协议定义(websocket 握手时通过 Sec-WebSocket-Protocol 协商，未指定时使用 JSON 文本帧)
+const (
+	SubprotocolJson     = "json"
+	SubprotocolProtobuf = "protobuf"
 )
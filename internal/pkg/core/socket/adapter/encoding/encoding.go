@@ -8,6 +8,9 @@ import (
 	"sync"
 )
 
+// MaxMessageSize 单条消息的最大长度，避免异常数据导致分配过大的内存
+const MaxMessageSize = 4 << 20
+
 var bufferPool = sync.Pool{
 	New: func() any {
 		return &bytes.Buffer{}
@@ -37,7 +40,9 @@ func NewEncode(data []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	buffer := buf.Bytes()
+	// 缓冲区归还后会被复用，需拷贝一份返回
+	buffer := make([]byte, buf.Len())
+	copy(buffer, buf.Bytes())
 	buf.Reset()
 	bufferPool.Put(buf)
 
@@ -58,6 +63,10 @@ func NewDecode(r io.Reader) ([]byte, error) {
 		return nil, fmt.Errorf("response msg size is negative: %v", length)
 	}
 
+	if length > MaxMessageSize {
+		return nil, fmt.Errorf("response msg size exceeds limit: %v", length)
+	}
+
 	// message binary data
 	buf := make([]byte, length)
 	if _, err := io.ReadFull(r, buf); err != nil {
@@ -3,8 +3,11 @@ package encoding
 import (
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestEncode(t *testing.T) {
@@ -31,3 +34,23 @@ func TestEncode(t *testing.T) {
 		fmt.Println(string(data))
 	}
 }
+
+func TestEncodeReuse(t *testing.T) {
+	first, err := NewEncode([]byte("first"))
+	assert.NoError(t, err)
+
+	_, err = NewEncode([]byte("second"))
+	assert.NoError(t, err)
+
+	data, err := NewDecode(bytes.NewReader(first))
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+}
+
+func TestDecodeMaxMessageSize(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	assert.NoError(t, binary.Write(buf, binary.LittleEndian, int32(MaxMessageSize+1)))
+
+	_, err := NewDecode(buf)
+	assert.Error(t, err)
+}
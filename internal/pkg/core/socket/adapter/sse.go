@@ -0,0 +1,181 @@
+package adapter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+var ErrSseClosed = errors.New("连接已断开")
+
+// SseAdapter Server-Sent Events 适配器(websocket 握手被拦截时的降级方案)
+//
+// 下行消息通过 SSE 推送，连接建立后首先推送 open 事件告知连接ID:
+//
+//	event: open
+//	data: {"id":"连接ID"}
+//
+// 上行消息(心跳、ack 及自定义事件)由客户端携带连接ID POST 提交，经 Push 写入
+type SseAdapter struct {
+	id      string
+	uid     int // 连接所属用户，上行消息仅允许该用户提交
+	writer  http.ResponseWriter
+	flusher http.Flusher
+	inChan  chan []byte
+	done    chan struct{}
+	mutex   sync.Mutex
+	closed  bool
+}
+
+// 连接ID与适配器的映射，上行消息需发送到建立 SSE 连接的节点
+var sseAdapters sync.Map
+
+func NewSseAdapter(w http.ResponseWriter, r *http.Request, uid int) (*SseAdapter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming unsupported")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	s := &SseAdapter{
+		id:      strings.ReplaceAll(uuid.New().String(), "-", ""),
+		uid:     uid,
+		writer:  w,
+		flusher: flusher,
+		inChan:  make(chan []byte, 10),
+		done:    make(chan struct{}),
+	}
+
+	if err := s.write("open", []byte(fmt.Sprintf(`{"id":"%s"}`, s.id))); err != nil {
+		return nil, err
+	}
+
+	sseAdapters.Store(s.id, s)
+
+	// 客户端断开后关闭连接
+	go func() {
+		select {
+		case <-r.Context().Done():
+			_ = s.Close()
+		case <-s.done:
+		}
+	}()
+
+	return s, nil
+}
+
+// GetSseAdapter 根据连接ID获取当前节点上用户的 SSE 连接
+func GetSseAdapter(id string, uid int) (*SseAdapter, bool) {
+	value, ok := sseAdapters.Load(id)
+	if !ok {
+		return nil, false
+	}
+
+	conn := value.(*SseAdapter)
+	if conn.uid != uid {
+		return nil, false
+	}
+
+	return conn, true
+}
+
+// Id 连接ID
+func (s *SseAdapter) Id() string {
+	return s.id
+}
+
+func (s *SseAdapter) Network() string {
+	return NetworkSse
+}
+
+// Push 写入客户端 POST 提交的上行消息
+func (s *SseAdapter) Push(data []byte) error {
+	select {
+	case <-s.done:
+		return ErrSseClosed
+	default:
+	}
+
+	select {
+	case s.inChan <- data:
+		return nil
+	case <-s.done:
+		return ErrSseClosed
+	}
+}
+
+func (s *SseAdapter) Read() ([]byte, error) {
+	select {
+	case data := <-s.inChan:
+		return data, nil
+	case <-s.done:
+		return nil, ErrSseClosed
+	}
+}
+
+func (s *SseAdapter) Write(data []byte) error {
+	return s.write("", data)
+}
+
+// Wait 阻塞至连接关闭，SSE 连接依赖请求处理函数保持响应不结束
+func (s *SseAdapter) Wait() {
+	<-s.done
+}
+
+func (s *SseAdapter) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	sseAdapters.Delete(s.id)
+	close(s.done)
+
+	return nil
+}
+
+// SetCloseHandler 连接断开后 Read 返回错误，由客户端读循环触发关闭回调
+func (s *SseAdapter) SetCloseHandler(fn func(code int, text string) error) {}
+
+func (s *SseAdapter) write(event string, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrSseClosed
+	}
+
+	buf := &bytes.Buffer{}
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("\n")
+
+	if _, err := s.writer.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.flusher.Flush()
+
+	return nil
+}
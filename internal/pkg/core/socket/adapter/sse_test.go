@@ -0,0 +1,71 @@
+package adapter
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSseAdapter(t *testing.T) {
+	conns := make(chan *SseAdapter, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := NewSseAdapter(w, r, 1)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		conns <- conn
+		conn.Wait()
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil || line == "\n" {
+				return strings.Join(lines, "")
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	conn := <-conns
+	assert.Equal(t, "event: open\ndata: {\"id\":\""+conn.Id()+"\"}\n", readEvent())
+
+	_, ok := GetSseAdapter(conn.Id(), 2)
+	assert.False(t, ok)
+
+	value, ok := GetSseAdapter(conn.Id(), 1)
+	assert.True(t, ok)
+	assert.NoError(t, value.Push([]byte(`{"event":"ping"}`)))
+
+	data, err := conn.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"event":"ping"}`, string(data))
+
+	assert.NoError(t, conn.Write([]byte(`{"event":"pong"}`)))
+	assert.Equal(t, "data: {\"event\":\"pong\"}\n", readEvent())
+
+	// 客户端断开后连接关闭并解除注册
+	_ = resp.Body.Close()
+
+	_, err = conn.Read()
+	assert.ErrorIs(t, err, ErrSseClosed)
+	assert.ErrorIs(t, conn.Write([]byte(`{}`)), ErrSseClosed)
+
+	_, ok = GetSseAdapter(conn.Id(), 1)
+	assert.False(t, ok)
+}
@@ -8,7 +8,8 @@ import (
 
 // WsAdapter Websocket 适配器
 type WsAdapter struct {
-	conn *websocket.Conn
+	conn   *websocket.Conn
+	binary bool // 是否使用二进制帧(protobuf 子协议)
 }
 
 var defaultUpGrader = websocket.Upgrader{
@@ -17,6 +18,8 @@ var defaultUpGrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
+	// 按优先级排列，客户端同时支持时优先使用 protobuf
+	Subprotocols: []string{SubprotocolProtobuf, SubprotocolJson},
 }
 
 func NewWsAdapter(w http.ResponseWriter, r *http.Request) (*WsAdapter, error) {
@@ -26,19 +29,32 @@ func NewWsAdapter(w http.ResponseWriter, r *http.Request) (*WsAdapter, error) {
 		return nil, err
 	}
 
-	return &WsAdapter{conn: conn}, nil
+	return &WsAdapter{conn: conn, binary: conn.Subprotocol() == SubprotocolProtobuf}, nil
 }
 
 func (w *WsAdapter) Network() string {
 	return NetworkWss
 }
 
+// Subprotocol 握手时协商的子协议
+func (w *WsAdapter) Subprotocol() string {
+	if w.conn.Subprotocol() == "" {
+		return SubprotocolJson
+	}
+
+	return w.conn.Subprotocol()
+}
+
 func (w *WsAdapter) Read() ([]byte, error) {
 	_, content, err := w.conn.ReadMessage()
 	return content, err
 }
 
 func (w *WsAdapter) Write(bytes []byte) error {
+	if w.binary {
+		return w.conn.WriteMessage(websocket.BinaryMessage, bytes)
+	}
+
 	return w.conn.WriteMessage(websocket.TextMessage, bytes)
 }
 
@@ -84,12 +84,21 @@ func (c *Channel) Start(ctx context.Context) error {
 			}
 
 			c.consume(worker, val, func(data *SenderContent, value *Client) {
-				_ = value.Write(&ClientResponse{
+				response := &ClientResponse{
 					IsAck:   data.IsAck,
 					Event:   data.message.Event,
 					Content: data.message.Content,
 					Retry:   3,
-				})
+					replay:  true,
+				}
+
+				if data.IsAck && data.onAck != nil {
+					response.OnAck = func() {
+						data.onAck(value.Cid(), value.Uid())
+					}
+				}
+
+				_ = value.Write(response)
 			})
 		}
 	}
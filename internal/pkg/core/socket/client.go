@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -44,22 +45,39 @@ type Client struct {
 	storage  IStorage             // 缓存服务
 	event    IEvent               // 回调方法
 	outChan  chan *ClientResponse // 发送通道
+	mutex    sync.Mutex           // 保证事件ID与推送顺序一致
+	replay   IReplayStorage       // 事件缓冲区
+	session  string               // 会话标识(断线重连时用于补发事件)
+	lastId   string               // 客户端已收到的最后事件ID
+	resumed  bool                 // 是否恢复了断开前的会话
+	eventIds *eventIdGenerator    // 事件ID生成器
+	events   chan *ReplayEvent    // 等待写入缓冲区的事件
+	detached chan struct{}        // 连接断开后通知写完剩余事件并保留缓冲区
+	acks     *replayAckSet        // 等待确认的事件回调
+	codec    ICodec               // 消息编解码器(按连接协商的子协议选择)
 }
 
 type ClientOption struct {
-	Uid         int         // 用户识别ID
-	Channel     IChannel    // 渠道信息
-	Storage     IStorage    // 自定义缓存组件，用于绑定用户与客户端的关系
-	IdGenerator IdGenerator // 客户端ID生成器(唯一ID), 默认使用雪花算法
-	Buffer      int         // 缓冲区大小根据业务，自行调整
+	Uid         int            // 用户识别ID
+	Channel     IChannel       // 渠道信息
+	Storage     IStorage       // 自定义缓存组件，用于绑定用户与客户端的关系
+	IdGenerator IdGenerator    // 客户端ID生成器(唯一ID), 默认使用雪花算法
+	Buffer      int            // 缓冲区大小根据业务，自行调整
+	Replay      IReplayStorage // 事件缓冲区，为空时不支持断线重连补发
+	Resume      bool           // 客户端是否支持断线重连补发，不支持时不创建会话
+	Session     string         // 断线重连时恢复的会话标识
+	LastEventId string         // 断线重连时客户端已收到的最后事件ID
 }
 
 type ClientResponse struct {
-	IsAck   bool   `json:"-"`                 // 是否需要 ack 回调
-	Ackid   string `json:"ackid,omitempty"`   // ACK ID
-	Event   string `json:"event"`             // 事件名
-	Content any    `json:"payload,omitempty"` // 事件内容
-	Retry   int    `json:"-"`                 // 重试次数（0 默认不重试）
+	IsAck   bool   `json:"-"`                  // 是否需要 ack 回调
+	Ackid   string `json:"ackid,omitempty"`    // ACK ID
+	Event   string `json:"event"`              // 事件名
+	Content any    `json:"payload,omitempty"`  // 事件内容
+	Retry   int    `json:"-"`                  // 重试次数（0 默认不重试）
+	OnAck   func() `json:"-"`                  // 客户端确认回调
+	EventId string `json:"event_id,omitempty"` // 事件ID(写入缓冲区的事件才有)
+	replay  bool   // 是否写入事件缓冲区
 }
 
 // NewClient 初始化客户端信息
@@ -80,6 +98,8 @@ func NewClient(conn IConn, option *ClientOption, event IEvent) error {
 		storage:  option.Storage,
 		outChan:  make(chan *ClientResponse, option.Buffer),
 		event:    event,
+		replay:   option.Replay,
+		codec:    codecOf(conn),
 	}
 
 	if option.IdGenerator != nil {
@@ -109,6 +129,9 @@ func NewClient(conn IConn, option *ClientOption, event IEvent) error {
 	// 注册心跳管理
 	health.insert(client)
 
+	// 绑定会话(在注册客户端之后，避免接管会话的间隙丢失事件)
+	client.bindSession(option.Resume, option.Session, option.LastEventId)
+
 	return client.init()
 }
 
@@ -153,10 +176,18 @@ func (c *Client) Write(data *ClientResponse) error {
 		}
 	}()
 
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	if c.Closed() {
 		return fmt.Errorf("connection has been closed")
 	}
 
+	// 重试推送的事件已写入缓冲区
+	if data.replay && data.EventId == "" {
+		c.append(data)
+	}
+
 	if data.IsAck && data.Ackid == "" {
 		data.Ackid = strings.ReplaceAll(uuid.New().String(), "-", "")
 	}
@@ -184,19 +215,36 @@ func (c *Client) loopAccept() {
 
 // 循环推送客户端信息
 func (c *Client) loopWrite() {
+	// 优先推送连接配置及断线前未收到的事件
+	if err := c.handshake(); err != nil {
+		log.Printf("[ERROR] [%s-%d-%d] client handshake err: %v \n", c.channel.Name(), c.cid, c.uid, err)
+		return
+	}
+
 	for data := range c.outChan {
 		if c.Closed() {
 			return
 		}
 
-		bt, err := json.Marshal(data)
+		// 已通过补发推送的事件不再重复推送
+		if data.EventId != "" && c.lastId != "" && compareEventId(data.EventId, c.lastId) <= 0 {
+			continue
+		}
+
+		bt, err := c.codec.Encode(data)
 		if err != nil {
-			log.Printf("[ERROR] client json marshal err: %v \n", err)
+			log.Printf("[ERROR] client encode err: %v \n", err)
 			break
 		}
 
+		// 先注册回调，避免客户端确认先于注册到达
+		if data.IsAck && data.OnAck != nil {
+			ack.register(data.Ackid, data.OnAck)
+		}
+
 		if err := c.conn.Write(bt); err != nil {
 			log.Printf("[ERROR] [%s-%d-%d] client write err: %v \n", c.channel.Name(), c.cid, c.uid, err)
+			ack.callbacks.Delete(data.Ackid)
 			return
 		}
 
@@ -210,6 +258,8 @@ func (c *Client) loopWrite() {
 			ackBufferContent.response = data
 
 			ack.insert(data.Ackid, ackBufferContent)
+		} else if data.IsAck && data.OnAck != nil {
+			ack.expire(data.Ackid)
 		}
 	}
 }
@@ -217,18 +267,13 @@ func (c *Client) loopWrite() {
 // 初始化连接
 func (c *Client) init() error {
 
-	// 推送心跳检测配置
-	_ = c.Write(&ClientResponse{
-		Event: "connect",
-		Content: map[string]any{
-			"ping_interval": heartbeatInterval,
-			"ping_timeout":  heartbeatTimeout,
-		}},
-	)
-
 	// 启动协程处理推送信息
 	go c.loopWrite()
 
+	if c.session != "" {
+		go c.loopReplay()
+	}
+
 	go c.loopAccept()
 
 	return nil
@@ -244,6 +289,12 @@ func (c *Client) hookClose(code int, text string) error {
 
 	c.event.Close(c, code, text)
 
+	// 仅保留事件缓冲区等待客户端重连，连接相关的绑定关系立即解除
+	if c.session != "" {
+		close(c.detached)
+		c.acks.detach(c.session, c.cid)
+	}
+
 	if c.storage != nil {
 		err := c.storage.UnBind(context.Background(), server.ID(), c.channel.Name(), c.cid)
 		if err != nil {
@@ -261,6 +312,12 @@ func (c *Client) hookClose(code int, text string) error {
 
 func (c *Client) handleMessage(data []byte) {
 
+	data, err := c.codec.Decode(data)
+	if err != nil {
+		log.Printf("[ERROR] decode err: %s \n", err.Error())
+		return
+	}
+
 	event, err := c.validate(data)
 	if err != nil {
 		log.Printf("[ERROR] validate err: %s \n", err.Error())
@@ -294,3 +351,174 @@ func (c *Client) validate(data []byte) (string, error) {
 
 	return res.String(), nil
 }
+
+// 绑定会话，客户端携带有效的会话标识时接管原会话
+func (c *Client) bindSession(resume bool, session string, lastId string) {
+	if c.replay == nil || !resume {
+		return
+	}
+
+	// 绑定完成前推送的事件等待会话确定后再写入缓冲区
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ctx := context.Background()
+
+	if session != "" && lastId != "" {
+		ok, err := c.replay.Resume(ctx, c.uid, session, c.cid)
+		if err != nil {
+			log.Println("[ERROR] resume session err: ", err.Error())
+		}
+
+		// 原会话可能在其它节点写入了客户端未收到的事件，事件ID需从缓冲区中最新的事件之后开始
+		var top string
+		if ok {
+			if top, err = c.replay.Last(ctx, c.uid, session); err != nil {
+				log.Println("[ERROR] resume session err: ", err.Error())
+				ok = false
+			}
+		}
+
+		if ok {
+			c.lastId, c.resumed = lastId, true
+			c.setSession(session, lastId)
+			c.eventIds.advance(top)
+			return
+		}
+	}
+
+	session = newReplaySession()
+	if err := c.replay.Bind(ctx, c.uid, session, c.cid); err != nil {
+		log.Println("[ERROR] bind session err: ", err.Error())
+		return
+	}
+
+	c.setSession(session, "")
+}
+
+func (c *Client) setSession(session string, lastId string) {
+	c.session = session
+	c.eventIds = newEventIdGenerator(lastId)
+	c.events = make(chan *ReplayEvent, replayQueueSize)
+	c.detached = make(chan struct{})
+	c.acks = loadReplayAckSet(session, c.cid)
+}
+
+// 设置事件ID并投递到缓冲区写入队列
+func (c *Client) append(data *ClientResponse) {
+	if c.session == "" {
+		return
+	}
+
+	payload, err := json.Marshal(data.Content)
+	if err != nil {
+		return
+	}
+
+	data.EventId = c.eventIds.next(time.Now())
+
+	// 确认回调随会话保留，补发的事件被确认时同样触发
+	if data.IsAck && data.OnAck != nil {
+		c.acks.add(data.EventId, data.OnAck)
+		data.OnAck = c.acks.callback(data.EventId)
+	}
+
+	select {
+	case c.events <- &ReplayEvent{Id: data.EventId, Event: data.Event, Payload: payload}:
+	default:
+		log.Printf("[ERROR] [%s-%d-%d] client replay queue is full, event:%s \n", c.channel.Name(), c.cid, c.uid, data.EventId)
+	}
+}
+
+// 循环写入事件缓冲区，连接断开后写完剩余事件并保留缓冲区等待重连
+func (c *Client) loopReplay() {
+	ctx := context.Background()
+
+	write := func(item *ReplayEvent) {
+		if err := c.replay.Append(ctx, c.uid, c.session, c.cid, item); err != nil {
+			log.Printf("[ERROR] [%s-%d-%d] client append event err: %v \n", c.channel.Name(), c.cid, c.uid, err)
+		}
+	}
+
+	for {
+		select {
+		case item := <-c.events:
+			write(item)
+		case <-c.detached:
+			for {
+				select {
+				case item := <-c.events:
+					write(item)
+				default:
+					if err := c.replay.Detach(ctx, c.uid, c.session, c.cid, replayDetachTimeout); err != nil {
+						log.Println("[ERROR] detach session err: ", err.Error())
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// 推送心跳检测配置，恢复会话时补发断线前未收到的事件
+func (c *Client) handshake() error {
+	content := map[string]any{
+		"ping_interval": heartbeatInterval,
+		"ping_timeout":  heartbeatTimeout,
+	}
+
+	var items []*ReplayEvent
+	if c.resumed {
+		list, err := c.replay.Range(context.Background(), c.uid, c.session, c.lastId, replayMaxCount)
+		if err != nil {
+			// 无法补发时告知客户端会话未恢复，由客户端通过增量同步接口拉取
+			log.Printf("[ERROR] [%s-%d-%d] client replay err: %v \n", c.channel.Name(), c.cid, c.uid, err)
+			c.resumed = false
+		}
+
+		items = list
+	}
+
+	if c.session != "" {
+		content["session"] = c.session
+		content["resumed"] = c.resumed
+	}
+
+	if err := c.writeConn(&ClientResponse{Event: "connect", Content: content}); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		data := &ClientResponse{EventId: item.Id, Event: item.Event, Content: item.Payload}
+
+		if c.acks.has(item.Id) {
+			data.IsAck = true
+			data.Ackid = strings.ReplaceAll(uuid.New().String(), "-", "")
+			data.OnAck = c.acks.callback(item.Id)
+
+			ack.register(data.Ackid, data.OnAck)
+		}
+
+		if err := c.writeConn(data); err != nil {
+			ack.callbacks.Delete(data.Ackid)
+			return err
+		}
+
+		if data.IsAck {
+			ack.expire(data.Ackid)
+		}
+
+		c.lastId = item.Id
+	}
+
+	return nil
+}
+
+func (c *Client) writeConn(data *ClientResponse) error {
+	bt, err := c.codec.Encode(data)
+	if err != nil {
+		return err
+	}
+
+	return c.conn.Write(bt)
+}
@@ -0,0 +1,132 @@
+package socket
+
+import (
+	"encoding/json"
+
+	cometpb "go-chat/api/pb/comet/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// 编码格式名称，与连接协商的子协议名称一致
+const (
+	CodecJson     = "json"
+	CodecProtobuf = "protobuf"
+)
+
+// ICodec 客户端消息编解码器
+type ICodec interface {
+	// Encode 编码推送给客户端的消息
+	Encode(data *ClientResponse) ([]byte, error)
+	// Decode 将客户端消息转换为 JSON 格式 {"event":"","ackid":"","payload":{}}
+	Decode(data []byte) ([]byte, error)
+}
+
+var codecs = map[string]ICodec{
+	CodecJson:     &JsonCodec{},
+	CodecProtobuf: &ProtobufCodec{},
+}
+
+// 根据连接协商的子协议选择编解码器，默认使用 JSON
+func codecOf(conn IConn) ICodec {
+	if c, ok := conn.(ISubprotocol); ok {
+		if codec, ok := codecs[c.Subprotocol()]; ok {
+			return codec
+		}
+	}
+
+	return codecs[CodecJson]
+}
+
+// JsonCodec JSON 文本帧
+type JsonCodec struct{}
+
+func (JsonCodec) Encode(data *ClientResponse) ([]byte, error) {
+	return json.Marshal(data)
+}
+
+func (JsonCodec) Decode(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// 已定义 protobuf 结构的事件与 Envelope.payload 字段的映射，其它事件使用 JSON 编码的 payload
+var protobufEvents = map[string]protoreflect.Name{
+	"im.message":          "message",
+	"im.message.revoke":   "message_revoke",
+	"im.message.keyboard": "message_keyboard",
+	"im.contact.apply":    "contact_apply",
+	"im.contact.status":   "contact_status",
+	"im.group.apply":      "group_apply",
+}
+
+// ProtobufCodec protobuf 二进制帧，消息结构见 api/proto/comet/v1/message.proto
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Encode(data *ClientResponse) ([]byte, error) {
+	in := &cometpb.Envelope{
+		Event:   data.Event,
+		Ackid:   data.Ackid,
+		EventId: data.EventId,
+	}
+
+	if data.Content != nil {
+		payload, err := json.Marshal(data.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		if !setProtobufPayload(in, payload) {
+			in.Payload = &cometpb.Envelope_Json{Json: payload}
+		}
+	}
+
+	return proto.Marshal(in)
+}
+
+func (ProtobufCodec) Decode(data []byte) ([]byte, error) {
+	in := &cometpb.Envelope{}
+	if err := proto.Unmarshal(data, in); err != nil {
+		return nil, err
+	}
+
+	msg := map[string]any{"event": in.Event}
+	if in.Ackid != "" {
+		msg["ackid"] = in.Ackid
+	}
+
+	ref := in.ProtoReflect()
+	if fd := ref.WhichOneof(ref.Descriptor().Oneofs().ByName("payload")); fd != nil {
+		if fd.Kind() == protoreflect.BytesKind {
+			msg["payload"] = json.RawMessage(in.GetJson())
+		} else {
+			payload, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(ref.Get(fd).Message().Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			msg["payload"] = json.RawMessage(payload)
+		}
+	}
+
+	return json.Marshal(msg)
+}
+
+// 将 JSON 编码的 payload 转换为事件对应的 protobuf 结构
+func setProtobufPayload(in *cometpb.Envelope, payload []byte) bool {
+	name, ok := protobufEvents[in.Event]
+	if !ok {
+		return false
+	}
+
+	ref := in.ProtoReflect()
+	fd := ref.Descriptor().Fields().ByName(name)
+
+	value := ref.NewField(fd)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(payload, value.Message().Interface()); err != nil {
+		return false
+	}
+
+	ref.Set(fd, value)
+	return true
+}
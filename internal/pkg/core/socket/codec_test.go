@@ -0,0 +1,88 @@
+package socket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	cometpb "go-chat/api/pb/comet/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+type testConn struct {
+	IConn
+	subprotocol string
+}
+
+func (t *testConn) Subprotocol() string {
+	return t.subprotocol
+}
+
+func TestCodecOf(t *testing.T) {
+	assert.IsType(t, &JsonCodec{}, codecOf(&testConn{}))
+	assert.IsType(t, &JsonCodec{}, codecOf(&testConn{subprotocol: "json"}))
+	assert.IsType(t, &ProtobufCodec{}, codecOf(&testConn{subprotocol: "protobuf"}))
+}
+
+func TestProtobufCodecEncode(t *testing.T) {
+	codec := &ProtobufCodec{}
+
+	bt, err := codec.Encode(&ClientResponse{
+		Ackid:   "ack",
+		Event:   "im.message",
+		EventId: "1697000000000-1",
+		Content: map[string]any{
+			"talk_mode":  2,
+			"from_id":    1,
+			"to_from_id": 100,
+			"body": map[string]any{
+				"msg_id":   "msg",
+				"sequence": 10,
+				"extra":    map[string]any{"content": "hello"},
+				"unknown":  "discard",
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	in := &cometpb.Envelope{}
+	assert.NoError(t, proto.Unmarshal(bt, in))
+	assert.Equal(t, "im.message", in.Event)
+	assert.Equal(t, "ack", in.Ackid)
+	assert.Equal(t, "1697000000000-1", in.EventId)
+	assert.Equal(t, int32(100), in.GetMessage().ToFromId)
+	assert.Equal(t, "msg", in.GetMessage().Body.MsgId)
+	assert.Equal(t, int64(10), in.GetMessage().Body.Sequence)
+	assert.Equal(t, "hello", in.GetMessage().Body.Extra.GetStructValue().Fields["content"].GetStringValue())
+
+	// 未定义 protobuf 结构的事件使用 JSON 编码的 payload
+	bt, err = codec.Encode(&ClientResponse{Event: "im.message.read", Content: map[string]any{"msg_id": "msg"}})
+	assert.NoError(t, err)
+
+	in = &cometpb.Envelope{}
+	assert.NoError(t, proto.Unmarshal(bt, in))
+	assert.JSONEq(t, `{"msg_id":"msg"}`, string(in.GetJson()))
+}
+
+func TestProtobufCodecDecode(t *testing.T) {
+	codec := &ProtobufCodec{}
+
+	bt, _ := proto.Marshal(&cometpb.Envelope{
+		Event:   "im.message.keyboard",
+		Payload: &cometpb.Envelope_MessageKeyboard{MessageKeyboard: &cometpb.ImMessageKeyboardPayload{ToFromId: 2054}},
+	})
+
+	data, err := codec.Decode(bt)
+	assert.NoError(t, err)
+	assert.Equal(t, "im.message.keyboard", gjson.GetBytes(data, "event").String())
+	assert.Equal(t, int64(2054), gjson.GetBytes(data, "payload.to_from_id").Int())
+
+	bt, _ = proto.Marshal(&cometpb.Envelope{Event: "ack", Ackid: "ack"})
+
+	data, err = codec.Decode(bt)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"event":"ack","ackid":"ack"}`, string(data))
+
+	_, err = codec.Decode([]byte("{}"))
+	assert.Error(t, err)
+}
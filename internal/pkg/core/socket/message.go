@@ -16,10 +16,11 @@ func NewMessage(event string, content any) *Message {
 // SenderContent 推送的消息
 type SenderContent struct {
 	IsAck     bool
-	broadcast bool     // 是否广播消息
-	exclude   []int64  // 排除的用户(预留)
-	receives  []int64  // 推送的用户
-	message   *Message // 消息体
+	onAck     func(cid int64, uid int) // 客户端确认回调
+	broadcast bool                     // 是否广播消息
+	exclude   []int64                  // 排除的用户(预留)
+	receives  []int64                  // 推送的用户
+	message   *Message                 // 消息体
 }
 
 func NewSenderContent() *SenderContent {
@@ -35,6 +36,12 @@ func (s *SenderContent) SetAck(value bool) *SenderContent {
 	return s
 }
 
+// SetAckCallback 设置客户端确认收到消息后的回调(每个确认的客户端都会触发)
+func (s *SenderContent) SetAckCallback(fn func(cid int64, uid int)) *SenderContent {
+	s.onAck = fn
+	return s
+}
+
 // SetBroadcast 设置广播推送
 func (s *SenderContent) SetBroadcast(value bool) *SenderContent {
 	s.broadcast = value
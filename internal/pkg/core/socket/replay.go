@@ -0,0 +1,164 @@
+package socket
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	replayDetachTimeout = 60 * time.Second // 客户端断开后缓冲区保留时长，期间重连可补发未收到的事件
+	replayMaxCount      = 1000             // 单次补发的最大事件数
+	replayQueueSize     = 256              // 等待写入缓冲区的事件数，超过后丢弃(仅影响补发，不影响实时推送)
+)
+
+// IReplayStorage 客户端事件缓冲区，用于断线重连后补发断线前未收到的事件
+type IReplayStorage interface {
+	// Bind 创建会话并绑定当前持有的客户端
+	Bind(ctx context.Context, uid int, session string, cid int64) error
+	// Detach 客户端断开后保留缓冲区 ttl 时长等待重连(会话已被其它客户端接管时不做处理)
+	Detach(ctx context.Context, uid int, session string, cid int64, ttl time.Duration) error
+	// Resume 接管会话，会话不存在或已过期时返回 false
+	Resume(ctx context.Context, uid int, session string, cid int64) (bool, error)
+	// Append 写入指定ID的事件，客户端非会话持有者时不写入
+	Append(ctx context.Context, uid int, session string, cid int64, event *ReplayEvent) error
+	// Range 获取指定事件ID之后的事件
+	Range(ctx context.Context, uid int, session string, lastId string, count int64) ([]*ReplayEvent, error)
+	// Last 获取缓冲区中最新的事件ID，缓冲区为空时返回空字符串
+	Last(ctx context.Context, uid int, session string) (string, error)
+}
+
+// ReplayEvent 缓冲区中的事件
+type ReplayEvent struct {
+	Id      string          // 事件ID
+	Event   string          // 事件名
+	Payload json.RawMessage // 事件内容
+}
+
+// 生成会话标识
+func newReplaySession() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// 比较事件ID大小，事件ID格式为 毫秒时间戳-序号
+func compareEventId(a, b string) int {
+	ams, aseq := parseEventId(a)
+	bms, bseq := parseEventId(b)
+
+	if ams != bms {
+		return cmp.Compare(ams, bms)
+	}
+
+	return cmp.Compare(aseq, bseq)
+}
+
+func parseEventId(id string) (uint64, uint64) {
+	ms, seq, _ := strings.Cut(id, "-")
+
+	msValue, _ := strconv.ParseUint(ms, 10, 64)
+	seqValue, _ := strconv.ParseUint(seq, 10, 64)
+
+	return msValue, seqValue
+}
+
+// 事件ID生成器，本地生成单调递增的ID，避免写入缓冲区阻塞推送
+type eventIdGenerator struct {
+	ms  uint64
+	seq uint64
+}
+
+// 以已知的最大事件ID为起点，保证接管会话后的事件ID大于原会话
+func newEventIdGenerator(lastId string) *eventIdGenerator {
+	ms, seq := parseEventId(lastId)
+	return &eventIdGenerator{ms: ms, seq: seq}
+}
+
+// 将起点推进到指定事件ID，小于当前起点时不做处理
+func (g *eventIdGenerator) advance(id string) {
+	if ms, seq := parseEventId(id); ms > g.ms || (ms == g.ms && seq > g.seq) {
+		g.ms, g.seq = ms, seq
+	}
+}
+
+func (g *eventIdGenerator) next(now time.Time) string {
+	if ms := uint64(now.UnixMilli()); ms > g.ms {
+		g.ms, g.seq = ms, 0
+	} else {
+		g.seq++
+	}
+
+	return strconv.FormatUint(g.ms, 10) + "-" + strconv.FormatUint(g.seq, 10)
+}
+
+// 会话中等待客户端确认的事件回调，同一节点内恢复会话时补发的事件沿用原回调
+var replayAcks sync.Map // session => *replayAckSet
+
+type replayAckSet struct {
+	mutex sync.Mutex
+	owner int64             // 会话当前持有的客户端
+	items map[string]func() // 事件ID => 确认回调
+}
+
+func loadReplayAckSet(session string, cid int64) *replayAckSet {
+	value, _ := replayAcks.LoadOrStore(session, &replayAckSet{items: map[string]func(){}})
+
+	set := value.(*replayAckSet)
+	set.mutex.Lock()
+	set.owner = cid
+	set.mutex.Unlock()
+
+	return set
+}
+
+// 客户端断开后保留回调至缓冲区过期，期间未被接管则清除
+func (s *replayAckSet) detach(session string, cid int64) {
+	time.AfterFunc(replayDetachTimeout, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.owner == cid {
+			replayAcks.CompareAndDelete(session, s)
+		}
+	})
+}
+
+func (s *replayAckSet) add(id string, fn func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.items[id] = fn
+}
+
+func (s *replayAckSet) has(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.items[id]
+	return ok
+}
+
+// 取出并移除回调，保证同一事件只回调一次
+func (s *replayAckSet) take(id string) func() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	fn := s.items[id]
+	delete(s.items, id)
+
+	return fn
+}
+
+// 确认回调，事件被补发后由任一客户端确认均只触发一次
+func (s *replayAckSet) callback(id string) func() {
+	return func() {
+		if fn := s.take(id); fn != nil {
+			fn()
+		}
+	}
+}
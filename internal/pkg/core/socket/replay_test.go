@@ -0,0 +1,358 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareEventId(t *testing.T) {
+	assert.Equal(t, 0, compareEventId("1697000000000-1", "1697000000000-1"))
+	assert.Equal(t, -1, compareEventId("1697000000000-9", "1697000000000-10"))
+	assert.Equal(t, 1, compareEventId("1697000000001-0", "1697000000000-10"))
+	assert.Equal(t, 1, compareEventId("1697000000000-0", ""))
+}
+
+func TestEventIdGenerator(t *testing.T) {
+	now := time.UnixMilli(1697000000000)
+
+	g := newEventIdGenerator("")
+	assert.Equal(t, "1697000000000-0", g.next(now))
+	assert.Equal(t, "1697000000000-1", g.next(now))
+	assert.Equal(t, "1697000000001-0", g.next(now.Add(time.Millisecond)))
+
+	// 接管会话时以客户端最后收到的事件ID为起点，时钟回拨也保持递增
+	g = newEventIdGenerator("1697000000005-3")
+	assert.Equal(t, "1697000000005-4", g.next(now))
+	assert.Equal(t, 1, compareEventId(g.next(now), "1697000000005-4"))
+
+	// 起点只前进不后退
+	g = newEventIdGenerator("1697000000005-3")
+	g.advance("1697000000005-1")
+	assert.Equal(t, "1697000000005-4", g.next(now))
+	g.advance("1697000000009-2")
+	assert.Equal(t, "1697000000009-3", g.next(now))
+}
+
+type testReplayStorage struct {
+	mutex    sync.Mutex
+	owner    int64
+	events   []*ReplayEvent
+	block    chan struct{} // 模拟写入缓慢的存储
+	rangeErr error
+	detached chan time.Duration
+}
+
+func newTestReplayStorage() *testReplayStorage {
+	return &testReplayStorage{detached: make(chan time.Duration, 10)}
+}
+
+func (s *testReplayStorage) Bind(_ context.Context, _ int, _ string, cid int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.owner = cid
+	return nil
+}
+
+func (s *testReplayStorage) Detach(_ context.Context, _ int, _ string, cid int64, ttl time.Duration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.owner == cid {
+		s.detached <- ttl
+	}
+
+	return nil
+}
+
+func (s *testReplayStorage) Resume(_ context.Context, _ int, _ string, cid int64) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.owner = cid
+	return true, nil
+}
+
+func (s *testReplayStorage) Append(_ context.Context, _ int, _ string, cid int64, event *ReplayEvent) error {
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.owner == cid {
+		s.events = append(s.events, event)
+	}
+
+	return nil
+}
+
+func (s *testReplayStorage) Range(_ context.Context, _ int, _ string, lastId string, _ int64) ([]*ReplayEvent, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.rangeErr != nil {
+		return nil, s.rangeErr
+	}
+
+	items := make([]*ReplayEvent, 0)
+	for _, item := range s.events {
+		if compareEventId(item.Id, lastId) > 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+func (s *testReplayStorage) Last(context.Context, int, string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.events) == 0 {
+		return "", nil
+	}
+
+	return s.events[len(s.events)-1].Id, nil
+}
+
+func (s *testReplayStorage) count() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.events)
+}
+
+type testClientStorage struct {
+	unbind atomic.Int32
+}
+
+func (s *testClientStorage) Bind(context.Context, string, string, int64, int) error { return nil }
+
+func (s *testClientStorage) UnBind(context.Context, string, string, int64) error {
+	s.unbind.Add(1)
+	return nil
+}
+
+type testReplayConn struct {
+	out  chan map[string]any
+	done chan struct{}
+	once sync.Once
+}
+
+func newTestReplayConn() *testReplayConn {
+	return &testReplayConn{out: make(chan map[string]any, 100), done: make(chan struct{})}
+}
+
+func (c *testReplayConn) Read() ([]byte, error) {
+	<-c.done
+	return nil, errors.New("closed")
+}
+
+func (c *testReplayConn) Write(data []byte) error {
+	var value map[string]any
+	_ = json.Unmarshal(data, &value)
+	c.out <- value
+	return nil
+}
+
+func (c *testReplayConn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return nil
+}
+
+func (c *testReplayConn) SetCloseHandler(func(code int, text string) error) {}
+func (c *testReplayConn) Network() string                                   { return "test" }
+
+func (c *testReplayConn) frame(t *testing.T) map[string]any {
+	select {
+	case value := <-c.out:
+		return value
+	case <-time.After(time.Second):
+		t.Fatal("wait frame timeout")
+		return nil
+	}
+}
+
+var startReplayTest sync.Once
+
+type testIdGenerator struct {
+	id atomic.Int64
+}
+
+func (g *testIdGenerator) IdGen() int64 {
+	return g.id.Add(1)
+}
+
+var testClientIds = &testIdGenerator{}
+
+func newReplayTestClient(t *testing.T, channel *Channel, replay IReplayStorage, storage IStorage, option ClientOption) (*Client, *testReplayConn) {
+	startReplayTest.Do(InitAck)
+
+	conn := newTestReplayConn()
+
+	option.Channel = channel
+	option.Replay = replay
+	option.Storage = storage
+	option.IdGenerator = testClientIds
+	assert.NoError(t, NewClient(conn, &option, NewEvent()))
+
+	client, ok := channel.Client(testClientIds.id.Load())
+	assert.True(t, ok)
+
+	return client, conn
+}
+
+func TestClientReleaseWithoutResume(t *testing.T) {
+	channel := NewChannel("test", make(chan *SenderContent, 10))
+	replay := newTestReplayStorage()
+	storage := &testClientStorage{}
+
+	client, conn := newReplayTestClient(t, channel, replay, storage, ClientOption{Uid: 1})
+
+	connect := conn.frame(t)
+	assert.Equal(t, "connect", connect["event"])
+	assert.NotContains(t, connect["payload"], "session")
+
+	assert.NoError(t, client.Write(&ClientResponse{Event: "im.message", Content: map[string]any{"id": 1}, replay: true}))
+	assert.Empty(t, conn.frame(t)["event_id"])
+
+	// 连接断开后立即解除绑定关系
+	client.Close(1000, "close")
+	assert.Equal(t, int32(1), storage.unbind.Load())
+	assert.Equal(t, int64(0), channel.Count())
+	assert.Equal(t, 0, replay.count())
+}
+
+func TestClientReplayAsync(t *testing.T) {
+	channel := NewChannel("test", make(chan *SenderContent, 10))
+	replay := newTestReplayStorage()
+	replay.block = make(chan struct{})
+	storage := &testClientStorage{}
+
+	client, conn := newReplayTestClient(t, channel, replay, storage, ClientOption{Uid: 1, Buffer: 20, Resume: true})
+
+	connect := conn.frame(t)
+	payload := connect["payload"].(map[string]any)
+	assert.NotEmpty(t, payload["session"])
+	assert.Equal(t, false, payload["resumed"])
+
+	// 存储阻塞时推送不受影响
+	ids := make([]string, 0)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, client.Write(&ClientResponse{Event: "im.message", Content: map[string]any{"id": i}, replay: true}))
+
+		id := conn.frame(t)["event_id"].(string)
+		if len(ids) > 0 {
+			assert.Equal(t, 1, compareEventId(id, ids[len(ids)-1]))
+		}
+
+		ids = append(ids, id)
+	}
+
+	assert.Equal(t, 0, replay.count())
+
+	// 连接断开后立即解除绑定关系，缓冲区写完剩余事件后保留等待重连
+	client.Close(1000, "close")
+	assert.Equal(t, int32(1), storage.unbind.Load())
+	assert.Equal(t, int64(0), channel.Count())
+
+	close(replay.block)
+
+	select {
+	case ttl := <-replay.detached:
+		assert.Equal(t, replayDetachTimeout, ttl)
+	case <-time.After(time.Second):
+		t.Fatal("wait detach timeout")
+	}
+
+	assert.Equal(t, 5, replay.count())
+	for i, item := range replay.events {
+		assert.Equal(t, ids[i], item.Id)
+	}
+}
+
+func TestClientResumeRangeError(t *testing.T) {
+	channel := NewChannel("test", make(chan *SenderContent, 10))
+	replay := newTestReplayStorage()
+	replay.rangeErr = errors.New("range error")
+
+	_, conn := newReplayTestClient(t, channel, replay, nil, ClientOption{Uid: 1, Resume: true, Session: "session", LastEventId: "1697000000000-0"})
+
+	payload := conn.frame(t)["payload"].(map[string]any)
+	assert.Equal(t, "session", payload["session"])
+	assert.Equal(t, false, payload["resumed"])
+}
+
+func TestClientResumeKeepsOnAck(t *testing.T) {
+	channel := NewChannel("test", make(chan *SenderContent, 10))
+	replay := newTestReplayStorage()
+
+	client, conn := newReplayTestClient(t, channel, replay, nil, ClientOption{Uid: 1, Resume: true})
+	session := conn.frame(t)["payload"].(map[string]any)["session"].(string)
+
+	var acked atomic.Int32
+	assert.NoError(t, client.Write(&ClientResponse{Event: "im.message", IsAck: true, Content: map[string]any{"id": 1}, replay: true}))
+	first := conn.frame(t)
+	assert.NoError(t, client.Write(&ClientResponse{Event: "im.message", IsAck: true, Content: map[string]any{"id": 2}, replay: true, OnAck: func() {
+		acked.Add(1)
+	}}))
+	second := conn.frame(t)
+
+	// 客户端收到第一条后断开，第二条未确认
+	client.Close(1000, "close")
+	<-replay.detached
+
+	client, conn = newReplayTestClient(t, channel, replay, nil, ClientOption{Uid: 1, Resume: true, Session: session, LastEventId: first["event_id"].(string)})
+
+	payload := conn.frame(t)["payload"].(map[string]any)
+	assert.Equal(t, true, payload["resumed"])
+
+	replayed := conn.frame(t)
+	assert.Equal(t, second["event_id"], replayed["event_id"])
+	assert.NotEmpty(t, replayed["ackid"])
+
+	client.handleMessage([]byte(`{"event":"ack","ackid":"` + replayed["ackid"].(string) + `"}`))
+
+	assert.Eventually(t, func() bool { return acked.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// 原推送的确认到达时不重复回调
+	ack.delete(second["ackid"].(string))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), acked.Load())
+
+	client.Close(1000, "close")
+}
+
+func TestClientResumeEventIdAfterReplay(t *testing.T) {
+	channel := NewChannel("test", make(chan *SenderContent, 10))
+	replay := newTestReplayStorage()
+
+	// 原会话在其它节点写入了客户端未收到的事件
+	now := time.Now().Add(time.Minute).UnixMilli()
+	replay.events = []*ReplayEvent{
+		{Id: fmt.Sprintf("%d-0", now), Event: "im.message", Payload: []byte(`{"id":1}`)},
+		{Id: fmt.Sprintf("%d-1", now), Event: "im.message", Payload: []byte(`{"id":2}`)},
+	}
+
+	client, conn := newReplayTestClient(t, channel, replay, nil, ClientOption{Uid: 1, Resume: true, Session: "session", LastEventId: fmt.Sprintf("%d-0", now)})
+
+	payload := conn.frame(t)["payload"].(map[string]any)
+	assert.Equal(t, true, payload["resumed"])
+	assert.Equal(t, fmt.Sprintf("%d-1", now), conn.frame(t)["event_id"])
+
+	// 接管后的事件ID大于缓冲区中最新的事件，不会被当作已补发的事件丢弃
+	assert.NoError(t, client.Write(&ClientResponse{Event: "im.message", Content: map[string]any{"id": 3}, replay: true}))
+	assert.Equal(t, fmt.Sprintf("%d-2", now), conn.frame(t)["event_id"])
+
+	client.Close(1000, "close")
+}
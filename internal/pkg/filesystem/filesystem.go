@@ -26,6 +26,9 @@ type IFilesystem interface {
 	// Write 文件写入
 	Write(bucketName string, objectName string, stream []byte) error
 
+	// WriteStream 文件流写入，size 为流的总长度
+	WriteStream(bucketName string, objectName string, reader io.Reader, size int64) error
+
 	// Copy 文件拷贝
 	Copy(bucketName string, srcObjectName, objectName string) error
 
@@ -38,6 +41,9 @@ type IFilesystem interface {
 	// GetObject 读取文件内容
 	GetObject(bucketName string, objectName string) ([]byte, error)
 
+	// ReadStream 读取文件流，使用完毕后需关闭
+	ReadStream(bucketName string, objectName string) (io.ReadCloser, error)
+
 	// PublicUrl 获取公开文件的访问地址
 	PublicUrl(bucketName, objectName string) string
 
@@ -70,6 +70,27 @@ func (l LocalFilesystem) Write(bucketName string, objectName string, stream []by
 	return err
 }
 
+func (l LocalFilesystem) WriteStream(bucketName string, objectName string, reader io.Reader, _ int64) error {
+	filePath := l.Path(bucketName, objectName)
+
+	dir := path.Dir(filePath)
+	if len(dir) > 0 && !isDirExist(dir) {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	_, err = io.Copy(f, reader)
+	return err
+}
+
 // WriteLocal 本地文件上传
 func (l LocalFilesystem) WriteLocal(bucketName string, localFile string, objectName string) error {
 	srcFile, err := os.Open(localFile)
@@ -137,6 +158,10 @@ func (l LocalFilesystem) GetObject(bucketName string, objectName string) ([]byte
 	return os.ReadFile(l.Path(bucketName, objectName))
 }
 
+func (l LocalFilesystem) ReadStream(bucketName string, objectName string) (io.ReadCloser, error) {
+	return os.Open(l.Path(bucketName, objectName))
+}
+
 func (l LocalFilesystem) PublicUrl(bucketName, objectName string) string {
 	domain := fmt.Sprintf("http://%s", l.config.Endpoint)
 	if l.config.SSL {
@@ -68,6 +68,11 @@ func (m MinioFilesystem) Write(bucketName string, objectName string, stream []by
 	return err
 }
 
+func (m MinioFilesystem) WriteStream(bucketName string, objectName string, reader io.Reader, size int64) error {
+	_, err := m.core.Client.PutObject(context.Background(), bucketName, objectName, reader, size, minio.PutObjectOptions{})
+	return err
+}
+
 func (m MinioFilesystem) Copy(bucketName string, srcObjectName, objectName string) error {
 	return m.CopyObject(bucketName, srcObjectName, bucketName, objectName)
 }
@@ -102,6 +107,10 @@ func (m MinioFilesystem) GetObject(bucketName string, objectName string) ([]byte
 	return io.ReadAll(object)
 }
 
+func (m MinioFilesystem) ReadStream(bucketName string, objectName string) (io.ReadCloser, error) {
+	return m.core.Client.GetObject(context.Background(), bucketName, objectName, minio.GetObjectOptions{})
+}
+
 func (m MinioFilesystem) PublicUrl(bucketName, objectName string) string {
 	uri, err := m.core.Client.PresignedGetObject(context.Background(), bucketName, objectName, 30*time.Minute, nil)
 	if err != nil {
@@ -50,9 +50,14 @@ func ParseToken(token string, secret string) (*AuthClaims, error) {
 		return []byte(secret), nil
 	})
 
+	// token 格式错误时 data 为 nil
+	if err != nil {
+		return nil, err
+	}
+
 	if claims, ok := data.Claims.(*AuthClaims); ok && data.Valid {
 		return claims, nil
 	}
 
-	return nil, err
+	return nil, jwt.ErrTokenInvalidClaims
 }
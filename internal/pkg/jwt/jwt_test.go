@@ -0,0 +1,30 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseToken(t *testing.T) {
+	token := GenerateToken("api", "secret", &Options{
+		ID:        "2054",
+		ExpiresAt: NewNumericDate(time.Now().Add(time.Hour)),
+	})
+
+	claims, err := ParseToken(token, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "api", claims.Guard)
+	assert.Equal(t, "2054", claims.ID)
+
+	_, err = ParseToken(token, "other")
+	assert.Error(t, err)
+}
+
+func TestParseTokenMalformed(t *testing.T) {
+	for _, token := range []string{"", "bad", "a.b.c"} {
+		_, err := ParseToken(token, "secret")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,170 @@
+package sqlmock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var (
+	_ driver.Connector      = (*Mock)(nil)
+	_ driver.QueryerContext = (*Mock)(nil)
+	_ driver.ExecerContext  = (*Mock)(nil)
+)
+
+// Statement 已执行的 SQL 语句及参数
+type Statement struct {
+	Query string
+	Args  []any
+}
+
+type rule struct {
+	contains     string
+	columns      []string
+	values       [][]any
+	rowsAffected int64
+}
+
+// Mock 模拟 MySQL 连接，记录执行的 SQL 语句，并按注册的规则返回查询结果
+// 规则按语句包含的片段匹配，后注册的规则优先，未匹配的查询返回空结果
+type Mock struct {
+	mu         sync.Mutex
+	queries    []*rule
+	execs      []*rule
+	statements []*Statement
+}
+
+// New 创建基于 Mock 连接的 gorm 实例
+func New() (*gorm.DB, *Mock, error) {
+	m := &Mock{}
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sql.OpenDB(m), SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, m, nil
+}
+
+// ExpectQuery 注册查询结果
+func (m *Mock) ExpectQuery(contains string, columns []string, values ...[]any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.queries = append(m.queries, &rule{contains: contains, columns: columns, values: values})
+}
+
+// ExpectExec 注册写入语句的受影响行数
+func (m *Mock) ExpectExec(contains string, rowsAffected int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.execs = append(m.execs, &rule{contains: contains, rowsAffected: rowsAffected})
+}
+
+// Statements 获取已执行的 SQL 语句
+func (m *Mock) Statements() []*Statement {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*Statement(nil), m.statements...)
+}
+
+// Find 获取包含指定片段的已执行语句
+func (m *Mock) Find(contains string) []*Statement {
+	items := make([]*Statement, 0)
+	for _, stmt := range m.Statements() {
+		if strings.Contains(stmt.Query, contains) {
+			items = append(items, stmt)
+		}
+	}
+
+	return items
+}
+
+func (m *Mock) Connect(context.Context) (driver.Conn, error) { return m, nil }
+func (m *Mock) Driver() driver.Driver                        { return nil }
+func (m *Mock) Prepare(string) (driver.Stmt, error)          { return nil, errors.New("not supported") }
+func (m *Mock) Close() error                                 { return nil }
+func (m *Mock) Begin() (driver.Tx, error)                    { return m, nil }
+func (m *Mock) Commit() error                                { return nil }
+func (m *Mock) Rollback() error                              { return nil }
+
+func (m *Mock) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record(query, args)
+
+	if r := match(m.queries, query); r != nil {
+		return &rows{columns: r.columns, values: r.values}, nil
+	}
+
+	return &rows{}, nil
+}
+
+func (m *Mock) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.record(query, args)
+
+	if r := match(m.execs, query); r != nil {
+		return result(r.rowsAffected), nil
+	}
+
+	return result(0), nil
+}
+
+func (m *Mock) record(query string, args []driver.NamedValue) {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	m.statements = append(m.statements, &Statement{Query: query, Args: values})
+}
+
+func match(rules []*rule, query string) *rule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if strings.Contains(query, rules[i].contains) {
+			return rules[i]
+		}
+	}
+
+	return nil
+}
+
+type result int64
+
+func (r result) LastInsertId() (int64, error) { return 0, nil }
+func (r result) RowsAffected() (int64, error) { return int64(r), nil }
+
+type rows struct {
+	columns []string
+	values  [][]any
+	index   int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.index >= len(r.values) {
+		return io.EOF
+	}
+
+	for i, value := range r.values[r.index] {
+		dest[i] = value
+	}
+
+	r.index++
+	return nil
+}
@@ -0,0 +1,73 @@
+package strutil
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Highlight 截取关键词所在的文本片段并使用 <em> 标签标记关键词(忽略大小写)
+// 返回内容已做 html 转义，radius 为关键词前后保留的字符数
+func Highlight(text string, keyword string, radius int) string {
+	runes, kw := []rune(text), []rune(keyword)
+
+	index := runeIndexFold(runes, kw, 0)
+	if index < 0 || len(kw) == 0 {
+		end := min(len(runes), radius*2)
+		return html.EscapeString(string(runes[:end])) + ellipsis(end < len(runes))
+	}
+
+	start := max(0, index-radius)
+	end := min(len(runes), index+len(kw)+radius)
+
+	var sb strings.Builder
+	sb.WriteString(ellipsis(start > 0))
+
+	for pos := start; pos < end; {
+		i := runeIndexFold(runes[:end], kw, pos)
+		if i < 0 {
+			sb.WriteString(html.EscapeString(string(runes[pos:end])))
+			break
+		}
+
+		sb.WriteString(html.EscapeString(string(runes[pos:i])))
+		sb.WriteString("<em>")
+		sb.WriteString(html.EscapeString(string(runes[i : i+len(kw)])))
+		sb.WriteString("</em>")
+		pos = i + len(kw)
+	}
+
+	sb.WriteString(ellipsis(end < len(runes)))
+
+	return sb.String()
+}
+
+func runeIndexFold(runes []rune, kw []rune, from int) int {
+	if len(kw) == 0 {
+		return -1
+	}
+
+	for i := from; i+len(kw) <= len(runes); i++ {
+		match := true
+		for j, r := range kw {
+			if unicode.ToLower(runes[i+j]) != unicode.ToLower(r) {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func ellipsis(ok bool) string {
+	if ok {
+		return "..."
+	}
+
+	return ""
+}
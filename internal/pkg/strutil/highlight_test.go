@@ -0,0 +1,25 @@
+package strutil
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	cases := []struct {
+		text    string
+		keyword string
+		radius  int
+		expect  string
+	}{
+		{"hello world", "world", 10, "hello <em>world</em>"},
+		{"Hello World, hello world", "hello", 30, "<em>Hello</em> World, <em>hello</em> world"},
+		{"今天天气不错，适合出去玩", "天气", 2, "今天<em>天气</em>不错..."},
+		{"适合出去玩，今天天气不错", "天气", 2, "...今天<em>天气</em>不错"},
+		{"a<b>c", "b", 5, "a&lt;<em>b</em>&gt;c"},
+		{"nothing here", "xyz", 3, "nothin..."},
+	}
+
+	for _, c := range cases {
+		if got := Highlight(c.text, c.keyword, c.radius); got != c.expect {
+			t.Errorf("Highlight(%q, %q) = %q, want %q", c.text, c.keyword, got, c.expect)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const maxBodySize = 512 * 1024 // 网页内容读取上限，元数据均位于 head 中无需读取全文
+
+var (
+	ErrForbiddenAddress = errors.New("禁止访问内网地址")
+	ErrUnsupportedUrl   = errors.New("不支持的链接地址")
+)
+
+// 禁止访问的保留地址段(net.IP 自带判断未覆盖的部分)
+var reservedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("240.0.0.0/4"),
+}
+
+// IFetcher 网页内容获取器
+type IFetcher interface {
+	Fetch(ctx context.Context, rawUrl string) (*Response, error)
+}
+
+type Response struct {
+	Url  *url.URL // 最终访问地址(跟随重定向后)
+	Body []byte   // 网页内容
+}
+
+// HttpFetcher 基于 http.Client 的网页内容获取器
+type HttpFetcher struct {
+	client *http.Client
+}
+
+func NewHttpFetcher(client *http.Client) *HttpFetcher {
+	return &HttpFetcher{client: client}
+}
+
+// NewSafeHttpClient 创建禁止访问内网地址的 http.Client
+// 在建立连接时校验解析后的IP，可同时防范重定向及 DNS 重绑定绕过
+func NewSafeHttpClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return ErrForbiddenAddress
+			}
+
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil, // 不使用代理，避免绕过地址校验
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 3 {
+				return errors.New("重定向次数过多")
+			}
+
+			return checkUrl(req.URL)
+		},
+	}
+}
+
+func (h *HttpFetcher) Fetch(ctx context.Context, rawUrl string) (*Response, error) {
+	uri, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, ErrUnsupportedUrl
+	}
+
+	if err := checkUrl(uri); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; LumenIM-LinkPreview/1.0)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); !strings.Contains(contentType, "html") {
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{Url: resp.Request.URL, Body: body}, nil
+}
+
+func checkUrl(uri *url.URL) error {
+	if (uri.Scheme != "http" && uri.Scheme != "https") || uri.Hostname() == "" {
+		return ErrUnsupportedUrl
+	}
+
+	return nil
+}
+
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDR(value string) *net.IPNet {
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
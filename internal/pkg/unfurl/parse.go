@@ -0,0 +1,117 @@
+package unfurl
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// Parse 解析网页中的 OpenGraph / Twitter Card 元数据，缺失时回退到 title 及 description
+func Parse(body []byte, base *url.URL) *Preview {
+	var (
+		meta    = make(map[string]string)
+		title   string
+		inTitle bool
+	)
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(body))
+
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			switch string(name) {
+			case "meta":
+				if hasAttr {
+					parseMeta(tokenizer, meta)
+				}
+			case "title":
+				inTitle = true
+			case "body":
+				break loop
+			}
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(tokenizer.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				break loop
+			}
+		}
+	}
+
+	return &Preview{
+		Title:       truncate(first(meta["og:title"], meta["twitter:title"], title), 200),
+		Description: truncate(first(meta["og:description"], meta["twitter:description"], meta["description"]), 500),
+		Image:       resolveUrl(base, first(meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"])),
+		SiteName:    truncate(first(meta["og:site_name"], base.Hostname()), 100),
+	}
+}
+
+func parseMeta(tokenizer *html.Tokenizer, meta map[string]string) {
+	var key, content string
+
+	for {
+		name, value, more := tokenizer.TagAttr()
+
+		switch string(name) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(string(value)))
+			}
+		case "content":
+			content = strings.TrimSpace(string(value))
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	// 同名标签以第一个为准
+	if _, ok := meta[key]; key != "" && content != "" && !ok {
+		meta[key] = content
+	}
+}
+
+func resolveUrl(base *url.URL, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	uri, err := base.Parse(value)
+	if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") {
+		return ""
+	}
+
+	return uri.String()
+}
+
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+
+	return string([]rune(value)[:length]) + "..."
+}
@@ -0,0 +1,52 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrNoPreview = errors.New("网页缺少可预览的内容")
+
+var urlRegexp = regexp.MustCompile(`https?://[^\s<>"'\x{3000}-\x{303F}\x{FF00}-\x{FFEF}]+`)
+
+// Preview 链接预览信息
+type Preview struct {
+	Url         string `json:"url"`         // 链接地址
+	Title       string `json:"title"`       // 标题
+	Description string `json:"description"` // 描述
+	Image       string `json:"image"`       // 图片地址
+	SiteName    string `json:"site_name"`   // 站点名称
+}
+
+// Unfurler 链接预览生成器
+type Unfurler struct {
+	fetcher IFetcher
+}
+
+func New(fetcher IFetcher) *Unfurler {
+	return &Unfurler{fetcher: fetcher}
+}
+
+// Unfurl 获取链接的预览信息
+func (u *Unfurler) Unfurl(ctx context.Context, rawUrl string) (*Preview, error) {
+	resp, err := u.fetcher.Fetch(ctx, rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := Parse(resp.Body, resp.Url)
+	if preview.Title == "" {
+		return nil, ErrNoPreview
+	}
+
+	preview.Url = rawUrl
+
+	return preview, nil
+}
+
+// FindUrl 查找文本中的第一个链接
+func FindUrl(text string) string {
+	return strings.TrimRight(urlRegexp.FindString(text), ".,;:!?)]}")
+}
@@ -0,0 +1,104 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Fallback Title</title>
+	<meta property="og:title" content="LumenIM" />
+	<meta property="og:description" content="一款在线聊天应用" />
+	<meta property="og:image" content="/static/cover.png" />
+	<meta name="twitter:title" content="Twitter Title" />
+</head>
+<body><meta property="og:title" content="ignored" /></body>
+</html>`
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(testPage))
+	})
+	mux.HandleFunc("/twitter", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>Page</title><meta name="twitter:description" content="desc"><meta name="twitter:image" content="https://cdn.example.com/a.png"></head></html>`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head></head><body>hello</body></html>`))
+	})
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestUnfurl(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	unfurler := New(NewHttpFetcher(server.Client()))
+
+	preview, err := unfurler.Unfurl(context.Background(), server.URL+"/article")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/article", preview.Url)
+	assert.Equal(t, "LumenIM", preview.Title)
+	assert.Equal(t, "一款在线聊天应用", preview.Description)
+	assert.Equal(t, server.URL+"/static/cover.png", preview.Image)
+	assert.Equal(t, "127.0.0.1", preview.SiteName)
+
+	preview, err = unfurler.Unfurl(context.Background(), server.URL+"/twitter")
+	assert.NoError(t, err)
+	assert.Equal(t, "Page", preview.Title)
+	assert.Equal(t, "desc", preview.Description)
+	assert.Equal(t, "https://cdn.example.com/a.png", preview.Image)
+
+	_, err = unfurler.Unfurl(context.Background(), server.URL+"/empty")
+	assert.ErrorIs(t, err, ErrNoPreview)
+
+	_, err = unfurler.Unfurl(context.Background(), server.URL+"/json")
+	assert.Error(t, err)
+
+	_, err = unfurler.Unfurl(context.Background(), "ftp://example.com/file")
+	assert.ErrorIs(t, err, ErrUnsupportedUrl)
+}
+
+func TestSafeHttpClient(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	unfurler := New(NewHttpFetcher(NewSafeHttpClient(3 * time.Second)))
+
+	_, err := unfurler.Unfurl(context.Background(), server.URL+"/article")
+	assert.True(t, errors.Is(err, ErrForbiddenAddress))
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.0.0.1", "172.16.8.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1"} {
+		assert.True(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+
+	for _, ip := range []string{"8.8.8.8", "114.114.114.114", "2001:4860:4860::8888"} {
+		assert.False(t, isPrivateIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestFindUrl(t *testing.T) {
+	assert.Equal(t, "https://example.com/a?b=1", FindUrl("看看这个 https://example.com/a?b=1 ，很有意思"))
+	assert.Equal(t, "http://example.com/path", FindUrl("(http://example.com/path)."))
+	assert.Equal(t, "https://example.com", FindUrl("链接：https://example.com。"))
+	assert.Equal(t, "", FindUrl("没有链接"))
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"go-chat/config"
+	"go-chat/internal/pkg/core/bus"
+)
+
+// NewBus 根据配置的驱动初始化消息总线，默认使用 Redis 发布订阅
+func NewBus(conf *config.Config, redis *redis.Client) bus.IBus {
+	if conf.Bus == nil {
+		return bus.NewRedisBus(redis)
+	}
+
+	switch conf.Bus.Driver {
+	case "", bus.DriverRedis:
+		return bus.NewRedisBus(redis)
+	case bus.DriverStream:
+		return bus.NewStreamBus(redis, conf.Bus.MaxLen)
+	case bus.DriverNsq:
+		if conf.Nsq == nil {
+			panic("bus driver nsq requires nsq config")
+		}
+
+		client, err := bus.NewNsqBus(conf.Nsq.Addr)
+		if err != nil {
+			panic(fmt.Errorf("nsq bus error: %s", err))
+		}
+
+		return client
+	}
+
+	panic(fmt.Sprintf("unsupported bus driver: %s", conf.Bus.Driver))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go-chat/internal/pkg/ipaddress"
+	"go-chat/internal/pkg/unfurl"
 )
 
 func NewHttpClient() *http.Client {
@@ -16,3 +17,8 @@ func NewHttpClient() *http.Client {
 func NewIpAddressClient(c *http.Client) *ipaddress.Client {
 	return ipaddress.NewClient(c)
 }
+
+// NewUnfurler 链接预览生成器(禁止访问内网地址)
+func NewUnfurler() *unfurl.Unfurler {
+	return unfurl.New(unfurl.NewHttpFetcher(unfurl.NewSafeHttpClient(10 * time.Second)))
+}
@@ -8,11 +8,13 @@ var ProviderSet = wire.NewSet(
 	// 基础服务
 	NewMySQLClient,
 	NewRedisClient,
+	NewBus,
 	NewHttpClient,
 	NewEmailClient,
 	NewFilesystem,
 	NewBase64Captcha,
 	NewIpAddressClient,
+	NewUnfurler,
 	NewRsa,
 	wire.Struct(new(Providers), "*"),
 )
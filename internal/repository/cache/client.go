@@ -49,6 +49,41 @@ func (c *ClientStorage) IsCurrentServerOnline(ctx context.Context, sid, channel,
 	return err == nil && val > 0
 }
 
+// GetServerIds 获取用户在线的服务节点ID[所有部署机器]
+// @params channel  渠道分组
+// @params uids     用户ID
+func (c *ClientStorage) GetServerIds(ctx context.Context, channel string, uids []int) ([]string, error) {
+	sids := c.storage.All(ctx, 1)
+	if len(sids) == 0 || len(uids) == 0 {
+		return []string{}, nil
+	}
+
+	cmds := make(map[string][]*redis.IntCmd, len(sids))
+	_, err := c.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, sid := range sids {
+			for _, uid := range uids {
+				cmds[sid] = append(cmds[sid], pipe.SCard(ctx, c.userKey(sid, channel, strconv.Itoa(uid))))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]string, 0, len(sids))
+	for sid, list := range cmds {
+		for _, cmd := range list {
+			if cmd.Val() > 0 {
+				items = append(items, sid)
+				break
+			}
+		}
+	}
+
+	return items, nil
+}
+
 // GetUidFromClientIds 获取当前节点用户ID关联的客户端ID
 // @params sid      服务ID
 // @params channel  渠道分组
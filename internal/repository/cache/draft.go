@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-chat/internal/pkg/jsonutil"
+)
+
+const (
+	// 会话草稿缓存过期时间 - 30天
+	draftExpireAt = 30 * 24 * time.Hour
+
+	// 缓存已从数据库完整加载的标记字段，缓存过期后仅写入单个草稿不视为完整数据
+	draftLoadedField = "_loaded"
+)
+
+type DraftStorage struct {
+	redis *redis.Client
+}
+
+type DraftCacheMessage struct {
+	Content   string `json:"content"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func NewDraftStorage(rds *redis.Client) *DraftStorage {
+	return &DraftStorage{rds}
+}
+
+// Set 保存会话草稿，缓存未完整加载时不写入(由下次读取时从数据库加载)
+func (d *DraftStorage) Set(ctx context.Context, uid int, mode int, toFromId int, draft *DraftCacheMessage) error {
+	script := `
+	if redis.call("HEXISTS", KEYS[1], ARGV[1]) == 1 then
+		redis.call("HSET", KEYS[1], ARGV[2], ARGV[3])
+		redis.call("EXPIRE", KEYS[1], ARGV[4])
+	end
+	return 1`
+
+	return d.redis.Eval(ctx, script, []string{d.name(uid)}, draftLoadedField, d.field(mode, toFromId), jsonutil.Encode(draft), int(draftExpireAt.Seconds())).Err()
+}
+
+// MSet 写入从数据库加载的全部会话草稿，并标记缓存已完整加载
+func (d *DraftStorage) MSet(ctx context.Context, uid int, items map[string]*DraftCacheMessage) error {
+	values := make(map[string]any, len(items)+1)
+	for field, item := range items {
+		values[field] = jsonutil.Encode(item)
+	}
+
+	values[draftLoadedField] = 1
+
+	pipe := d.redis.Pipeline()
+	pipe.HSet(ctx, d.name(uid), values)
+	pipe.Expire(ctx, d.name(uid), draftExpireAt)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Del 删除会话草稿
+func (d *DraftStorage) Del(ctx context.Context, uid int, mode int, toFromId int) error {
+	return d.redis.HDel(ctx, d.name(uid), d.field(mode, toFromId)).Err()
+}
+
+// All 获取用户全部会话草稿，key 格式为 {talk_mode}_{to_from_id}，缓存未完整加载时 loaded 为 false
+func (d *DraftStorage) All(ctx context.Context, uid int) (items map[string]*DraftCacheMessage, loaded bool, err error) {
+	res, err := d.redis.HGetAll(ctx, d.name(uid)).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	items = make(map[string]*DraftCacheMessage, len(res))
+	for field, val := range res {
+		if field == draftLoadedField {
+			loaded = true
+			continue
+		}
+
+		draft := &DraftCacheMessage{}
+		if err := jsonutil.Decode(val, draft); err != nil {
+			continue
+		}
+
+		items[field] = draft
+	}
+
+	return items, loaded, nil
+}
+
+func (d *DraftStorage) name(uid int) string {
+	return fmt.Sprintf("im:talk:draft:%d", uid)
+}
+
+func (d *DraftStorage) field(mode int, toFromId int) string {
+	return fmt.Sprintf("%d_%d", mode, toFromId)
+}
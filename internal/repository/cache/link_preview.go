@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/unfurl"
+)
+
+const (
+	linkPreviewExpireAt      = 24 * time.Hour   // 链接预览缓存时间
+	linkPreviewEmptyExpireAt = 10 * time.Minute // 无法生成预览的链接缓存时间，避免短时间内重复抓取
+)
+
+type LinkPreviewStorage struct {
+	redis *redis.Client
+}
+
+func NewLinkPreviewStorage(rds *redis.Client) *LinkPreviewStorage {
+	return &LinkPreviewStorage{rds}
+}
+
+// Get 获取链接预览，exist 为 true 且 preview 为 nil 时表示该链接无法生成预览
+func (l *LinkPreviewStorage) Get(ctx context.Context, url string) (preview *unfurl.Preview, exist bool) {
+	value, err := l.redis.Get(ctx, l.name(url)).Result()
+	if err != nil {
+		return nil, false
+	}
+
+	if value == "" {
+		return nil, true
+	}
+
+	preview = &unfurl.Preview{}
+	if err := jsonutil.Decode(value, preview); err != nil {
+		return nil, false
+	}
+
+	return preview, true
+}
+
+// Set 缓存链接预览，preview 为 nil 时缓存空结果
+func (l *LinkPreviewStorage) Set(ctx context.Context, url string, preview *unfurl.Preview) error {
+	if preview == nil {
+		return l.redis.Set(ctx, l.name(url), "", linkPreviewEmptyExpireAt).Err()
+	}
+
+	return l.redis.Set(ctx, l.name(url), jsonutil.Encode(preview), linkPreviewExpireAt).Err()
+}
+
+func (l *LinkPreviewStorage) name(url string) string {
+	return fmt.Sprintf("im:link:preview:%x", md5.Sum([]byte(url)))
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-chat/internal/pkg/jsonutil"
+)
+
+type LiveLocationStorage struct {
+	redis *redis.Client
+}
+
+// LiveLocationCache 共享中的位置信息，用于校验客户端上报的位置并保存最新坐标
+type LiveLocationCache struct {
+	UserId    int    `json:"user_id"`
+	TalkMode  int    `json:"talk_mode"`
+	ToFromId  int    `json:"to_from_id"`
+	Longitude string `json:"longitude"`
+	Latitude  string `json:"latitude"`
+	ExpiredAt int64  `json:"expired_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+func NewLiveLocationStorage(rds *redis.Client) *LiveLocationStorage {
+	return &LiveLocationStorage{rds}
+}
+
+// Set 保存位置共享信息，共享结束后自动过期
+func (l *LiveLocationStorage) Set(ctx context.Context, id int, value *LiveLocationCache) error {
+	expire := time.Until(time.Unix(value.ExpiredAt, 0))
+	if expire <= 0 {
+		return nil
+	}
+
+	return l.redis.Set(ctx, l.name(id), jsonutil.Encode(value), expire+time.Minute).Err()
+}
+
+// Get 获取位置共享信息
+func (l *LiveLocationStorage) Get(ctx context.Context, id int) (*LiveLocationCache, error) {
+	value, err := l.redis.Get(ctx, l.name(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &LiveLocationCache{}
+	if err := jsonutil.Decode(value, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Del 删除位置共享信息
+func (l *LiveLocationStorage) Del(ctx context.Context, id int) error {
+	return l.redis.Del(ctx, l.name(id), l.throttle(id)).Err()
+}
+
+// Allow 位置更新推送节流，interval 内仅允许推送一次
+func (l *LiveLocationStorage) Allow(ctx context.Context, id int, interval time.Duration) bool {
+	ok, err := l.redis.SetNX(ctx, l.throttle(id), 1, interval).Result()
+	return err == nil && ok
+}
+
+func (l *LiveLocationStorage) name(id int) string {
+	return fmt.Sprintf("im:live:location:%d", id)
+}
+
+func (l *LiveLocationStorage) throttle(id int) string {
+	return fmt.Sprintf("im:live:location:throttle:%d", id)
+}
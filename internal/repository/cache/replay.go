@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go-chat/internal/pkg/core/socket"
+)
+
+const (
+	replayExpire = 12 * time.Hour // 会话缓冲区过期时间(兜底清理异常退出节点遗留的数据)
+	replayMaxLen = 1000           // 缓冲区保留的最大事件数
+)
+
+var _ socket.IReplayStorage = (*ReplayStorage)(nil)
+
+// ReplayStorage 基于 Redis Streams 的客户端事件缓冲区
+type ReplayStorage struct {
+	redis *redis.Client
+}
+
+func NewReplayStorage(rds *redis.Client) *ReplayStorage {
+	return &ReplayStorage{rds}
+}
+
+// Bind 创建会话并绑定当前持有的客户端
+func (r *ReplayStorage) Bind(ctx context.Context, uid int, session string, cid int64) error {
+	return r.redis.Set(ctx, r.ownerKey(uid, session), cid, replayExpire).Err()
+}
+
+// Detach 客户端断开后缩短缓冲区过期时间，等待客户端重连
+func (r *ReplayStorage) Detach(ctx context.Context, uid int, session string, cid int64, ttl time.Duration) error {
+	script := `
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		redis.call("EXPIRE", KEYS[1], ARGV[2])
+		redis.call("EXPIRE", KEYS[2], ARGV[2])
+	end
+	return 0`
+
+	return r.redis.Eval(ctx, script, []string{r.ownerKey(uid, session), r.streamKey(uid, session)}, cid, int(ttl.Seconds())).Err()
+}
+
+// Resume 接管会话
+func (r *ReplayStorage) Resume(ctx context.Context, uid int, session string, cid int64) (bool, error) {
+	return r.redis.SetXX(ctx, r.ownerKey(uid, session), cid, replayExpire).Result()
+}
+
+// Append 写入事件，会话已被其它客户端接管时不写入
+func (r *ReplayStorage) Append(ctx context.Context, uid int, session string, cid int64, event *socket.ReplayEvent) error {
+	script := `
+	if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+		return 0
+	end
+	redis.call("XADD", KEYS[2], "MAXLEN", "~", ARGV[2], ARGV[3], "event", ARGV[4], "payload", ARGV[5])
+	redis.call("EXPIRE", KEYS[1], ARGV[6])
+	redis.call("EXPIRE", KEYS[2], ARGV[6])
+	return 1`
+
+	return r.redis.Eval(ctx, script, []string{r.ownerKey(uid, session), r.streamKey(uid, session)},
+		cid, replayMaxLen, event.Id, event.Event, []byte(event.Payload), int(replayExpire.Seconds())).Err()
+}
+
+// Range 获取指定事件ID之后的事件
+func (r *ReplayStorage) Range(ctx context.Context, uid int, session string, lastId string, count int64) ([]*socket.ReplayEvent, error) {
+	items, err := r.redis.XRangeN(ctx, r.streamKey(uid, session), "("+lastId, "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*socket.ReplayEvent, 0, len(items))
+	for _, item := range items {
+		event, _ := item.Values["event"].(string)
+		payload, _ := item.Values["payload"].(string)
+
+		events = append(events, &socket.ReplayEvent{Id: item.ID, Event: event, Payload: []byte(payload)})
+	}
+
+	return events, nil
+}
+
+// Last 获取缓冲区中最新的事件ID
+func (r *ReplayStorage) Last(ctx context.Context, uid int, session string) (string, error) {
+	items, err := r.redis.XRevRangeN(ctx, r.streamKey(uid, session), "+", "-", 1).Result()
+	if err != nil || len(items) == 0 {
+		return "", err
+	}
+
+	return items[0].ID, nil
+}
+
+func (r *ReplayStorage) ownerKey(uid int, session string) string {
+	return fmt.Sprintf("im:socket:replay:%d:%s:owner", uid, session)
+}
+
+func (r *ReplayStorage) streamKey(uid int, session string) string {
+	return fmt.Sprintf("im:socket:replay:%d:%s", uid, session)
+}
@@ -28,16 +28,35 @@ func (s *Sequence) Name(id int, isUserId bool) string {
 	return fmt.Sprintf("im:sequence:chat:group:%d", id)
 }
 
+// EventName 同步事件发号器，与消息发号器分开，避免消息时序ID出现空洞
+func (s *Sequence) EventName(id int, isUserId bool) string {
+	if isUserId {
+		return fmt.Sprintf("im:sequence:event:uid:%d", id)
+	}
+
+	return fmt.Sprintf("im:sequence:event:group:%d", id)
+}
+
 // Set 初始化发号器
 func (s *Sequence) Set(ctx context.Context, id int, isUserId bool, value int64) error {
 	return s.redis.SetEx(ctx, s.Name(id, isUserId), value, 12*time.Hour).Err()
 }
 
+// SetEvent 初始化同步事件发号器
+func (s *Sequence) SetEvent(ctx context.Context, id int, isUserId bool, value int64) error {
+	return s.redis.SetEx(ctx, s.EventName(id, isUserId), value, 12*time.Hour).Err()
+}
+
 // Get 获取消息时序ID
 func (s *Sequence) Get(ctx context.Context, id int, isUserId bool) int64 {
 	return s.redis.Incr(ctx, s.Name(id, isUserId)).Val()
 }
 
+// GetEvent 获取同步事件时序ID
+func (s *Sequence) GetEvent(ctx context.Context, id int, isUserId bool) int64 {
+	return s.redis.Incr(ctx, s.EventName(id, isUserId)).Val()
+}
+
 // BatchGet 批量获取消息时序ID
 func (s *Sequence) BatchGet(ctx context.Context, id int, isUserId bool, num int64) []int64 {
 
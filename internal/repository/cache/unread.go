@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -74,3 +75,54 @@ func (u *UnreadStorage) Reset(ctx context.Context, uid, mode, sender int) {
 func (u *UnreadStorage) name(uid, mode, sender int) string {
 	return fmt.Sprintf("im:unread:%d:%d_%d", uid, mode, sender)
 }
+
+// PipeIncrThread 话题未读数自增
+// @params uid       用户ID
+// @params threadId  话题根消息ID
+func (u *UnreadStorage) PipeIncrThread(ctx context.Context, pipe redis.Pipeliner, uid int, threadId string) {
+	name := u.threadName(uid, threadId)
+	pipe.Incr(ctx, name)
+	pipe.Expire(ctx, name, unreadExpireAt)
+}
+
+// MGetThread 批量获取话题未读数
+// @params uid        用户ID
+// @params threadIds  话题根消息ID列表
+func (u *UnreadStorage) MGetThread(ctx context.Context, uid int, threadIds []string) map[string]int {
+	items := make(map[string]int, len(threadIds))
+	if len(threadIds) == 0 {
+		return items
+	}
+
+	keys := make([]string, 0, len(threadIds))
+	for _, threadId := range threadIds {
+		keys = append(keys, u.threadName(uid, threadId))
+	}
+
+	values, err := u.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return items
+	}
+
+	for i, value := range values {
+		if val, ok := value.(string); ok {
+			num, _ := strconv.Atoi(val)
+			items[threadIds[i]] = num
+		}
+	}
+
+	return items
+}
+
+// ResetThread 话题未读数重置
+// @params uid       用户ID
+// @params threadId  话题根消息ID
+func (u *UnreadStorage) ResetThread(ctx context.Context, uid int, threadId string) {
+	u.redis.Del(ctx, u.threadName(uid, threadId))
+}
+
+// 话题未读数缓存
+// im:unread:uid:thread_msgid
+func (u *UnreadStorage) threadName(uid int, threadId string) string {
+	return fmt.Sprintf("im:unread:%d:thread_%s", uid, threadId)
+}
@@ -19,4 +19,8 @@ var ProviderSet = wire.NewSet(
 	NewVote,
 	NewUnreadStorage,
 	NewGroupApplyStorage,
+	NewDraftStorage,
+	NewReplayStorage,
+	NewLinkPreviewStorage,
+	NewLiveLocationStorage,
 )
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// TalkBroadcast 群发列表
+type TalkBroadcast struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 创建者ID
+	Name      string    `gorm:"column:name;" json:"name"`                       // 列表名称
+	UserIds   string    `gorm:"column:user_ids;" json:"user_ids"`               // 接收者ID(多个以逗号分隔)
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkBroadcast) TableName() string {
+	return "talk_broadcast"
+}
+
+// TalkBroadcastLog 群发记录
+type TalkBroadcastLog struct {
+	Id          int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId      int       `gorm:"column:user_id;" json:"user_id"`                 // 发送者ID
+	BroadcastId int       `gorm:"column:broadcast_id;" json:"broadcast_id"`       // 群发列表ID
+	MsgType     int       `gorm:"column:msg_type;" json:"msg_type"`               // 消息类型
+	Extra       string    `gorm:"column:extra;" json:"extra"`                     // 消息扩展字段
+	Total       int       `gorm:"column:total;" json:"total"`                     // 接收者总数
+	ReachedIds  string    `gorm:"column:reached_ids;" json:"reached_ids"`         // 发送成功的接收者ID(多个以逗号分隔)
+	FailedIds   string    `gorm:"column:failed_ids;" json:"failed_ids"`           // 发送失败的接收者ID(多个以逗号分隔)
+	CreatedAt   time.Time `gorm:"column:created_at;" json:"created_at"`           // 发送时间
+}
+
+func (TalkBroadcastLog) TableName() string {
+	return "talk_broadcast_log"
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TalkDraft struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 用户ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	Content   string    `gorm:"column:content;" json:"content"`                 // 草稿内容
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkDraft) TableName() string {
+	return "talk_draft"
+}
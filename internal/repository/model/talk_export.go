@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+const (
+	TalkExportStatusWait       = 1 // 待处理
+	TalkExportStatusProcessing = 2 // 处理中
+	TalkExportStatusSuccess    = 3 // 已完成
+	TalkExportStatusFail       = 4 // 导出失败
+)
+
+type TalkExport struct {
+	Id         int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId     int       `gorm:"column:user_id;" json:"user_id"`                 // 导出用户ID
+	TalkMode   int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId   int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	Format     string    `gorm:"column:format;" json:"format"`                   // 导出格式[json;html;txt;]
+	StartTime  time.Time `gorm:"column:start_time;" json:"start_time"`           // 消息发送时间起始(包含)
+	EndTime    time.Time `gorm:"column:end_time;" json:"end_time"`               // 消息发送时间截止(不包含)
+	Status     int       `gorm:"column:status;" json:"status"`                   // 导出状态[1:待处理;2:处理中;3:已完成;4:导出失败;]
+	MessageNum int       `gorm:"column:message_num;" json:"message_num"`         // 导出消息数
+	FileNum    int       `gorm:"column:file_num;" json:"file_num"`               // 打包附件数
+	Path       string    `gorm:"column:path;" json:"path"`                       // 压缩包路径(私有桶)
+	Size       int       `gorm:"column:size;" json:"size"`                       // 压缩包大小
+	Reason     string    `gorm:"column:reason;" json:"reason"`                   // 导出失败原因
+	CreatedAt  time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt  time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkExport) TableName() string {
+	return "talk_export"
+}
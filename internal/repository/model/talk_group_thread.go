@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TalkGroupThread struct {
+	Id          int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 话题ID
+	GroupId     int       `gorm:"column:group_id;" json:"group_id"`               // 群组ID
+	MsgId       string    `gorm:"column:msg_id;" json:"msg_id"`                   // 话题根消息ID
+	ReplyCount  int       `gorm:"column:reply_count;" json:"reply_count"`         // 回复数
+	LastReplyAt time.Time `gorm:"column:last_reply_at;" json:"last_reply_at"`     // 最后回复时间
+	CreatedAt   time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt   time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkGroupThread) TableName() string {
+	return "talk_group_thread"
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+const (
+	TalkLiveLocationStatusSharing = 1 // 共享中
+	TalkLiveLocationStatusEnded   = 2 // 已结束
+)
+
+// TalkLiveLocation 实时位置共享
+type TalkLiveLocation struct {
+	Id          int        `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId      int        `gorm:"column:user_id;" json:"user_id"`                 // 共享者ID
+	TalkMode    int        `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId    int        `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	Duration    int        `gorm:"column:duration;" json:"duration"`               // 共享时长(秒)
+	Status      int        `gorm:"column:status;" json:"status"`                   // 共享状态[1:共享中;2:已结束;]
+	Longitude   string     `gorm:"column:longitude;" json:"longitude"`             // 最后位置经度
+	Latitude    string     `gorm:"column:latitude;" json:"latitude"`               // 最后位置纬度
+	Description string     `gorm:"column:description;" json:"description"`         // 位置描述
+	ExpiredAt   time.Time  `gorm:"column:expired_at;" json:"expired_at"`           // 共享结束时间
+	EndedAt     *time.Time `gorm:"column:ended_at;" json:"ended_at"`               // 实际结束时间
+	CreatedAt   time.Time  `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt   time.Time  `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkLiveLocation) TableName() string {
+	return "talk_live_location"
+}
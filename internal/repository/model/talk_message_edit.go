@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TalkMessageEdit struct {
+	Id        int64     `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 编辑记录ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	MsgId     string    `gorm:"column:msg_id;" json:"msg_id"`                   // 消息ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 编辑者ID
+	MsgType   int       `gorm:"column:msg_type;" json:"msg_type"`               // 消息类型
+	Extra     string    `gorm:"column:extra;" json:"extra"`                     // 编辑前的消息扩展字段
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 编辑时间
+}
+
+func (TalkMessageEdit) TableName() string {
+	return "talk_message_edit"
+}
@@ -14,8 +14,18 @@ type TalkRecordExtraGroupMember struct {
 
 // TalkRecordExtraText 文本消息
 type TalkRecordExtraText struct {
-	Content  string `json:"content"`            // 文本消息
-	Mentions []int  `json:"mentions,omitempty"` // @用户ID列表
+	Content  string                      `json:"content"`            // 文本消息
+	Mentions []int                       `json:"mentions,omitempty"` // @用户ID列表
+	Preview  *TalkRecordExtraLinkPreview `json:"preview,omitempty"`  // 链接预览
+}
+
+// TalkRecordExtraLinkPreview 文本消息中的链接预览
+type TalkRecordExtraLinkPreview struct {
+	Url         string `json:"url"`         // 链接地址
+	Title       string `json:"title"`       // 标题
+	Description string `json:"description"` // 描述
+	Image       string `json:"image"`       // 图片地址
+	SiteName    string `json:"site_name"`   // 站点名称
 }
 
 // TalkRecordExtraCode 代码消息
@@ -166,6 +176,21 @@ type TalkRecordExtraGroupNotice struct {
 	Content   string `json:"content"`    // 内容
 }
 
+// TalkRecordExtraMessagePinned 置顶消息
+type TalkRecordExtraMessagePinned struct {
+	OwnerId   int    `json:"owner_id"`   // 操作人ID
+	OwnerName string `json:"owner_name"` // 操作人昵称
+	MsgId     string `json:"msg_id"`     // 被置顶消息ID(私聊为操作人信箱中的消息ID)
+	Content   string `json:"content"`    // 被置顶消息摘要
+}
+
+// TalkRecordExtraMessageTtl 消息自毁时长变更
+type TalkRecordExtraMessageTtl struct {
+	OwnerId   int    `json:"owner_id"`   // 操作人ID
+	OwnerName string `json:"owner_name"` // 操作人昵称
+	Ttl       int    `json:"ttl"`        // 消息自毁时长(秒)[0:关闭;]
+}
+
 type TalkRecordExtraMixedItem struct {
 	Type    int    `json:"type"`           // 消息类型, 跟msgtype字段一致
 	Content string `json:"content"`        // 消息内容。可包含图片、文字、表情等多种消息。
@@ -175,7 +200,8 @@ type TalkRecordExtraMixedItem struct {
 // TalkRecordExtraMixed 图文混合消息
 type TalkRecordExtraMixed struct {
 	// 消息内容。可包含图片、文字、等消息。
-	Items []*TalkRecordExtraMixedItem `json:"items"` // 消息内容。可包含图片、文字、表情等多种消息。
+	Items    []*TalkRecordExtraMixedItem `json:"items"`              // 消息内容。可包含图片、文字、表情等多种消息。
+	Mentions []int                       `json:"mentions,omitempty"` // @用户ID列表
 }
 
 type TalkRecordExtraVote struct {
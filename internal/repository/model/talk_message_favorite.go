@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type TalkMessageFavorite struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 收藏ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 用户ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 来源对话类型[1:私信;2:群聊;]
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 来源会话ID（用户ID 或 群ID）
+	MsgId     string    `gorm:"column:msg_id;" json:"msg_id"`                   // 来源消息ID
+	FromId    int       `gorm:"column:from_id;" json:"from_id"`                 // 消息发送者ID
+	MsgType   int       `gorm:"column:msg_type;" json:"msg_type"`               // 消息类型
+	Extra     string    `gorm:"column:extra;" json:"extra"`                     // 消息扩展字段快照
+	Tags      string    `gorm:"column:tags;" json:"tags"`                       // 标签(多个以逗号分隔)
+	SendTime  time.Time `gorm:"column:send_time;" json:"send_time"`             // 消息发送时间
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 收藏时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkMessageFavorite) TableName() string {
+	return "talk_message_favorite"
+}
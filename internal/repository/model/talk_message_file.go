@@ -0,0 +1,81 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+)
+
+const (
+	TalkMessageFileSourcePrivate  = 1 // 私聊消息
+	TalkMessageFileSourceGroup    = 2 // 群聊消息
+	TalkMessageFileSourceFavorite = 3 // 消息收藏
+)
+
+// TalkMessageFile 消息附件引用记录，消息或收藏写入时同步创建，用于判断附件是否仍被引用
+type TalkMessageFile struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	Source    int       `gorm:"column:source;" json:"source"`                   // 引用来源[1:私聊消息;2:群聊消息;3:消息收藏;]
+	SourceId  string    `gorm:"column:source_id;" json:"source_id"`             // 来源ID(消息ID 或 收藏ID)
+	Object    string    `gorm:"column:object;" json:"object"`                   // 附件标识(见 TalkMessageFileObjects)
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+}
+
+func (TalkMessageFile) TableName() string {
+	return "talk_message_file"
+}
+
+// TalkMessageFileObjects 解析消息引用的附件标识，文件消息为私有桶中的文件路径，多媒体消息为访问地址的路径部分
+func TalkMessageFileObjects(msgType int, extra string) []string {
+	var value struct {
+		Url   string                      `json:"url"`
+		Path  string                      `json:"path"`
+		Items []*TalkRecordExtraMixedItem `json:"items"`
+	}
+
+	if err := jsonutil.Decode(extra, &value); err != nil {
+		return nil
+	}
+
+	switch msgType {
+	case entity.ChatMsgTypeFile:
+		if value.Path != "" {
+			return []string{value.Path}
+		}
+	case entity.ChatMsgTypeImage, entity.ChatMsgTypeAudio, entity.ChatMsgTypeVideo:
+		if object := talkMessageFileUrl(value.Url); object != "" {
+			return []string{object}
+		}
+	case entity.ChatMsgTypeMixed:
+		items := make([]string, 0)
+		for _, item := range value.Items {
+			if item.Type != entity.ChatMsgTypeImage {
+				continue
+			}
+
+			if object := talkMessageFileUrl(item.Content); object != "" {
+				items = append(items, object)
+			}
+		}
+
+		return items
+	}
+
+	return nil
+}
+
+func talkMessageFileUrl(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	uri, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(uri.Path, "/")
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const MentionAllUserId = 0 // @所有人(提及用户ID为0)
+
+type TalkMessageMention struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 被提及的用户ID
+	GroupId   int       `gorm:"column:group_id;" json:"group_id"`               // 群ID
+	MsgId     string    `gorm:"column:msg_id;" json:"msg_id"`                   // 消息ID
+	FromId    int       `gorm:"column:from_id;" json:"from_id"`                 // 消息发送者ID
+	IsAll     int       `gorm:"column:is_all;" json:"is_all"`                   // 是否@所有人[1:是;2:否;]
+	IsRead    int       `gorm:"column:is_read;" json:"is_read"`                 // 是否已读[1:是;2:否;]
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+}
+
+func (TalkMessageMention) TableName() string {
+	return "talk_message_mention"
+}
+
+type TalkMentionCount struct {
+	GroupId int `json:"group_id"`
+	Num     int `json:"num"`
+}
+
+type TalkMentionItem struct {
+	Id          int       `json:"id"`
+	GroupId     int       `json:"group_id"`
+	GroupName   string    `json:"group_name"`
+	GroupAvatar string    `json:"group_avatar"`
+	MsgId       string    `json:"msg_id"`
+	FromId      int       `json:"from_id"`
+	Nickname    string    `json:"nickname"`
+	Avatar      string    `json:"avatar"`
+	MsgType     int       `json:"msg_type"`
+	Extra       string    `json:"extra"`
+	IsRevoked   int       `json:"is_revoked"`
+	IsAll       int       `json:"is_all"`
+	IsRead      int       `json:"is_read"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type TalkMessagePin struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 消息所属用户ID(群聊为0)
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	MsgId     string    `gorm:"column:msg_id;" json:"msg_id"`                   // 消息ID
+	PinnedBy  int       `gorm:"column:pinned_by;" json:"pinned_by"`             // 置顶操作人ID
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 置顶时间
+}
+
+func (TalkMessagePin) TableName() string {
+	return "talk_message_pin"
+}
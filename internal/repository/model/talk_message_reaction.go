@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type TalkMessageReaction struct {
+	Id        int64     `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 表态记录ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	MsgId     string    `gorm:"column:msg_id;" json:"msg_id"`                   // 消息ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 表态用户ID
+	Emoji     string    `gorm:"column:emoji;" json:"emoji"`                     // 表情
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+}
+
+func (TalkMessageReaction) TableName() string {
+	return "talk_message_reaction"
+}
+
+// TalkMessageReactionCount 消息表态统计
+type TalkMessageReactionCount struct {
+	Emoji   string `json:"emoji"`    // 表情
+	Count   int    `json:"count"`    // 表态人数
+	UserIds []int  `json:"user_ids"` // 表态用户ID列表
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	TalkMessageScheduleStatusWait    = 1 // 待发送
+	TalkMessageScheduleStatusSending = 2 // 发送中
+	TalkMessageScheduleStatusSent    = 3 // 已发送
+	TalkMessageScheduleStatusCancel  = 4 // 已取消
+	TalkMessageScheduleStatusFail    = 5 // 发送失败
+)
+
+type TalkMessageSchedule struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 发送者ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	MsgType   int       `gorm:"column:msg_type;" json:"msg_type"`               // 消息类型
+	QuoteId   string    `gorm:"column:quote_id;" json:"quote_id"`               // 引用消息ID
+	Extra     string    `gorm:"column:extra;" json:"extra"`                     // 消息扩展字段
+	Status    int       `gorm:"column:status;" json:"status"`                   // 发送状态[1:待发送;2:发送中;3:已发送;4:已取消;5:发送失败;]
+	SendAt    time.Time `gorm:"column:send_at;" json:"send_at"`                 // 计划发送时间
+	Reason    string    `gorm:"column:reason;" json:"reason"`                   // 发送失败原因
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkMessageSchedule) TableName() string {
+	return "talk_message_schedule"
+}
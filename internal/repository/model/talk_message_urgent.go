@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// TalkMessageUrgent 紧急消息提醒(每个接收者一条记录)
+type TalkMessageUrgent struct {
+	Id           int        `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	TalkMode     int        `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	MsgId        string     `gorm:"column:msg_id;" json:"msg_id"`                   // 接收者看到的消息ID
+	OrgMsgId     string     `gorm:"column:org_msg_id;" json:"org_msg_id"`           // 原始消息ID(私聊为 org_msg_id，群聊与 msg_id 相同)
+	UserId       int        `gorm:"column:user_id;" json:"user_id"`                 // 接收者ID
+	ToFromId     int        `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者视角的会话ID(私聊为发送者ID，群聊为群ID)
+	FromId       int        `gorm:"column:from_id;" json:"from_id"`                 // 发送者ID
+	IsAcked      int        `gorm:"column:is_acked;" json:"is_acked"`               // 是否已确认[1:是;2:否;]
+	NotifyNum    int        `gorm:"column:notify_num;" json:"notify_num"`           // 已重复提醒次数
+	NextNotifyAt time.Time  `gorm:"column:next_notify_at;" json:"next_notify_at"`   // 下次提醒时间
+	AckedAt      *time.Time `gorm:"column:acked_at;" json:"acked_at"`               // 确认时间
+	CreatedAt    time.Time  `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+}
+
+func (TalkMessageUrgent) TableName() string {
+	return "talk_message_urgent"
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	TalkQuickReplyScopePersonal = 1 // 个人快捷回复
+	TalkQuickReplyScopeDept     = 2 // 部门快捷回复(部门及下级部门成员可见)
+)
+
+type TalkQuickReply struct {
+	Id        int       `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	Scope     int       `gorm:"column:scope;" json:"scope"`                     // 可见范围[1:个人;2:部门;]
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 创建者ID
+	DeptId    int       `gorm:"column:dept_id;" json:"dept_id"`                 // 所属部门ID(个人快捷回复为0)
+	Title     string    `gorm:"column:title;" json:"title"`                     // 标题
+	MsgType   int       `gorm:"column:msg_type;" json:"msg_type"`               // 消息类型[1:文本消息;12:图文消息;]
+	Content   string    `gorm:"column:content;" json:"content"`                 // 模板内容(图文消息为 JSON 数组)
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 更新时间
+}
+
+func (TalkQuickReply) TableName() string {
+	return "talk_quick_reply"
+}
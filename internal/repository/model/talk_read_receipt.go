@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type TalkReadReceipt struct {
+	Id        int64     `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 用户ID
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	Sequence  int64     `gorm:"column:sequence;" json:"sequence"`               // 已读消息时序ID
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+	UpdatedAt time.Time `gorm:"column:updated_at;" json:"updated_at"`           // 已读时间
+}
+
+func (TalkReadReceipt) TableName() string {
+	return "talk_read_receipt"
+}
+
+type TalkReadUser struct {
+	UserId   int       `json:"user_id"`
+	Nickname string    `json:"nickname"`
+	Avatar   string    `json:"avatar"`
+	ReadAt   time.Time `json:"read_at"`
+}
@@ -16,16 +16,34 @@ type TalkRecord struct {
 }
 
 type TalkMessageRecord struct {
-	TalkMode  int       `json:"talk_mode"`  // 对话类型 1:私聊 2:群聊
-	FromId    int       `json:"from_id"`    // 消息发送者
-	ToFromId  int       `json:"to_from_id"` // 消息接受者
-	MsgId     string    `json:"msg_id"`     // 消息ID
-	Sequence  int       `json:"sequence"`   // 时序ID（排序）
-	MsgType   int       `json:"msg_type"`   // 消息类型
-	Nickname  string    `json:"nickname"`   // 发送者昵称
-	Avatar    string    `json:"avatar"`     // 发送者头像
-	IsRevoked int       `json:"is_revoked"` // 消息是否已撤销
-	SendTime  time.Time `json:"send_time"`  // 发送时间
-	Extra     string    `json:"extra"`      // 额外参数
-	Quote     string    `json:"quote"`      // 消息引用
+	TalkMode       int       `json:"talk_mode"`       // 对话类型 1:私聊 2:群聊
+	FromId         int       `json:"from_id"`         // 消息发送者
+	ToFromId       int       `json:"to_from_id"`      // 消息接受者
+	MsgId          string    `json:"msg_id"`          // 消息ID
+	Sequence       int       `json:"sequence"`        // 时序ID（排序）
+	MsgType        int       `json:"msg_type"`        // 消息类型
+	Nickname       string    `json:"nickname"`        // 发送者昵称
+	Avatar         string    `json:"avatar"`          // 发送者头像
+	IsRevoked      int       `json:"is_revoked"`      // 消息是否已撤销
+	IsEdited       int       `json:"is_edited"`       // 消息是否已编辑
+	SendTime       time.Time `json:"send_time"`       // 发送时间
+	Extra          string    `json:"extra"`           // 额外参数
+	Quote          string    `json:"quote"`           // 消息引用
+	ThreadId       string    `json:"thread_id"`       // 话题根消息ID
+	DeliveryStatus int       `json:"delivery_status"` // 投递状态[1:已发送;2:已送达;3:已读;](仅私聊)
+
+	Reactions []*TalkMessageReactionCount `json:"reactions"`        // 表态统计
+	Thread    *TalkMessageRecordThread    `json:"thread"`           // 话题信息
+	Urgent    *TalkMessageRecordUrgent    `json:"urgent,omitempty"` // 紧急消息提醒(仅接收者可见)
+}
+
+type TalkMessageRecordUrgent struct {
+	IsAcked   int `json:"is_acked"`   // 是否已确认[1:是;2:否;]
+	NotifyNum int `json:"notify_num"` // 已重复提醒次数
+}
+
+type TalkMessageRecordThread struct {
+	ReplyCount  int    `json:"reply_count"`   // 回复数
+	LastReplyAt string `json:"last_reply_at"` // 最后回复时间
+	UnreadNum   int    `json:"unread_num"`    // 未读回复数
 }
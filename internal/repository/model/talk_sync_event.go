@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// 多端同步事件类型
+const (
+	SyncEventMessageRevoke  = "message.revoke"  // 消息撤回
+	SyncEventMessageDelete  = "message.delete"  // 消息删除
+	SyncEventMessageEdit    = "message.edit"    // 消息编辑
+	SyncEventSessionTop     = "session.top"     // 会话置顶
+	SyncEventSessionDelete  = "session.delete"  // 会话删除
+	SyncEventSessionDisturb = "session.disturb" // 会话免打扰
+)
+
+type TalkSyncEvent struct {
+	Id        int64     `gorm:"column:id;primary_key;AUTO_INCREMENT" json:"id"` // 自增ID
+	UserId    int       `gorm:"column:user_id;" json:"user_id"`                 // 用户ID(群事件为0)
+	GroupId   int       `gorm:"column:group_id;" json:"group_id"`               // 群ID(用户事件为0)
+	Sequence  int64     `gorm:"column:sequence;" json:"sequence"`               // 时序ID(同步事件独立发号)
+	Event     string    `gorm:"column:event;" json:"event"`                     // 事件类型
+	TalkMode  int       `gorm:"column:talk_mode;" json:"talk_mode"`             // 对话类型[1:私信;2:群聊;]
+	ToFromId  int       `gorm:"column:to_from_id;" json:"to_from_id"`           // 接收者ID（用户ID 或 群ID）
+	Payload   string    `gorm:"column:payload;" json:"payload"`                 // 事件内容
+	CreatedAt time.Time `gorm:"column:created_at;" json:"created_at"`           // 创建时间
+}
+
+func (TalkSyncEvent) TableName() string {
+	return "talk_sync_event"
+}
+
+type TalkSyncEventPayload struct {
+	MsgIds []string `json:"msg_ids,omitempty"` // 消息ID列表
+	Value  int      `json:"value,omitempty"`   // 会话设置值
+}
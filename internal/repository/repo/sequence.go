@@ -23,11 +23,37 @@ func NewSequence(db *gorm.DB, cache *cache.Sequence) *Sequence {
 }
 
 func (s *Sequence) try(ctx context.Context, id int, isUserId bool) error {
-	result := s.cache.Redis().TTL(ctx, s.cache.Name(id, isUserId)).Val()
+	tx := s.db.WithContext(ctx).Select("ifnull(max(sequence),0)")
+	if isUserId {
+		tx.Table("talk_user_message").Where("user_id = ?", id)
+	} else {
+		tx.Table("talk_group_message").Where("group_id = ?", id)
+	}
+
+	return s.load(ctx, s.cache.Name(id, isUserId), tx, func(seq int64) error {
+		return s.cache.Set(ctx, id, isUserId, seq)
+	})
+}
+
+func (s *Sequence) tryEvent(ctx context.Context, id int, isUserId bool) error {
+	tx := s.db.WithContext(ctx).Select("ifnull(max(sequence),0)").Table("talk_sync_event")
+	if isUserId {
+		tx.Where("user_id = ? and group_id = 0", id)
+	} else {
+		tx.Where("user_id = 0 and group_id = ?", id)
+	}
+
+	return s.load(ctx, s.cache.EventName(id, isUserId), tx, func(seq int64) error {
+		return s.cache.SetEvent(ctx, id, isUserId, seq)
+	})
+}
+
+func (s *Sequence) load(ctx context.Context, name string, tx *gorm.DB, set func(seq int64) error) error {
+	result := s.cache.Redis().TTL(ctx, name).Val()
 
 	// 当数据不存在时需要从数据库中加载
 	if result == time.Duration(-2) {
-		lockName := fmt.Sprintf("%s_lock", s.cache.Name(id, isUserId))
+		lockName := fmt.Sprintf("%s_lock", name)
 
 		isTrue := s.cache.Redis().SetNX(ctx, lockName, 1, 10*time.Second).Val()
 		if !isTrue {
@@ -36,25 +62,18 @@ func (s *Sequence) try(ctx context.Context, id int, isUserId bool) error {
 
 		defer s.cache.Redis().Del(ctx, lockName)
 
-		tx := s.db.WithContext(ctx).Select("ifnull(max(sequence),0)")
-		if isUserId {
-			tx.Table("talk_user_message").Where("user_id = ?", id)
-		} else {
-			tx.Table("talk_group_message").Where("group_id = ?", id)
-		}
-
 		var seq int64
 		if err := tx.Scan(&seq).Error; err != nil {
 			logger.Errorf("[Sequence Total] 加载异常 err: %s", err.Error())
 			return err
 		}
 
-		if err := s.cache.Set(ctx, id, isUserId, seq+100); err != nil {
+		if err := set(seq + 100); err != nil {
 			logger.Errorf("[Sequence set] 加载异常 err: %s", err.Error())
 			return err
 		}
 	} else if result < time.Hour {
-		s.cache.Redis().Expire(ctx, s.cache.Name(id, isUserId), 12*time.Hour)
+		s.cache.Redis().Expire(ctx, name, 12*time.Hour)
 	}
 
 	return nil
@@ -83,3 +102,15 @@ func (s *Sequence) BatchGet(ctx context.Context, id int, isUserId bool, num int6
 
 	return s.cache.BatchGet(ctx, id, isUserId, num)
 }
+
+// GetEvent 获取同步事件的时序ID(与消息时序ID相互独立)
+func (s *Sequence) GetEvent(ctx context.Context, id int, isUserId bool) int64 {
+
+	if err := utils.Retry(5, 100*time.Millisecond, func() error {
+		return s.tryEvent(ctx, id, isUserId)
+	}); err != nil {
+		log.Println("Sequence GetEvent Err :", err.Error())
+	}
+
+	return s.cache.GetEvent(ctx, id, isUserId)
+}
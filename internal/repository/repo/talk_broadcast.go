@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkBroadcast struct {
+	core.Repo[model.TalkBroadcast]
+}
+
+func NewTalkBroadcast(db *gorm.DB) *TalkBroadcast {
+	return &TalkBroadcast{Repo: core.NewRepo[model.TalkBroadcast](db)}
+}
+
+type TalkBroadcastLog struct {
+	core.Repo[model.TalkBroadcastLog]
+}
+
+func NewTalkBroadcastLog(db *gorm.DB) *TalkBroadcastLog {
+	return &TalkBroadcastLog{Repo: core.NewRepo[model.TalkBroadcastLog](db)}
+}
@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkDraft struct {
+	core.Repo[model.TalkDraft]
+}
+
+func NewTalkDraft(db *gorm.DB) *TalkDraft {
+	return &TalkDraft{Repo: core.NewRepo[model.TalkDraft](db)}
+}
+
+// Upsert 保存会话草稿
+func (t *TalkDraft) Upsert(ctx context.Context, uid int, talkMode int, toFromId int, content string, updatedAt time.Time) error {
+	return t.Db.WithContext(ctx).Exec(
+		"INSERT INTO talk_draft (`user_id`, `talk_mode`, `to_from_id`, `content`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE content = VALUES(content), updated_at = VALUES(updated_at)",
+		uid, talkMode, toFromId, content, updatedAt, updatedAt,
+	).Error
+}
+
+// Delete 删除会话草稿
+func (t *TalkDraft) Delete(ctx context.Context, uid int, talkMode int, toFromId int) error {
+	return t.Db.WithContext(ctx).Delete(&model.TalkDraft{}, "user_id = ? and talk_mode = ? and to_from_id = ?", uid, talkMode, toFromId).Error
+}
+
+// FindAllByUserId 获取用户全部会话草稿
+func (t *TalkDraft) FindAllByUserId(ctx context.Context, uid int) ([]*model.TalkDraft, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ?", uid)
+	})
+}
@@ -0,0 +1,27 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkExport struct {
+	core.Repo[model.TalkExport]
+}
+
+func NewTalkExport(db *gorm.DB) *TalkExport {
+	return &TalkExport{Repo: core.NewRepo[model.TalkExport](db)}
+}
+
+// UpdateStatus 按原状态更新导出状态(用于抢占待处理任务)
+func (t *TalkExport) UpdateStatus(ctx context.Context, id int, from int, data map[string]any) (bool, error) {
+	res := t.Model(ctx).Where("id = ? and status = ?", id, from).Updates(data)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"go-chat/internal/pkg/core"
 	"go-chat/internal/repository/model"
@@ -19,3 +20,49 @@ func NewTalkRecordGroup(db *gorm.DB) *TalkGroupMessage {
 func (t *TalkGroupMessage) FindByMsgId(ctx context.Context, msgId string) (*model.TalkGroupMessage, error) {
 	return t.FindByWhere(ctx, "msg_id =?", msgId)
 }
+
+// FindThreadMemberIds 获取话题参与者ID(根消息发送者及回复者)
+func (t *TalkGroupMessage) FindThreadMemberIds(ctx context.Context, msgId string) ([]int, error) {
+	var ids []int
+	err := t.Model(ctx).Where("msg_id = ? or thread_id = ?", msgId, msgId).Distinct("from_id").Pluck("from_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// FindLastSequence 获取群聊消息列表(不含话题回复)最新的消息时序ID
+func (t *TalkGroupMessage) FindLastSequence(ctx context.Context, groupId int) (int64, error) {
+	var sequence int64
+	err := t.Model(ctx).Where("group_id = ? and thread_id = ''", groupId).Select("coalesce(max(sequence), 0)").Scan(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+// FindSequenceBefore 获取指定时间前群聊最新的消息时序ID(用于确定成员入群时的消息位置)
+func (t *TalkGroupMessage) FindSequenceBefore(ctx context.Context, groupId int, before time.Time) (int64, error) {
+	var sequence int64
+	err := t.Model(ctx).Where("group_id = ? and send_time < ?", groupId, before).Select("coalesce(max(sequence), 0)").Scan(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+// FindSenderIds 获取时序区间内(start, end]的消息发送者ID
+func (t *TalkGroupMessage) FindSenderIds(ctx context.Context, groupId int, start, end int64) ([]int, error) {
+	var ids []int
+	err := t.Model(ctx).
+		Where("group_id = ? and thread_id = '' and sequence > ? and sequence <= ? and from_id > 0", groupId, start, end).
+		Distinct("from_id").Pluck("from_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkGroupThread struct {
+	core.Repo[model.TalkGroupThread]
+}
+
+func NewTalkGroupThread(db *gorm.DB) *TalkGroupThread {
+	return &TalkGroupThread{Repo: core.NewRepo[model.TalkGroupThread](db)}
+}
+
+// IncrReply 话题回复数自增
+func (t *TalkGroupThread) IncrReply(ctx context.Context, groupId int, msgId string, replyAt time.Time) error {
+	return t.Db.WithContext(ctx).Exec(
+		"INSERT INTO talk_group_thread (`group_id`, `msg_id`, `reply_count`, `last_reply_at`) VALUES (?, ?, 1, ?) ON DUPLICATE KEY UPDATE reply_count = reply_count + 1, last_reply_at = ?",
+		groupId, msgId, replyAt, replyAt,
+	).Error
+}
+
+// DecrReply 话题回复数自减(回复撤回时调用)
+func (t *TalkGroupThread) DecrReply(ctx context.Context, msgId string) error {
+	return t.Db.WithContext(ctx).Model(&model.TalkGroupThread{}).
+		Where("msg_id = ? and reply_count > 0", msgId).
+		Update("reply_count", gorm.Expr("reply_count - 1")).Error
+}
+
+// FindByMsgIds 根据根消息ID获取话题信息
+func (t *TalkGroupThread) FindByMsgIds(ctx context.Context, msgIds []string) (map[string]*model.TalkGroupThread, error) {
+	items, err := t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("msg_id in ?", msgIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*model.TalkGroupThread, len(items))
+	for _, item := range items {
+		result[item.MsgId] = item
+	}
+
+	return result, nil
+}
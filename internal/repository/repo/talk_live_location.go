@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkLiveLocation struct {
+	core.Repo[model.TalkLiveLocation]
+}
+
+func NewTalkLiveLocation(db *gorm.DB) *TalkLiveLocation {
+	return &TalkLiveLocation{Repo: core.NewRepo[model.TalkLiveLocation](db)}
+}
+
+// FindAllSharing 获取全部共享中的位置共享
+func (t *TalkLiveLocation) FindAllSharing(ctx context.Context) ([]*model.TalkLiveLocation, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("status = ?", model.TalkLiveLocationStatusSharing)
+	})
+}
+
+// FindAllByTalk 获取会话中共享中的位置共享
+func (t *TalkLiveLocation) FindAllByTalk(ctx context.Context, uid int, talkMode int, toFromId int) ([]*model.TalkLiveLocation, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("talk_mode = ? and status = ?", talkMode, model.TalkLiveLocationStatusSharing)
+
+		if talkMode == 1 {
+			db.Where("(user_id = ? and to_from_id = ?) or (user_id = ? and to_from_id = ?)", uid, toFromId, toFromId, uid)
+		} else {
+			db.Where("to_from_id = ?", toFromId)
+		}
+
+		db.Order("id asc")
+	})
+}
+
+// End 结束位置共享(仅共享中的记录可结束，用于多节点抢占)
+func (t *TalkLiveLocation) End(ctx context.Context, id int, data map[string]any) (bool, error) {
+	res := t.Model(ctx).Where("id = ? and status = ?", id, model.TalkLiveLocationStatusSharing).Updates(data)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
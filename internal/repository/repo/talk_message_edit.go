@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkMessageEdit struct {
+	core.Repo[model.TalkMessageEdit]
+}
+
+func NewTalkMessageEdit(db *gorm.DB) *TalkMessageEdit {
+	return &TalkMessageEdit{Repo: core.NewRepo[model.TalkMessageEdit](db)}
+}
+
+// FindAllByMsgId 获取消息的编辑记录(按编辑时间正序)
+func (t *TalkMessageEdit) FindAllByMsgId(ctx context.Context, msgId string) ([]*model.TalkMessageEdit, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("msg_id = ?", msgId).Order("id asc")
+	})
+}
+
+// FindEditedMsgIds 获取已编辑过的消息ID
+func (t *TalkMessageEdit) FindEditedMsgIds(ctx context.Context, msgIds []string) ([]string, error) {
+	var items []string
+	err := t.Model(ctx).Distinct("msg_id").Where("msg_id in ?", msgIds).Pluck("msg_id", &items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
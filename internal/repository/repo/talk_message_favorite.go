@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkMessageFavorite struct {
+	core.Repo[model.TalkMessageFavorite]
+}
+
+func NewTalkMessageFavorite(db *gorm.DB) *TalkMessageFavorite {
+	return &TalkMessageFavorite{Repo: core.NewRepo[model.TalkMessageFavorite](db)}
+}
+
+// FindAllTags 获取用户收藏使用过的标签
+func (t *TalkMessageFavorite) FindAllTags(ctx context.Context, uid int) ([]string, error) {
+	var items []string
+	err := t.Model(ctx).Where("user_id = ? and tags <> ''", uid).Pluck("tags", &items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TalkMessageFile struct {
+	core.Repo[model.TalkMessageFile]
+}
+
+func NewTalkMessageFile(db *gorm.DB) *TalkMessageFile {
+	return &TalkMessageFile{Repo: core.NewRepo[model.TalkMessageFile](db)}
+}
+
+// Attach 写入消息附件引用记录，需与消息或收藏在同一事务中调用，已存在的引用忽略
+func (t *TalkMessageFile) Attach(tx *gorm.DB, source int, sourceId string, msgType int, extra string) error {
+	objects := model.TalkMessageFileObjects(msgType, extra)
+	if len(objects) == 0 {
+		return nil
+	}
+
+	items := make([]*model.TalkMessageFile, 0, len(objects))
+	for _, object := range objects {
+		items = append(items, &model.TalkMessageFile{Source: source, SourceId: sourceId, Object: object, CreatedAt: time.Now()})
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(items).Error
+}
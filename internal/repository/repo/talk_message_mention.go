@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TalkMessageMention struct {
+	core.Repo[model.TalkMessageMention]
+}
+
+func NewTalkMessageMention(db *gorm.DB) *TalkMessageMention {
+	return &TalkMessageMention{Repo: core.NewRepo[model.TalkMessageMention](db)}
+}
+
+// BatchCreate 批量写入提及索引(重复提及忽略)
+func (t *TalkMessageMention) BatchCreate(ctx context.Context, items []*model.TalkMessageMention) error {
+	return t.Repo.Db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(items, 500).Error
+}
+
+// Replace 替换消息的提及索引(消息编辑后)，仍被提及的用户保留已读状态
+func (t *TalkMessageMention) Replace(ctx context.Context, item *model.TalkGroupMessage, uids []int, isAll bool) error {
+	return t.Repo.Db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("msg_id = ?", item.MsgId)
+		if len(uids) > 0 {
+			query = query.Where("user_id not in ?", uids)
+		}
+
+		if err := query.Delete(&model.TalkMessageMention{}).Error; err != nil {
+			return err
+		}
+
+		if len(uids) == 0 {
+			return nil
+		}
+
+		isAllValue := lo.Ternary(isAll, model.Yes, model.No)
+		if err := tx.Model(&model.TalkMessageMention{}).Where("msg_id = ? and is_all <> ?", item.MsgId, isAllValue).Update("is_all", isAllValue).Error; err != nil {
+			return err
+		}
+
+		items := make([]*model.TalkMessageMention, 0, len(uids))
+		for _, uid := range uids {
+			items = append(items, &model.TalkMessageMention{
+				UserId:    uid,
+				GroupId:   item.GroupId,
+				MsgId:     item.MsgId,
+				FromId:    item.FromId,
+				IsAll:     isAllValue,
+				IsRead:    model.No,
+				CreatedAt: item.SendTime,
+			})
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(items, 500).Error
+	})
+}
+
+// MarkRead 标记用户在群内的提及为已读
+func (t *TalkMessageMention) MarkRead(ctx context.Context, uid int, groupId int) error {
+	_, err := t.UpdateByWhere(ctx, map[string]any{"is_read": model.Yes}, "user_id = ? and group_id = ? and is_read = ?", uid, groupId, model.No)
+	return err
+}
+
+// DeleteByMsgIds 删除消息关联的提及索引
+func (t *TalkMessageMention) DeleteByMsgIds(ctx context.Context, msgIds []string) error {
+	return t.Repo.Db.WithContext(ctx).Delete(&model.TalkMessageMention{}, "msg_id in ?", msgIds).Error
+}
+
+// FindUnreadCount 获取用户各群的未读提及数
+func (t *TalkMessageMention) FindUnreadCount(ctx context.Context, uid int) (map[int]int, error) {
+	var items []*model.TalkMentionCount
+
+	err := t.Model(ctx).Select("group_id,count(*) as num").
+		Where("user_id = ? and is_read = ?", uid, model.No).
+		Group("group_id").Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(items))
+	for _, item := range items {
+		counts[item.GroupId] = item.Num
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkMessagePin struct {
+	core.Repo[model.TalkMessagePin]
+}
+
+func NewTalkMessagePin(db *gorm.DB) *TalkMessagePin {
+	return &TalkMessagePin{Repo: core.NewRepo[model.TalkMessagePin](db)}
+}
+
+// FindAllByTalk 获取会话置顶消息(按置顶时间倒序)
+func (t *TalkMessagePin) FindAllByTalk(ctx context.Context, uid int, talkMode int, toFromId int) ([]*model.TalkMessagePin, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		if talkMode == entity.ChatPrivateMode {
+			db.Where("talk_mode = ? and user_id = ? and to_from_id = ?", talkMode, uid, toFromId)
+		} else {
+			db.Where("talk_mode = ? and to_from_id = ?", talkMode, toFromId)
+		}
+
+		db.Order("id desc")
+	})
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkMessageReaction struct {
+	core.Repo[model.TalkMessageReaction]
+}
+
+func NewTalkMessageReaction(db *gorm.DB) *TalkMessageReaction {
+	return &TalkMessageReaction{Repo: core.NewRepo[model.TalkMessageReaction](db)}
+}
+
+// FindCountByMsgIds 按消息统计表态数据，表情按首次表态时间排序
+func (t *TalkMessageReaction) FindCountByMsgIds(ctx context.Context, msgIds []string) (map[string][]*model.TalkMessageReactionCount, error) {
+	items, err := t.FindAll(ctx, func(db *gorm.DB) {
+		db.Select("msg_id,user_id,emoji").Where("msg_id in ?", msgIds).Order("id asc")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*model.TalkMessageReactionCount)
+	for _, item := range items {
+		var count *model.TalkMessageReactionCount
+		for _, value := range result[item.MsgId] {
+			if value.Emoji == item.Emoji {
+				count = value
+				break
+			}
+		}
+
+		if count == nil {
+			count = &model.TalkMessageReactionCount{Emoji: item.Emoji, UserIds: make([]int, 0)}
+			result[item.MsgId] = append(result[item.MsgId], count)
+		}
+
+		count.Count++
+		count.UserIds = append(count.UserIds, item.UserId)
+	}
+
+	return result, nil
+}
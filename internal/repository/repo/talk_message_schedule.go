@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkMessageSchedule struct {
+	core.Repo[model.TalkMessageSchedule]
+}
+
+func NewTalkMessageSchedule(db *gorm.DB) *TalkMessageSchedule {
+	return &TalkMessageSchedule{Repo: core.NewRepo[model.TalkMessageSchedule](db)}
+}
+
+// FindAllExpired 获取已到发送时间的待发送消息
+func (t *TalkMessageSchedule) FindAllExpired(ctx context.Context, now time.Time, lastId int, limit int) ([]*model.TalkMessageSchedule, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("id > ? and status = ? and send_at <= ?", lastId, model.TalkMessageScheduleStatusWait, now).Order("id asc").Limit(limit)
+	})
+}
+
+// UpdateStatus 按原状态更新发送状态(用于抢占待发送任务)
+func (t *TalkMessageSchedule) UpdateStatus(ctx context.Context, id int, from int, data map[string]any) (bool, error) {
+	res := t.Model(ctx).Where("id = ? and status = ?", id, from).Updates(data)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// FailTimeout 将超时仍处于发送中的消息标记为发送失败
+func (t *TalkMessageSchedule) FailTimeout(ctx context.Context, before time.Time, reason string) (int64, error) {
+	return t.UpdateByWhere(ctx, map[string]any{
+		"status": model.TalkMessageScheduleStatusFail,
+		"reason": reason,
+	}, "status = ? and updated_at < ?", model.TalkMessageScheduleStatusSending, before)
+}
@@ -0,0 +1,61 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TalkMessageUrgent struct {
+	core.Repo[model.TalkMessageUrgent]
+}
+
+func NewTalkMessageUrgent(db *gorm.DB) *TalkMessageUrgent {
+	return &TalkMessageUrgent{Repo: core.NewRepo[model.TalkMessageUrgent](db)}
+}
+
+// BatchCreate 批量写入紧急消息提醒
+func (t *TalkMessageUrgent) BatchCreate(ctx context.Context, items []*model.TalkMessageUrgent) error {
+	return t.Repo.Db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(items, 500).Error
+}
+
+// FindAllDue 获取已到提醒时间且未确认的紧急消息
+func (t *TalkMessageUrgent) FindAllDue(ctx context.Context, now time.Time, maxNotify int, lastId int, limit int) ([]*model.TalkMessageUrgent, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("id > ? and is_acked = ? and next_notify_at <= ? and notify_num < ?", lastId, model.No, now, maxNotify).Order("id asc").Limit(limit)
+	})
+}
+
+// FindAllByMsgIds 获取用户作为接收者的紧急消息
+func (t *TalkMessageUrgent) FindAllByMsgIds(ctx context.Context, uid int, msgIds []string) (map[string]*model.TalkMessageUrgent, error) {
+	items, err := t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ? and msg_id in ?", uid, msgIds)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hash := make(map[string]*model.TalkMessageUrgent, len(items))
+	for _, item := range items {
+		hash[item.MsgId] = item
+	}
+
+	return hash, nil
+}
+
+// Ack 接收者确认紧急消息
+func (t *TalkMessageUrgent) Ack(ctx context.Context, uid int, msgId string) (int64, error) {
+	return t.UpdateByWhere(ctx, map[string]any{
+		"is_acked": model.Yes,
+		"acked_at": time.Now(),
+	}, "user_id = ? and msg_id = ? and is_acked = ?", uid, msgId, model.No)
+}
+
+// DeleteByOrgMsgId 删除原始消息对应的全部紧急提醒(消息撤回后不再提醒)
+func (t *TalkMessageUrgent) DeleteByOrgMsgId(ctx context.Context, orgMsgId string) error {
+	return t.Repo.Db.WithContext(ctx).Delete(&model.TalkMessageUrgent{}, "org_msg_id = ?", orgMsgId).Error
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkQuickReply struct {
+	core.Repo[model.TalkQuickReply]
+}
+
+func NewTalkQuickReply(db *gorm.DB) *TalkQuickReply {
+	return &TalkQuickReply{Repo: core.NewRepo[model.TalkQuickReply](db)}
+}
+
+// FindAllVisible 获取用户可见的快捷回复(个人快捷回复及所在部门链路上的部门快捷回复)
+func (t *TalkQuickReply) FindAllVisible(ctx context.Context, uid int, deptIds []int) ([]*model.TalkQuickReply, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where(t.visible(uid, deptIds)).Order("scope asc,id desc")
+	})
+}
+
+// FindVisible 获取用户可见的快捷回复
+func (t *TalkQuickReply) FindVisible(ctx context.Context, uid int, deptIds []int, id int) (*model.TalkQuickReply, error) {
+	var item model.TalkQuickReply
+	if err := t.Model(ctx).Where("id = ?", id).Where(t.visible(uid, deptIds)).First(&item).Error; err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (t *TalkQuickReply) visible(uid int, deptIds []int) *gorm.DB {
+	cond := t.Repo.Db.Where("scope = ? and user_id = ?", model.TalkQuickReplyScopePersonal, uid)
+	if len(deptIds) > 0 {
+		cond = cond.Or("scope = ? and dept_id in ?", model.TalkQuickReplyScopeDept, deptIds)
+	}
+
+	return cond
+}
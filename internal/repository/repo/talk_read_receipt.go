@@ -0,0 +1,60 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkReadReceipt struct {
+	core.Repo[model.TalkReadReceipt]
+}
+
+func NewTalkReadReceipt(db *gorm.DB) *TalkReadReceipt {
+	return &TalkReadReceipt{Repo: core.NewRepo[model.TalkReadReceipt](db)}
+}
+
+// FindSequence 获取用户会话已读的消息时序ID
+func (t *TalkReadReceipt) FindSequence(ctx context.Context, uid int, talkMode int, toFromId int) (int64, error) {
+	info, err := t.FindByWhere(ctx, "user_id = ? and talk_mode = ? and to_from_id = ?", uid, talkMode, toFromId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return info.Sequence, nil
+}
+
+// Upsert 更新用户会话已读的消息时序ID(只增不减)
+func (t *TalkReadReceipt) Upsert(ctx context.Context, uid int, talkMode int, toFromId int, sequence int64) error {
+	return t.Db.WithContext(ctx).Exec(
+		"INSERT INTO talk_read_receipt (`user_id`, `talk_mode`, `to_from_id`, `sequence`) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE sequence = GREATEST(sequence, VALUES(sequence))",
+		uid, talkMode, toFromId, sequence,
+	).Error
+}
+
+// FindGroupReadUsers 获取已读群消息的用户列表(仅包含当前群成员)
+func (t *TalkReadReceipt) FindGroupReadUsers(ctx context.Context, groupId int, sequence int64, excludeUid int) ([]*model.TalkReadUser, error) {
+	items := make([]*model.TalkReadUser, 0)
+
+	err := t.Db.WithContext(ctx).Table("talk_read_receipt as trr").
+		Select("trr.user_id,users.nickname,users.avatar,trr.updated_at as read_at").
+		Joins("inner join group_member on group_member.group_id = trr.to_from_id and group_member.user_id = trr.user_id and group_member.is_quit = ?", model.No).
+		Joins("left join users on users.id = trr.user_id").
+		Where("trr.talk_mode = ? and trr.to_from_id = ?", entity.ChatGroupMode, groupId).
+		Where("trr.sequence >= ? and trr.user_id <> ?", sequence, excludeUid).
+		Order("trr.updated_at asc").
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 
+	"go-chat/internal/entity"
 	"go-chat/internal/pkg/core"
 	"go-chat/internal/repository/model"
 	"gorm.io/gorm"
@@ -30,3 +31,12 @@ func (t *TalkSession) FindBySessionId(uid int, receiverId int, talkType int) int
 
 	return resp.Id
 }
+
+// UpdateMessageTtl 设置私聊双方会话的消息自毁时长(会话不存在时自动创建)
+func (t *TalkSession) UpdateMessageTtl(ctx context.Context, uid int, toFromId int, ttl int) error {
+	return t.Repo.Db.WithContext(ctx).Exec(
+		"INSERT INTO talk_session (talk_mode, user_id, to_from_id, message_ttl, created_at, updated_at) VALUES (?, ?, ?, ?, NOW(), NOW()), (?, ?, ?, ?, NOW(), NOW()) ON DUPLICATE KEY UPDATE message_ttl = VALUES(message_ttl)",
+		entity.ChatPrivateMode, uid, toFromId, ttl,
+		entity.ChatPrivateMode, toFromId, uid, ttl,
+	).Error
+}
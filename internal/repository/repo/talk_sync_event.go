@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/pkg/core"
+	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
+)
+
+type TalkSyncEvent struct {
+	core.Repo[model.TalkSyncEvent]
+	sequence *Sequence
+}
+
+func NewTalkSyncEvent(db *gorm.DB, sequence *Sequence) *TalkSyncEvent {
+	return &TalkSyncEvent{Repo: core.NewRepo[model.TalkSyncEvent](db), sequence: sequence}
+}
+
+// CreateUserEvent 写入用户同步事件
+func (t *TalkSyncEvent) CreateUserEvent(ctx context.Context, uid int, event *model.TalkSyncEvent) error {
+	event.UserId = uid
+	event.GroupId = 0
+	event.Sequence = t.sequence.GetEvent(ctx, uid, true)
+	event.CreatedAt = time.Now()
+
+	return t.Create(ctx, event)
+}
+
+// CreateGroupEvent 写入群同步事件
+func (t *TalkSyncEvent) CreateGroupEvent(ctx context.Context, gid int, event *model.TalkSyncEvent) error {
+	event.UserId = 0
+	event.GroupId = gid
+	event.Sequence = t.sequence.GetEvent(ctx, gid, false)
+	event.CreatedAt = time.Now()
+
+	return t.Create(ctx, event)
+}
+
+// FindAllUserEvents 获取时序ID之后的用户同步事件
+func (t *TalkSyncEvent) FindAllUserEvents(ctx context.Context, uid int, sequence int64, limit int) ([]*model.TalkSyncEvent, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ? and group_id = 0 and sequence > ?", uid, sequence).Order("sequence asc").Limit(limit)
+	})
+}
+
+// FindAllGroupEvents 获取时序ID之后的群同步事件
+func (t *TalkSyncEvent) FindAllGroupEvents(ctx context.Context, gid int, sequence int64, limit int) ([]*model.TalkSyncEvent, error) {
+	return t.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = 0 and group_id = ? and sequence > ?", gid, sequence).Order("sequence asc").Limit(limit)
+	})
+}
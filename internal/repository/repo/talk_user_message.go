@@ -19,3 +19,32 @@ func NewTalkRecordFriend(db *gorm.DB) *TalkUserMessage {
 func (t *TalkUserMessage) FindByMsgId(ctx context.Context, msgId string) (*model.TalkUserMessage, error) {
 	return t.FindByWhere(ctx, "msg_id = ?", msgId)
 }
+
+// FindLastSequence 获取用户私聊会话最新的消息时序ID
+func (t *TalkUserMessage) FindLastSequence(ctx context.Context, uid int, toFromId int) (int64, error) {
+	var sequence int64
+	err := t.Model(ctx).Where("user_id = ? and to_from_id = ?", uid, toFromId).Select("coalesce(max(sequence), 0)").Scan(&sequence).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+// UpdateDelivered 标记发送者信箱中的消息为已送达，返回受影响行数
+func (t *TalkUserMessage) UpdateDelivered(ctx context.Context, orgMsgId string) (int64, error) {
+	res := t.Db.WithContext(ctx).Exec(
+		"UPDATE talk_user_message SET delivery_status = ? WHERE org_msg_id = ? AND user_id = from_id AND delivery_status < ?",
+		model.MessageDeliveryDelivered, orgMsgId, model.MessageDeliveryDelivered,
+	)
+
+	return res.RowsAffected, res.Error
+}
+
+// UpdateRead 标记发送者信箱中已被接收者阅读的消息为已读
+func (t *TalkUserMessage) UpdateRead(ctx context.Context, senderId int, receiverId int, sequence int64) error {
+	return t.Db.WithContext(ctx).Exec(
+		"UPDATE talk_user_message SET delivery_status = ? WHERE user_id = ? AND to_from_id = ? AND from_id = ? AND sequence <= ? AND delivery_status < ?",
+		model.MessageDeliveryRead, senderId, receiverId, senderId, sequence, model.MessageDeliveryRead,
+	).Error
+}
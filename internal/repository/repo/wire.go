@@ -17,6 +17,23 @@ var ProviderSet = wire.NewSet(
 	NewGroupNotice,
 	NewTalkSession,
 	NewTalkRecordGroupDel,
+	NewTalkMessageEdit,
+	NewTalkMessageReaction,
+	NewTalkGroupThread,
+	NewTalkReadReceipt,
+	NewTalkMessageSchedule,
+	NewTalkMessagePin,
+	NewTalkMessageFavorite,
+	NewTalkDraft,
+	NewTalkSyncEvent,
+	NewTalkMessageMention,
+	NewTalkExport,
+	NewTalkBroadcast,
+	NewTalkBroadcastLog,
+	NewTalkQuickReply,
+	NewTalkMessageUrgent,
+	NewTalkMessageFile,
+	NewTalkLiveLocation,
 	NewEmoticon,
 	NewGroupVote,
 	NewFileUpload,
@@ -50,7 +50,7 @@ func (s *ContactApplyService) Create(ctx context.Context, opt *ContactApplyCreat
 		return err
 	}
 
-	_ = s.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+	_ = s.PushMessage.PushUsers(ctx, []int{opt.FriendId}, &entity.SubscribeMessage{
 		Event: entity.SubEventContactApply,
 		Payload: jsonutil.Encode(entity.SubEventContactApplyPayload{
 			ApplyId: apply.Id,
@@ -138,7 +138,7 @@ func (s *ContactApplyService) Decline(ctx context.Context, opt *ContactApplyDecl
 		return err
 	}
 
-	_ = s.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+	_ = s.PushMessage.PushUsers(ctx, []int{opt.UserId}, &entity.SubscribeMessage{
 		Event: entity.SubEventContactApply,
 		Payload: jsonutil.Encode(entity.SubEventContactApplyPayload{
 			ApplyId: opt.ApplyId,
@@ -136,15 +136,13 @@ func (g *GroupService) Create(ctx context.Context, opt *GroupCreateOpt) (int, er
 		return nil
 	})
 
-	_ = g.PushMessage.MultiPush(ctx, entity.ImTopicChat, []*entity.SubscribeMessage{
-		{
-			Event: entity.SubEventGroupJoin,
-			Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
-				GroupId: group.Id,
-				Type:    1,
-				Uids:    uids,
-			}),
-		},
+	_ = g.PushMessage.PushUsers(ctx, uids, &entity.SubscribeMessage{
+		Event: entity.SubEventGroupJoin,
+		Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
+			GroupId: group.Id,
+			Type:    1,
+			Uids:    uids,
+		}),
 	})
 
 	return group.Id, err
@@ -248,22 +246,21 @@ func (g *GroupService) Secede(ctx context.Context, groupId int, uid int) error {
 
 	g.Relation.DelGroupRelation(ctx, uid, groupId)
 
-	_ = g.PushMessage.MultiPush(ctx, entity.ImTopicChat, []*entity.SubscribeMessage{
-		{
-			Event: entity.SubEventGroupJoin,
-			Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
-				Type:    2,
-				GroupId: groupId,
-				Uids:    []int{uid},
-			}),
-		},
-		{
-			Event: entity.SubEventImMessage,
-			Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
-				TalkMode: entity.ChatGroupMode,
-				Message:  jsonutil.Encode(record),
-			}),
-		},
+	_ = g.PushMessage.PushUsers(ctx, []int{uid}, &entity.SubscribeMessage{
+		Event: entity.SubEventGroupJoin,
+		Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
+			Type:    2,
+			GroupId: groupId,
+			Uids:    []int{uid},
+		}),
+	})
+
+	_ = g.PushMessage.PushGroup(ctx, groupId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessage,
+		Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
+			TalkMode: entity.ChatGroupMode,
+			Message:  jsonutil.Encode(record),
+		}),
 	})
 
 	return nil
@@ -402,22 +399,21 @@ func (g *GroupService) Invite(ctx context.Context, opt *GroupInviteOpt) error {
 		return err
 	}
 
-	_ = g.PushMessage.MultiPush(ctx, entity.ImTopicChat, []*entity.SubscribeMessage{
-		{
-			Event: entity.SubEventImMessage,
-			Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
-				TalkMode: entity.ChatGroupMode,
-				Message:  jsonutil.Encode(record),
-			}),
-		},
-		{
-			Event: entity.SubEventGroupJoin,
-			Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
-				GroupId: opt.GroupId,
-				Type:    1,
-				Uids:    opt.MemberIds,
-			}),
-		},
+	_ = g.PushMessage.PushGroup(ctx, opt.GroupId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessage,
+		Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
+			TalkMode: entity.ChatGroupMode,
+			Message:  jsonutil.Encode(record),
+		}),
+	})
+
+	_ = g.PushMessage.PushUsers(ctx, opt.MemberIds, &entity.SubscribeMessage{
+		Event: entity.SubEventGroupJoin,
+		Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
+			GroupId: opt.GroupId,
+			Type:    1,
+			Uids:    opt.MemberIds,
+		}),
 	})
 
 	return nil
@@ -498,22 +494,21 @@ func (g *GroupService) RemoveMember(ctx context.Context, opt *GroupRemoveMembers
 
 	g.Relation.BatchDelGroupRelation(ctx, opt.MemberIds, opt.GroupId)
 
-	_ = g.PushMessage.MultiPush(ctx, entity.ImTopicChat, []*entity.SubscribeMessage{
-		{
-			Event: entity.SubEventGroupJoin,
-			Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
-				GroupId: opt.GroupId,
-				Type:    2,
-				Uids:    opt.MemberIds,
-			}),
-		},
-		{
-			Event: entity.SubEventImMessage,
-			Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
-				TalkMode: entity.ChatGroupMode,
-				Message:  jsonutil.Encode(record),
-			}),
-		},
+	_ = g.PushMessage.PushUsers(ctx, opt.MemberIds, &entity.SubscribeMessage{
+		Event: entity.SubEventGroupJoin,
+		Payload: jsonutil.Encode(entity.SubEventGroupJoinPayload{
+			GroupId: opt.GroupId,
+			Type:    2,
+			Uids:    opt.MemberIds,
+		}),
+	})
+
+	_ = g.PushMessage.PushGroup(ctx, opt.GroupId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessage,
+		Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
+			TalkMode: entity.ChatGroupMode,
+			Message:  jsonutil.Encode(record),
+		}),
 	})
 
 	return nil
@@ -1,5 +1,7 @@
 package message
 
+import "go-chat/internal/repository/model"
+
 type CreatePrivateSysMessageOption struct {
 	FromId   int    `json:"from_id"`    // 发送者
 	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
@@ -7,11 +9,13 @@ type CreatePrivateSysMessageOption struct {
 }
 
 type CreatePrivateMessageOption struct {
-	MsgType  int    `json:"msg_type"`   // 消息类型，1-文本消息，2-图片消息，3-语音消息，4-视频消息，5-文件消息，6-链接消息，7-小程序消息
-	FromId   int    `json:"from_id"`    // 发送者
-	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
-	QuoteId  string `json:"quote_id"`   // 引用消息id
-	Extra    string `json:"extra"`      // 扩展字段
+	MsgType      int    `json:"msg_type"`      // 消息类型，1-文本消息，2-图片消息，3-语音消息，4-视频消息，5-文件消息，6-链接消息，7-小程序消息
+	FromId       int    `json:"from_id"`       // 发送者
+	ToFromId     int    `json:"to_from_id"`    // 接受者(好友ID或者群组ID)
+	QuoteId      string `json:"quote_id"`      // 引用消息id
+	Extra        string `json:"extra"`         // 扩展字段
+	FromSequence int64  `json:"from_sequence"` // 发送者信箱的时序ID(批量发送时预先分配，为0时自动获取)
+	IsUrgent     bool   `json:"is_urgent"`     // 是否为紧急消息
 }
 
 type CreateGroupMessageOption struct {
@@ -19,7 +23,10 @@ type CreateGroupMessageOption struct {
 	FromId   int    `json:"from_id"`    // 发送者
 	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
 	QuoteId  string `json:"quote_id"`   // 引用消息id
+	ThreadId string `json:"thread_id"`  // 话题根消息id
 	Extra    string `json:"extra"`      // 扩展字段
+	Mentions []int  `json:"mentions"`   // @用户ID列表(0表示@所有人)
+	IsUrgent bool   `json:"is_urgent"`  // 是否为紧急消息
 }
 
 type CreateGroupSysMessageOption struct {
@@ -33,7 +40,10 @@ type CreateMessageOption struct {
 	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
 	MsgType  int    `json:"msg_type"`   // 消息类型
 	QuoteId  string `json:"quote_id"`   // 引用消息id
+	ThreadId string `json:"thread_id"`  // 话题根消息id(仅群聊)
 	Extra    string `json:"extra"`      // 扩展字段
+	Mentions []int  `json:"mentions"`   // @用户ID列表(仅群聊，0表示@所有人)
+	IsUrgent bool   `json:"is_urgent"`  // 是否为紧急消息
 }
 
 type CreateLoginMessageOption struct {
@@ -52,7 +62,9 @@ type CreateTextMessage struct {
 	ToFromId int    `json:"to_from_id"`         // 接受者(好友ID或者群组ID)
 	Content  string `json:"content"`            // 消息内容
 	QuoteId  string `json:"quote_id"`           // 引用消息id
+	ThreadId string `json:"thread_id"`          // 话题根消息id(仅群聊)
 	Mentions []int  `json:"mentions,omitempty"` // @用户ID列表
+	IsUrgent bool   `json:"is_urgent"`          // 是否为紧急消息
 }
 
 type CreateImageMessage struct {
@@ -60,6 +72,7 @@ type CreateImageMessage struct {
 	FromId   int    `json:"from_id"`    // 发送者
 	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
 	QuoteId  string `json:"quote_id"`   // 引用消息id
+	ThreadId string `json:"thread_id"`  // 话题根消息id(仅群聊)
 	Url      string `json:"url"`        // 图片地址
 	Width    int    `json:"width"`      // 图片宽度
 	Height   int    `json:"height"`     // 图片高度
@@ -96,6 +109,7 @@ type CreateCodeMessage struct {
 	TalkMode int    `json:"talk_mode"`  // 发送模式，1-单聊，2-群聊
 	FromId   int    `json:"from_id"`    // 发送者
 	ToFromId int    `json:"to_from_id"` // 接受者(好友ID或者群组ID)
+	ThreadId string `json:"thread_id"`  // 话题根消息id(仅群聊)
 	Code     string `json:"code"`       // 代码内容
 	Lang     string `json:"lang"`       // 代码语言
 }
@@ -123,6 +137,8 @@ type CreateForwardMessage struct {
 	Gids     []int    `json:"gids"` // 群ID列表
 	Uids     []int    `json:"uids"` // 好友ID列表
 	UserId   int      `json:"user_id"`
+
+	Records []model.TalkRecord `json:"-"` // 消息快照(逐条转发时使用)
 }
 
 type CreateLocationMessage struct {
@@ -146,8 +162,10 @@ type CreateMixedMessage struct {
 	FromId      int                      `json:"from_id"`            // 发送者
 	ToFromId    int                      `json:"to_from_id"`         // 接受者(好友ID或者群组ID)
 	QuoteId     string                   `json:"quote_id"`           // 引用消息id
+	ThreadId    string                   `json:"thread_id"`          // 话题根消息id(仅群聊)
 	Mentions    []int                    `json:"mentions,omitempty"` // @用户ID列表
 	MessageList []CreateMixedMessageItem `json:"message_list"`       // 消息列表
+	IsUrgent    bool                     `json:"is_urgent"`          // 是否为紧急消息
 }
 
 type CreateMixedMessageItem struct {
@@ -10,6 +10,7 @@ import (
 	"go-chat/internal/pkg/logger"
 	"go-chat/internal/pkg/strutil"
 	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
 )
 
 type ForwardMessageOpt struct {
@@ -19,6 +20,8 @@ type ForwardMessageOpt struct {
 	UserId       int      `json:"user_id"`
 	ToUserId     int      `json:"to_user_id"`
 	ToUserIdType int      `json:"to_user_id_type"` // 1:用户ID 2:群ID
+
+	Records []model.TalkRecord `json:"-"` // 消息快照(不为空时直接转发快照内容，仅支持逐条转发)
 }
 
 // SplitForward 分拆转发
@@ -29,7 +32,9 @@ func (s *Service) toSplitForward(ctx context.Context, req ForwardMessageOpt) err
 		messageItems = make([]model.TalkRecord, 0)
 	)
 
-	if req.TalkMode == entity.ChatGroupMode {
+	if len(req.Records) > 0 {
+		messageItems = append(messageItems, req.Records...)
+	} else if req.TalkMode == entity.ChatGroupMode {
 		records := make([]model.TalkGroupMessage, 0)
 
 		err := db.Table("talk_group_message").Where("group_id = ? and msg_id in ?", req.ToFromId, req.MsgIds).Scan(&records).Error
@@ -78,8 +83,22 @@ func (s *Service) toSplitForward(ctx context.Context, req ForwardMessageOpt) err
 			})
 		}
 
-		if err := db.Create(items).Error; err == nil {
-			err = s.PushMessage.MultiPush(ctx, entity.ImTopicChat,
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(items).Error; err != nil {
+				return err
+			}
+
+			for _, item := range items {
+				if err := s.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourceGroup, item.MsgId, item.MsgType, item.Extra); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err == nil {
+			err = s.PushMessage.MultiPushGroup(ctx, req.ToUserId,
 				lo.Map(items, func(item model.TalkGroupMessage, index int) *entity.SubscribeMessage {
 					return &entity.SubscribeMessage{
 						Event: entity.SubEventImMessage,
@@ -136,7 +155,21 @@ func (s *Service) toSplitForward(ctx context.Context, req ForwardMessageOpt) err
 			})
 		}
 
-		if err := db.Create(items).Error; err == nil {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(items).Error; err != nil {
+				return err
+			}
+
+			for _, item := range items {
+				if err := s.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourcePrivate, item.MsgId, item.MsgType, item.Extra); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		if err == nil {
 			list := lo.Map(items, func(item model.TalkUserMessage, _ int) *entity.SubscribeMessage {
 				return &entity.SubscribeMessage{
 					Event: entity.SubEventImMessage,
@@ -147,7 +180,7 @@ func (s *Service) toSplitForward(ctx context.Context, req ForwardMessageOpt) err
 				}
 			})
 
-			_ = s.PushMessage.MultiPush(ctx, entity.ImTopicChat, list)
+			_ = s.PushMessage.MultiPushUsers(ctx, []int{req.UserId, req.ToUserId}, list)
 		} else {
 			logger.Errorf("split forward message failed :%s", err.Error())
 		}
@@ -297,8 +330,7 @@ func (s *Service) toCombineForward(ctx context.Context, req ForwardMessageOpt) e
 	}
 
 	if len(pushMessageItems) > 0 {
-		err := s.PushMessage.MultiPush(ctx,
-			entity.ImTopicChat,
+		err := s.PushMessage.MultiPushTalk(ctx, req.ToUserIdType, req.UserId, req.ToUserId,
 			lo.Map(pushMessageItems, func(item entity.SubEventImMessagePayload, index int) *entity.SubscribeMessage {
 				return &entity.SubscribeMessage{
 					Event: entity.SubEventImMessage,
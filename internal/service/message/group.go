@@ -2,19 +2,41 @@ package message
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/samber/lo"
 	"go-chat/internal/entity"
 	"go-chat/internal/pkg/jsonutil"
 	"go-chat/internal/pkg/logger"
 	"go-chat/internal/pkg/strutil"
 	"go-chat/internal/repository/cache"
 	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
 )
 
 func (s *Service) CreateGroupMessage(ctx context.Context, option CreateGroupMessageOption) error {
 	quoteJsonText := "{}"
 
+	if option.ThreadId != "" {
+		root := &model.TalkGroupMessage{}
+		if err := s.Db().WithContext(ctx).First(root, "msg_id = ?", option.ThreadId).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("话题消息不存在")
+			}
+
+			return err
+		}
+
+		if root.GroupId != option.ToFromId || root.ThreadId != "" {
+			return errors.New("话题消息不存在")
+		}
+
+		if root.IsRevoked == model.Yes {
+			return errors.New("话题消息已撤回")
+		}
+	}
+
 	if option.QuoteId != "" {
 		quoteRecord := &model.TalkGroupMessage{}
 		if err := s.Db().First(quoteRecord, "msg_id = ?", option.QuoteId).Error; err != nil {
@@ -32,10 +54,15 @@ func (s *Service) CreateGroupMessage(ctx context.Context, option CreateGroupMess
 		}
 
 		quote.Nickname = user.Nickname
-		quote.Content = s.getTextMessage(quoteRecord.MsgType, quoteRecord.Extra)
+		quote.Content = s.GetTextMessage(quoteRecord.MsgType, quoteRecord.Extra)
 		quoteJsonText = jsonutil.Encode(quote)
 	}
 
+	mentionIds, isMentionAll, err := s.findMentionUserIds(ctx, option.ToFromId, option.FromId, option.Mentions)
+	if err != nil {
+		return err
+	}
+
 	item := &model.TalkGroupMessage{
 		MsgId:     strutil.NewMsgId(),
 		Sequence:  s.Sequence.Get(ctx, option.ToFromId, false),
@@ -43,26 +70,57 @@ func (s *Service) CreateGroupMessage(ctx context.Context, option CreateGroupMess
 		GroupId:   option.ToFromId,
 		FromId:    option.FromId,
 		Quote:     quoteJsonText,
+		ThreadId:  option.ThreadId,
 		Extra:     option.Extra,
 		IsRevoked: model.No,
 		SendTime:  time.Now(),
 	}
 
-	if err := s.Db().WithContext(ctx).Create(item).Error; err != nil {
+	err = s.Db().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+
+		return s.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourceGroup, item.MsgId, item.MsgType, item.Extra)
+	})
+	if err != nil {
 		return err
 	}
 
-	err := s.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+	if item.ThreadId != "" {
+		if err := s.TalkGroupThreadRepo.IncrReply(ctx, item.GroupId, item.ThreadId, item.SendTime); err != nil {
+			logger.Errorf("CreateGroupMessage incr thread reply error:%s", err.Error())
+		}
+	}
+
+	if len(mentionIds) > 0 {
+		s.createMentions(ctx, item, mentionIds, isMentionAll)
+	}
+
+	if option.IsUrgent {
+		s.createGroupUrgent(ctx, item)
+	}
+
+	err = s.PushMessage.PushGroup(ctx, item.GroupId, &entity.SubscribeMessage{
 		Event: entity.SubEventImMessage,
 		Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
 			TalkMode: entity.ChatGroupMode,
 			Message:  jsonutil.Encode(item),
+			IsUrgent: option.IsUrgent,
 		}),
 	})
 	if err != nil {
 		logger.Errorf("CreateGroupMessage publish message error:%s", err.Error())
 	}
 
+	s.PublishLinkPreview(ctx, entity.ChatGroupMode, item.MsgId, item.MsgType, item.Extra)
+
+	// 话题回复仅更新话题参与者的话题未读数，不影响群会话未读数及最后一条消息
+	if item.ThreadId != "" {
+		s.incrThreadUnread(ctx, item)
+		return nil
+	}
+
 	pipe := s.Source.Redis().Pipeline()
 	for _, uid := range s.GroupMemberRepo.GetMemberIds(ctx, item.GroupId) {
 		if uid != item.FromId {
@@ -73,7 +131,7 @@ func (s *Service) CreateGroupMessage(ctx context.Context, option CreateGroupMess
 
 	// 更新最后一条消息
 	_ = s.MessageStorage.Set(ctx, entity.ChatGroupMode, item.FromId, item.GroupId, &cache.LastCacheMessage{
-		Content:  s.getTextMessage(item.MsgType, option.Extra),
+		Content:  s.GetTextMessage(item.MsgType, option.Extra),
 		Datetime: item.CreatedAt.Format(time.DateTime),
 	})
 
@@ -90,3 +148,89 @@ func (s *Service) CreateGroupSysMessage(ctx context.Context, option CreateGroupS
 		}),
 	})
 }
+
+// 校验@的成员并返回需要建立提及索引的用户ID，仅群主或管理员可以@所有人
+func (s *Service) findMentionUserIds(ctx context.Context, groupId int, fromId int, mentions []int) ([]int, bool, error) {
+	if len(mentions) == 0 {
+		return nil, false, nil
+	}
+
+	isMentionAll := lo.Contains(mentions, model.MentionAllUserId)
+	if isMentionAll && !s.GroupMemberRepo.IsLeader(ctx, groupId, fromId) {
+		return nil, false, errors.New("仅群主或管理员可以@所有人")
+	}
+
+	memberIds := s.GroupMemberRepo.GetMemberIds(ctx, groupId)
+	if !isMentionAll {
+		memberIds = lo.Intersect(memberIds, mentions)
+	}
+
+	return lo.Without(lo.Uniq(memberIds), fromId), isMentionAll, nil
+}
+
+// UpdateGroupMentions 消息编辑后按新的@用户列表更新提及索引
+func (s *Service) UpdateGroupMentions(ctx context.Context, item *model.TalkGroupMessage, mentions []int) error {
+	uids, isMentionAll, err := s.findMentionUserIds(ctx, item.GroupId, item.FromId, mentions)
+	if err != nil {
+		return err
+	}
+
+	return s.TalkMentionRepo.Replace(ctx, item, uids, isMentionAll)
+}
+
+// UpdateGroupLastMessage 仅当编辑的消息为会话最新消息时更新，item.Extra 为编辑后的内容
+func (s *Service) UpdateGroupLastMessage(ctx context.Context, item *model.TalkGroupMessage) error {
+	if item.ThreadId != "" {
+		return nil
+	}
+
+	sequence, err := s.TalkGroupMessageRepo.FindLastSequence(ctx, item.GroupId)
+	if err != nil {
+		return err
+	}
+
+	if sequence != item.Sequence {
+		return nil
+	}
+
+	return s.MessageStorage.Set(ctx, entity.ChatGroupMode, item.FromId, item.GroupId, &cache.LastCacheMessage{
+		Content:  s.GetTextMessage(item.MsgType, item.Extra),
+		Datetime: item.CreatedAt.Format(time.DateTime),
+	})
+}
+
+// 写入提及索引
+func (s *Service) createMentions(ctx context.Context, item *model.TalkGroupMessage, uids []int, isMentionAll bool) {
+	items := make([]*model.TalkMessageMention, 0, len(uids))
+	for _, uid := range uids {
+		items = append(items, &model.TalkMessageMention{
+			UserId:    uid,
+			GroupId:   item.GroupId,
+			MsgId:     item.MsgId,
+			FromId:    item.FromId,
+			IsAll:     lo.Ternary(isMentionAll, model.Yes, model.No),
+			IsRead:    model.No,
+			CreatedAt: item.SendTime,
+		})
+	}
+
+	if err := s.TalkMentionRepo.BatchCreate(ctx, items); err != nil {
+		logger.Errorf("CreateGroupMessage create mentions error:%s", err.Error())
+	}
+}
+
+func (s *Service) incrThreadUnread(ctx context.Context, item *model.TalkGroupMessage) {
+	uids, err := s.TalkGroupMessageRepo.FindThreadMemberIds(ctx, item.ThreadId)
+	if err != nil {
+		logger.Errorf("CreateGroupMessage find thread members error:%s", err.Error())
+		return
+	}
+
+	pipe := s.Source.Redis().Pipeline()
+	for _, uid := range uids {
+		if uid != item.FromId && uid > 0 {
+			s.UnreadStorage.PipeIncrThread(ctx, pipe, uid, item.ThreadId)
+		}
+	}
+	_, _ = pipe.Exec(ctx)
+}
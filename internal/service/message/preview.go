@@ -0,0 +1,38 @@
+package message
+
+import (
+	"context"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/unfurl"
+	"go-chat/internal/repository/model"
+)
+
+// PublishLinkPreview 文本消息包含链接时投递链接预览任务，由队列异步生成预览
+func (s *Service) PublishLinkPreview(ctx context.Context, talkMode int, msgId string, msgType int, extra string) {
+	if msgType != entity.ChatMsgTypeText {
+		return
+	}
+
+	var text model.TalkRecordExtraText
+	if err := jsonutil.Decode(extra, &text); err != nil {
+		return
+	}
+
+	// 已生成预览的消息无需重复投递
+	url := unfurl.FindUrl(text.Content)
+	if url == "" || text.Preview != nil {
+		return
+	}
+
+	err := s.Bus.Publish(ctx, entity.LinkPreviewTopic, jsonutil.Marshal(entity.LinkPreviewPayload{
+		TalkMode: talkMode,
+		MsgId:    msgId,
+		Url:      url,
+	}))
+	if err != nil {
+		logger.Errorf("publish link preview error:%s", err.Error())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"go-chat/internal/pkg/strutil"
 	"go-chat/internal/repository/cache"
 	"go-chat/internal/repository/model"
+	"gorm.io/gorm"
 )
 
 func (s *Service) CreatePrivateMessage(ctx context.Context, option CreatePrivateMessageOption) error {
@@ -37,13 +38,18 @@ func (s *Service) CreatePrivateMessage(ctx context.Context, option CreatePrivate
 		}
 
 		queue.Nickname = user.Nickname
-		queue.Content = s.getTextMessage(quoteRecord.MsgType, quoteRecord.Extra)
+		queue.Content = s.GetTextMessage(quoteRecord.MsgType, quoteRecord.Extra)
 		quoteJsonText = jsonutil.Encode(queue)
 	}
 
+	fromSequence := option.FromSequence
+	if fromSequence <= 0 {
+		fromSequence = s.Sequence.Get(ctx, option.FromId, true)
+	}
+
 	items = append(items, &model.TalkUserMessage{
 		MsgId:     strutil.NewMsgId(),
-		Sequence:  s.Sequence.Get(ctx, option.FromId, true),
+		Sequence:  fromSequence,
 		MsgType:   option.MsgType,
 		UserId:    option.FromId,
 		ToFromId:  option.ToFromId,
@@ -71,36 +77,59 @@ func (s *Service) CreatePrivateMessage(ctx context.Context, option CreatePrivate
 		IsDeleted: model.No,
 	})
 
-	if err := s.Db().WithContext(ctx).Create(items).Error; err != nil {
+	err := s.Db().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(items).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := s.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourcePrivate, item.MsgId, item.MsgType, item.Extra); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
-	// 推送消息
+	if option.IsUrgent {
+		s.createPrivateUrgent(ctx, items[1])
+	}
+
 	pipe := s.Source.Redis().Pipeline()
 	for _, item := range items {
-		content := &entity.SubscribeMessage{
-			Event: entity.SubEventImMessage,
-			Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
-				TalkMode: entity.ChatPrivateMode,
-				Message:  jsonutil.Encode(item),
-			}),
-		}
-
-		pipe.Publish(ctx, entity.ImTopicChat, jsonutil.Encode(content))
-
 		if item.UserId != option.FromId {
 			s.UnreadStorage.PipeIncr(ctx, pipe, item.UserId, entity.ChatPrivateMode, item.ToFromId)
 		}
 
 		// 更新最后一条消息
 		_ = s.MessageStorage.Set(ctx, entity.ChatPrivateMode, item.UserId, item.ToFromId, &cache.LastCacheMessage{
-			Content:  s.getTextMessage(item.MsgType, option.Extra),
+			Content:  s.GetTextMessage(item.MsgType, option.Extra),
 			Datetime: item.CreatedAt.Format(time.DateTime),
 		})
 	}
 
 	_, _ = pipe.Exec(ctx)
 
+	// 推送消息
+	for _, item := range items {
+		err := s.PushMessage.PushUsers(ctx, []int{item.UserId}, &entity.SubscribeMessage{
+			Event: entity.SubEventImMessage,
+			Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
+				TalkMode: entity.ChatPrivateMode,
+				Message:  jsonutil.Encode(item),
+				IsUrgent: option.IsUrgent,
+			}),
+		})
+		if err != nil {
+			logger.Errorf("CreatePrivateMessage publish message error:%s", err.Error())
+		}
+	}
+
+	s.PublishLinkPreview(ctx, entity.ChatPrivateMode, orgMsgId, option.MsgType, option.Extra)
+
 	return nil
 }
 
@@ -128,11 +157,18 @@ func (s *Service) CreateToUserPrivateMessage(ctx context.Context, data *model.Ta
 	data.IsRevoked = model.No
 	data.IsDeleted = model.No
 
-	if err := s.Db().WithContext(ctx).Create(data).Error; err != nil {
+	err := s.Db().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+
+		return s.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourcePrivate, data.MsgId, data.MsgType, data.Extra)
+	})
+	if err != nil {
 		return err
 	}
 
-	err := s.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+	err = s.PushMessage.PushUsers(ctx, []int{data.UserId}, &entity.SubscribeMessage{
 		Event: entity.SubEventImMessage,
 		Payload: jsonutil.Encode(entity.SubEventImMessagePayload{
 			TalkMode: entity.ChatPrivateMode,
@@ -147,13 +183,30 @@ func (s *Service) CreateToUserPrivateMessage(ctx context.Context, data *model.Ta
 
 	// 更新最后一条消息
 	_ = s.MessageStorage.Set(ctx, entity.ChatPrivateMode, data.UserId, data.ToFromId, &cache.LastCacheMessage{
-		Content:  s.getTextMessage(data.MsgType, data.Extra),
+		Content:  s.GetTextMessage(data.MsgType, data.Extra),
 		Datetime: data.CreatedAt.Format(time.DateTime),
 	})
 
 	return nil
 }
 
+// UpdatePrivateLastMessage 仅当编辑的消息为会话最新消息时更新，item.Extra 为编辑后的内容
+func (s *Service) UpdatePrivateLastMessage(ctx context.Context, item *model.TalkUserMessage) error {
+	sequence, err := s.TalkUserMessageRepo.FindLastSequence(ctx, item.UserId, item.ToFromId)
+	if err != nil {
+		return err
+	}
+
+	if sequence != item.Sequence {
+		return nil
+	}
+
+	return s.MessageStorage.Set(ctx, entity.ChatPrivateMode, item.UserId, item.ToFromId, &cache.LastCacheMessage{
+		Content:  s.GetTextMessage(item.MsgType, item.Extra),
+		Datetime: item.CreatedAt.Format(time.DateTime),
+	})
+}
+
 func (s *Service) CreatePrivateSysMessage(ctx context.Context, option CreatePrivateSysMessageOption) error {
 	return s.CreateToUserPrivateMessage(ctx, &model.TalkUserMessage{
 		MsgId:    strutil.NewMsgId(),
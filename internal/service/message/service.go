@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/bus"
 	"go-chat/internal/pkg/filesystem"
 	"go-chat/internal/pkg/jsonutil"
 	"go-chat/internal/pkg/logger"
@@ -30,6 +31,8 @@ type IPrivateMessage interface {
 	CreatePrivateMessage(ctx context.Context, option CreatePrivateMessageOption) error
 	// CreateToUserPrivateMessage 给指定用户信箱添加消息
 	CreateToUserPrivateMessage(ctx context.Context, data *model.TalkUserMessage) error
+	// UpdatePrivateLastMessage 消息编辑后更新会话最后一条消息
+	UpdatePrivateLastMessage(ctx context.Context, item *model.TalkUserMessage) error
 }
 
 // IGroupMessage 群消息
@@ -38,6 +41,10 @@ type IGroupMessage interface {
 	CreateGroupMessage(ctx context.Context, option CreateGroupMessageOption) error
 	// CreateGroupSysMessage 创建群系统消息
 	CreateGroupSysMessage(ctx context.Context, option CreateGroupSysMessageOption) error
+	// UpdateGroupMentions 更新群消息的提及索引
+	UpdateGroupMentions(ctx context.Context, item *model.TalkGroupMessage, mentions []int) error
+	// UpdateGroupLastMessage 消息编辑后更新会话最后一条消息
+	UpdateGroupLastMessage(ctx context.Context, item *model.TalkGroupMessage) error
 }
 
 type IMessage interface {
@@ -69,6 +76,10 @@ type IMessage interface {
 	CreateBusinessCardMessage(ctx context.Context, option CreateBusinessCardMessage) error
 	// CreateMixedMessage 图文消息
 	CreateMixedMessage(ctx context.Context, option CreateMixedMessage) error
+	// GetTextMessage 获取消息摘要
+	GetTextMessage(msgType int, extra string) string
+	// PublishLinkPreview 投递链接预览任务
+	PublishLinkPreview(ctx context.Context, talkMode int, msgId string, msgType int, extra string)
 }
 
 type IService interface {
@@ -79,19 +90,26 @@ type IService interface {
 
 type Service struct {
 	*repo.Source
-	GroupMemberRepo     *repo.GroupMember
-	SplitUploadRepo     *repo.FileUpload
-	TalkRecordsVoteRepo *repo.GroupVote
-	UsersRepo           *repo.Users
-	Filesystem          filesystem.IFilesystem
-	UnreadStorage       *cache.UnreadStorage
-	MessageStorage      *cache.MessageStorage
-	ServerStorage       *cache.ServerStorage
-	ClientStorage       *cache.ClientStorage
-	Sequence            *repo.Sequence
-	RobotRepo           *repo.Robot
+	GroupMemberRepo      *repo.GroupMember
+	SplitUploadRepo      *repo.FileUpload
+	TalkRecordsVoteRepo  *repo.GroupVote
+	UsersRepo            *repo.Users
+	Filesystem           filesystem.IFilesystem
+	UnreadStorage        *cache.UnreadStorage
+	MessageStorage       *cache.MessageStorage
+	ServerStorage        *cache.ServerStorage
+	ClientStorage        *cache.ClientStorage
+	Sequence             *repo.Sequence
+	RobotRepo            *repo.Robot
+	TalkGroupThreadRepo  *repo.TalkGroupThread
+	TalkUserMessageRepo  *repo.TalkUserMessage
+	TalkGroupMessageRepo *repo.TalkGroupMessage
+	TalkMentionRepo      *repo.TalkMessageMention
+	TalkUrgentRepo       *repo.TalkMessageUrgent
+	TalkMessageFileRepo  *repo.TalkMessageFile
 
 	PushMessage *business.PushMessage
+	Bus         bus.IBus
 }
 
 func (s *Service) CreateMessage(ctx context.Context, option CreateMessageOption) error {
@@ -102,6 +120,7 @@ func (s *Service) CreateMessage(ctx context.Context, option CreateMessageOption)
 			ToFromId: option.ToFromId,
 			QuoteId:  option.QuoteId,
 			Extra:    option.Extra,
+			IsUrgent: option.IsUrgent,
 		})
 	}
 
@@ -110,7 +129,10 @@ func (s *Service) CreateMessage(ctx context.Context, option CreateMessageOption)
 		FromId:   option.FromId,
 		ToFromId: option.ToFromId,
 		QuoteId:  option.QuoteId,
+		ThreadId: option.ThreadId,
 		Extra:    option.Extra,
+		Mentions: option.Mentions,
+		IsUrgent: option.IsUrgent,
 	})
 }
 
@@ -121,6 +143,9 @@ func (s *Service) CreateTextMessage(ctx context.Context, option CreateTextMessag
 		ToFromId: option.ToFromId,
 		MsgType:  entity.ChatMsgTypeText,
 		QuoteId:  option.QuoteId,
+		ThreadId: option.ThreadId,
+		Mentions: option.Mentions,
+		IsUrgent: option.IsUrgent,
 		Extra: jsonutil.Encode(model.TalkRecordExtraText{
 			Content:  option.Content,
 			Mentions: option.Mentions,
@@ -135,6 +160,7 @@ func (s *Service) CreateImageMessage(ctx context.Context, option CreateImageMess
 		ToFromId: option.ToFromId,
 		MsgType:  entity.ChatMsgTypeImage,
 		QuoteId:  option.QuoteId,
+		ThreadId: option.ThreadId,
 		Extra: jsonutil.Encode(model.TalkRecordExtraImage{
 			Size:   option.Size,
 			Url:    option.Url,
@@ -244,6 +270,7 @@ func (s *Service) CreateCodeMessage(ctx context.Context, option CreateCodeMessag
 		FromId:   option.FromId,
 		ToFromId: option.ToFromId,
 		MsgType:  entity.ChatMsgTypeCode,
+		ThreadId: option.ThreadId,
 		Extra: jsonutil.Encode(model.TalkRecordExtraCode{
 			Lang: option.Lang,
 			Code: option.Code,
@@ -298,6 +325,7 @@ func (s *Service) CreateForwardMessage(ctx context.Context, option CreateForward
 				UserId:       option.FromId,
 				ToUserId:     userId,
 				ToUserIdType: 1,
+				Records:      option.Records,
 			}
 
 			items = append(items, item)
@@ -320,6 +348,7 @@ func (s *Service) CreateForwardMessage(ctx context.Context, option CreateForward
 				UserId:       option.FromId,
 				ToUserId:     groupId,
 				ToUserIdType: 2,
+				Records:      option.Records,
 			}
 
 			items = append(items, item)
@@ -435,8 +464,12 @@ func (s *Service) CreateMixedMessage(ctx context.Context, option CreateMixedMess
 		FromId:   option.FromId,
 		ToFromId: option.ToFromId,
 		MsgType:  entity.ChatMsgTypeMixed,
+		ThreadId: option.ThreadId,
+		Mentions: option.Mentions,
+		IsUrgent: option.IsUrgent,
 		Extra: jsonutil.Encode(model.TalkRecordExtraMixed{
-			Items: items,
+			Items:    items,
+			Mentions: option.Mentions,
 		}),
 	})
 }
@@ -463,6 +496,7 @@ func (s *Service) CreateLoginMessage(ctx context.Context, option CreateLoginMess
 	})
 }
 
-func (s *Service) getTextMessage(msgType int, extra string) string {
+// GetTextMessage 获取消息摘要
+func (s *Service) GetTextMessage(msgType int, extra string) string {
 	return text(msgType, extra)
 }
@@ -0,0 +1,66 @@
+package message
+
+import (
+	"context"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/repository/model"
+)
+
+// UrgentNotifyInterval 紧急消息未确认时的重复提醒间隔
+const UrgentNotifyInterval = time.Minute
+
+// 私聊紧急消息，为接收者创建提醒记录
+func (s *Service) createPrivateUrgent(ctx context.Context, item *model.TalkUserMessage) {
+	s.createUrgent(ctx, []*model.TalkMessageUrgent{
+		{
+			TalkMode:     entity.ChatPrivateMode,
+			MsgId:        item.MsgId,
+			OrgMsgId:     item.OrgMsgId,
+			UserId:       item.UserId,
+			ToFromId:     item.ToFromId,
+			FromId:       item.FromId,
+			IsAcked:      model.No,
+			NextNotifyAt: item.SendTime.Add(UrgentNotifyInterval),
+			CreatedAt:    item.SendTime,
+		},
+	})
+}
+
+// 群聊紧急消息，为除发送者外的所有群成员创建提醒记录
+func (s *Service) createGroupUrgent(ctx context.Context, item *model.TalkGroupMessage) {
+	uids := s.GroupMemberRepo.GetMemberIds(ctx, item.GroupId)
+
+	items := make([]*model.TalkMessageUrgent, 0, len(uids))
+	for _, uid := range uids {
+		if uid == item.FromId {
+			continue
+		}
+
+		items = append(items, &model.TalkMessageUrgent{
+			TalkMode:     entity.ChatGroupMode,
+			MsgId:        item.MsgId,
+			OrgMsgId:     item.MsgId,
+			UserId:       uid,
+			ToFromId:     item.GroupId,
+			FromId:       item.FromId,
+			IsAcked:      model.No,
+			NextNotifyAt: item.SendTime.Add(UrgentNotifyInterval),
+			CreatedAt:    item.SendTime,
+		})
+	}
+
+	s.createUrgent(ctx, items)
+}
+
+func (s *Service) createUrgent(ctx context.Context, items []*model.TalkMessageUrgent) {
+	if len(items) == 0 {
+		return
+	}
+
+	if err := s.TalkUrgentRepo.BatchCreate(ctx, items); err != nil {
+		logger.Errorf("create urgent message error:%s", err.Error())
+	}
+}
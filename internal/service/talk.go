@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/samber/lo"
 	"go-chat/internal/business"
 	"go-chat/internal/entity"
 	"go-chat/internal/pkg/jsonutil"
 	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/unfurl"
 	"go-chat/internal/repository/cache"
 	"go-chat/internal/repository/model"
 	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ ITalkService = (*TalkService)(nil)
@@ -31,17 +35,51 @@ type TalkDeleteRecordOption struct {
 	MsgIds   []string
 }
 
+type TalkEditOption struct {
+	UserId   int
+	TalkMode int
+	MsgId    string
+	Content  string                            // 文本消息内容
+	Mentions []int                             // 文本消息@用户ID列表
+	Code     string                            // 代码消息内容
+	Lang     string                            // 代码消息语言
+	Items    []*model.TalkRecordExtraMixedItem // 图文消息内容
+}
+
+type TalkReactionOption struct {
+	UserId   int
+	TalkMode int
+	MsgId    string
+	Emoji    string
+}
+
 type ITalkService interface {
 	DeleteRecord(ctx context.Context, opt *TalkDeleteRecordOption) error
 	Revoke(ctx context.Context, opt *TalkRevokeOption) error
+	Edit(ctx context.Context, opt *TalkEditOption) error
+	AddReaction(ctx context.Context, opt *TalkReactionOption) error
+	RemoveReaction(ctx context.Context, opt *TalkReactionOption) error
+	ClearUnreadMessage(ctx context.Context, uid int, talkMode int, toFromId int) error
+	FindGroupReadUsers(ctx context.Context, uid int, msgId string) ([]*model.TalkReadUser, error)
+	MarkDelivered(ctx context.Context, uid int, orgMsgId string) error
 }
 
 type TalkService struct {
 	*repo.Source
-	GroupMemberRepo *repo.GroupMember
-	UserRepo        *repo.Users
-	PushMessage     *business.PushMessage
-	MessageStorage  *cache.MessageStorage
+	GroupMemberRepo      *repo.GroupMember
+	UserRepo             *repo.Users
+	TalkReadReceiptRepo  *repo.TalkReadReceipt
+	TalkRecordFriendRepo *repo.TalkUserMessage
+	TalkRecordGroupRepo  *repo.TalkGroupMessage
+	TalkSyncEventRepo    *repo.TalkSyncEvent
+	TalkMentionRepo      *repo.TalkMessageMention
+	TalkUrgentRepo       *repo.TalkMessageUrgent
+	TalkGroupThreadRepo  *repo.TalkGroupThread
+	TalkMessageFileRepo  *repo.TalkMessageFile
+	MessageService       message.IService
+	PushMessage          *business.PushMessage
+	MessageStorage       *cache.MessageStorage
+	UnreadStorage        *cache.UnreadStorage
 }
 
 // DeleteRecord 删除消息记录
@@ -50,9 +88,15 @@ func (t *TalkService) DeleteRecord(ctx context.Context, opt *TalkDeleteRecordOpt
 
 	// 私有消息直接更新删除状态
 	if opt.TalkMode == entity.ChatPrivateMode {
-		return db.Model(model.TalkUserMessage{}).
+		err := db.Model(model.TalkUserMessage{}).
 			Where("user_id = ? and msg_id in ?", opt.UserId, opt.MsgIds).
 			Update("is_deleted", model.Yes).Error
+		if err != nil {
+			return err
+		}
+
+		t.createUserSyncEvent(ctx, opt.UserId, model.SyncEventMessageDelete, opt.TalkMode, opt.ToFromId, opt.MsgIds)
+		return nil
 	}
 
 	if !t.GroupMemberRepo.IsMember(ctx, opt.ToFromId, opt.UserId, false) {
@@ -79,7 +123,12 @@ func (t *TalkService) DeleteRecord(ctx context.Context, opt *TalkDeleteRecordOpt
 	}
 
 	// 删除后清除最后一条记录
-	return db.Create(items).Error
+	if err := db.Create(items).Error; err != nil {
+		return err
+	}
+
+	t.createUserSyncEvent(ctx, opt.UserId, model.SyncEventMessageDelete, opt.TalkMode, opt.ToFromId, opt.MsgIds)
+	return nil
 }
 
 // Revoke 撤回消息
@@ -106,7 +155,7 @@ func (t *TalkService) Revoke(ctx context.Context, opt *TalkRevokeOption) (err er
 				})
 			}
 
-			e := t.PushMessage.Push(ctx, entity.ImTopicChat, &entity.SubscribeMessage{
+			e := t.PushMessage.PushTalk(ctx, opt.TalkMode, fromId, toFromId, &entity.SubscribeMessage{
 				Event: entity.SubEventImMessageRevoke,
 				Payload: jsonutil.Encode(entity.SubEventTalkRevokePayload{
 					TalkMode: opt.TalkMode,
@@ -116,7 +165,7 @@ func (t *TalkService) Revoke(ctx context.Context, opt *TalkRevokeOption) (err er
 			})
 
 			if e != nil {
-				logger.Errorf("revoke push message error:%s", err.Error())
+				logger.Errorf("revoke push message error:%s", e.Error())
 			}
 		}
 	}()
@@ -145,9 +194,19 @@ func (t *TalkService) Revoke(ctx context.Context, opt *TalkRevokeOption) (err er
 		fromId = record.FromId
 		toFromId = record.ToFromId
 
-		return db.Model(&model.TalkUserMessage{}).
+		err = db.Model(&model.TalkUserMessage{}).
 			Where("org_msg_id = ?", record.OrgMsgId).
 			Update("is_revoked", model.Yes).Error
+		if err != nil {
+			return err
+		}
+
+		if err := t.TalkUrgentRepo.DeleteByOrgMsgId(ctx, record.OrgMsgId); err != nil {
+			logger.Errorf("revoke delete urgent error:%s", err.Error())
+		}
+
+		t.createPrivateSyncEvent(ctx, model.SyncEventMessageRevoke, record.OrgMsgId)
+		return nil
 
 	case entity.ChatGroupMode:
 		var record model.TalkGroupMessage
@@ -172,10 +231,595 @@ func (t *TalkService) Revoke(ctx context.Context, opt *TalkRevokeOption) (err er
 		fromId = record.FromId
 		toFromId = record.GroupId
 
-		return db.Model(&model.TalkGroupMessage{}).
-			Where("msg_id = ?", record.MsgId).
-			Update("is_revoked", model.Yes).Error
+		// 以未撤回作为更新条件，避免并发撤回时重复扣减话题回复数
+		res := db.Model(&model.TalkGroupMessage{}).
+			Where("msg_id = ? and is_revoked = ?", record.MsgId, model.No).
+			Update("is_revoked", model.Yes)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return errors.New("消息已撤回")
+		}
+
+		if record.ThreadId != "" {
+			if err := t.TalkGroupThreadRepo.DecrReply(ctx, record.ThreadId); err != nil {
+				logger.Errorf("revoke decr thread reply error:%s", err.Error())
+			}
+		}
+
+		if err := t.TalkMentionRepo.DeleteByMsgIds(ctx, []string{record.MsgId}); err != nil {
+			logger.Errorf("revoke delete mentions error:%s", err.Error())
+		}
+
+		if err := t.TalkUrgentRepo.DeleteByOrgMsgId(ctx, record.MsgId); err != nil {
+			logger.Errorf("revoke delete urgent error:%s", err.Error())
+		}
+
+		t.createGroupSyncEvent(ctx, model.SyncEventMessageRevoke, record.GroupId, record.MsgId)
+		return nil
 	}
 
 	return errors.New("暂不支持撤回消息")
 }
+
+// Edit 编辑消息(仅支持文本、代码、图文消息)
+func (t *TalkService) Edit(ctx context.Context, opt *TalkEditOption) error {
+	db := t.Db().WithContext(ctx)
+
+	var (
+		msgType   int
+		extra     string
+		oldExtra  string
+		previewId string
+		fromId    int
+		toFromId  int
+		msgIds    []string
+		private   *model.TalkUserMessage
+		group     *model.TalkGroupMessage
+		update    func(tx *gorm.DB) error
+		sync      func()
+	)
+
+	switch opt.TalkMode {
+	case entity.ChatPrivateMode:
+		var record model.TalkUserMessage
+
+		err := db.First(&record, "msg_id = ? and from_id = ?", opt.MsgId, opt.UserId).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("消息ID不存在")
+			}
+
+			return err
+		}
+
+		if record.IsRevoked == model.Yes {
+			return errors.New("消息已撤回")
+		}
+
+		// 私信消息发送者和接收者各存一份，需同步更新
+		err = db.Model(&model.TalkUserMessage{}).Where("org_msg_id = ?", record.OrgMsgId).Pluck("msg_id", &msgIds).Error
+		if err != nil {
+			return err
+		}
+
+		private = &record
+		msgType, oldExtra, previewId = record.MsgType, record.Extra, record.OrgMsgId
+		fromId, toFromId = record.UserId, record.ToFromId
+		update = func(tx *gorm.DB) error {
+			return tx.Model(&model.TalkUserMessage{}).
+				Where("org_msg_id = ?", record.OrgMsgId).
+				Update("extra", extra).Error
+		}
+		sync = func() {
+			t.createPrivateSyncEvent(ctx, model.SyncEventMessageEdit, record.OrgMsgId)
+		}
+
+	case entity.ChatGroupMode:
+		var record model.TalkGroupMessage
+
+		err := db.First(&record, "msg_id = ? and from_id = ?", opt.MsgId, opt.UserId).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("消息ID不存在")
+			}
+
+			return err
+		}
+
+		if record.IsRevoked == model.Yes {
+			return errors.New("消息已撤回")
+		}
+
+		if !t.GroupMemberRepo.IsMember(ctx, record.GroupId, opt.UserId, false) {
+			return entity.ErrPermissionDenied
+		}
+
+		if lo.Contains(opt.Mentions, model.MentionAllUserId) && !t.GroupMemberRepo.IsLeader(ctx, record.GroupId, opt.UserId) {
+			return errors.New("仅群主或管理员可以@所有人")
+		}
+
+		msgIds, group = []string{record.MsgId}, &record
+		msgType, oldExtra, previewId = record.MsgType, record.Extra, record.MsgId
+		fromId, toFromId = record.FromId, record.GroupId
+		update = func(tx *gorm.DB) error {
+			return tx.Model(&model.TalkGroupMessage{}).
+				Where("msg_id = ?", record.MsgId).
+				Update("extra", extra).Error
+		}
+		sync = func() {
+			t.createGroupSyncEvent(ctx, model.SyncEventMessageEdit, record.GroupId, record.MsgId)
+		}
+
+	default:
+		return errors.New("暂不支持编辑消息")
+	}
+
+	switch msgType {
+	case entity.ChatMsgTypeText:
+		if opt.Content == "" {
+			return errors.New("消息内容不能为空")
+		}
+
+		text := model.TalkRecordExtraText{
+			Content:  opt.Content,
+			Mentions: opt.Mentions,
+		}
+
+		// 链接未变化时保留已生成的预览，否则重新生成
+		var old model.TalkRecordExtraText
+		if err := jsonutil.Decode(oldExtra, &old); err == nil && old.Preview != nil && old.Preview.Url == unfurl.FindUrl(opt.Content) {
+			text.Preview = old.Preview
+		}
+
+		extra = jsonutil.Encode(text)
+	case entity.ChatMsgTypeCode:
+		if opt.Code == "" || opt.Lang == "" {
+			return errors.New("代码内容不能为空")
+		}
+
+		extra = jsonutil.Encode(model.TalkRecordExtraCode{
+			Lang: opt.Lang,
+			Code: opt.Code,
+		})
+	case entity.ChatMsgTypeMixed:
+		if len(opt.Items) == 0 {
+			return errors.New("图文内容不能为空")
+		}
+
+		extra = jsonutil.Encode(model.TalkRecordExtraMixed{
+			Items:    opt.Items,
+			Mentions: opt.Mentions,
+		})
+	default:
+		return errors.New("该类型消息不支持编辑")
+	}
+
+	if extra == oldExtra {
+		return nil
+	}
+
+	items := make([]*model.TalkMessageEdit, 0, len(msgIds))
+	for _, msgId := range msgIds {
+		items = append(items, &model.TalkMessageEdit{
+			TalkMode:  opt.TalkMode,
+			MsgId:     msgId,
+			UserId:    opt.UserId,
+			MsgType:   msgType,
+			Extra:     oldExtra,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	source := lo.Ternary(opt.TalkMode == entity.ChatGroupMode, model.TalkMessageFileSourceGroup, model.TalkMessageFileSourcePrivate)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := update(tx); err != nil {
+			return err
+		}
+
+		// 图文消息编辑后可能引用新的图片，需补充附件引用记录
+		for _, msgId := range msgIds {
+			if err := t.TalkMessageFileRepo.Attach(tx, source, msgId, msgType, extra); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(items).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	sync()
+
+	// 编辑的消息为会话最新消息时同步更新会话列表展示的内容
+	if private != nil {
+		private.Extra = extra
+		err = t.MessageService.UpdatePrivateLastMessage(ctx, private)
+	} else {
+		group.Extra = extra
+		err = t.MessageService.UpdateGroupLastMessage(ctx, group)
+	}
+
+	if err != nil {
+		logger.Errorf("edit update last message error:%s", err.Error())
+	}
+
+	// 群聊文本及图文消息的@用户可能变化，需同步更新提及索引
+	if group != nil && msgType != entity.ChatMsgTypeCode {
+		if err := t.MessageService.UpdateGroupMentions(ctx, group, opt.Mentions); err != nil {
+			logger.Errorf("edit update mentions error:%s", err.Error())
+		}
+	}
+
+	err = t.PushMessage.PushTalk(ctx, opt.TalkMode, fromId, toFromId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessageEdit,
+		Payload: jsonutil.Encode(entity.SubEventTalkEditPayload{
+			TalkMode: opt.TalkMode,
+			MsgId:    opt.MsgId,
+		}),
+	})
+	if err != nil {
+		logger.Errorf("edit push message error:%s", err.Error())
+	}
+
+	t.MessageService.PublishLinkPreview(ctx, opt.TalkMode, previewId, msgType, extra)
+
+	return nil
+}
+
+// 记录用户多端同步事件
+func (t *TalkService) createUserSyncEvent(ctx context.Context, uid int, event string, talkMode int, toFromId int, msgIds []string) {
+	err := t.TalkSyncEventRepo.CreateUserEvent(ctx, uid, &model.TalkSyncEvent{
+		Event:    event,
+		TalkMode: talkMode,
+		ToFromId: toFromId,
+		Payload:  jsonutil.Encode(model.TalkSyncEventPayload{MsgIds: msgIds}),
+	})
+
+	if err != nil {
+		logger.Errorf("create user sync event error:%s", err.Error())
+	}
+}
+
+// 记录私聊消息的多端同步事件(私聊消息双方各存一份，需分别记录)
+func (t *TalkService) createPrivateSyncEvent(ctx context.Context, event string, orgMsgId string) {
+	records, err := t.TalkRecordFriendRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("org_msg_id = ?", orgMsgId)
+	})
+	if err != nil {
+		logger.Errorf("create private sync event error:%s", err.Error())
+		return
+	}
+
+	for _, record := range records {
+		t.createUserSyncEvent(ctx, record.UserId, event, entity.ChatPrivateMode, record.ToFromId, []string{record.MsgId})
+	}
+}
+
+// 记录群聊消息的多端同步事件
+func (t *TalkService) createGroupSyncEvent(ctx context.Context, event string, groupId int, msgId string) {
+	err := t.TalkSyncEventRepo.CreateGroupEvent(ctx, groupId, &model.TalkSyncEvent{
+		Event:    event,
+		TalkMode: entity.ChatGroupMode,
+		ToFromId: groupId,
+		Payload:  jsonutil.Encode(model.TalkSyncEventPayload{MsgIds: []string{msgId}}),
+	})
+
+	if err != nil {
+		logger.Errorf("create group sync event error:%s", err.Error())
+	}
+}
+
+// AddReaction 添加消息表态
+func (t *TalkService) AddReaction(ctx context.Context, opt *TalkReactionOption) error {
+	msgIds, toFromId, err := t.findReactionMsgIds(ctx, opt)
+	if err != nil {
+		return err
+	}
+
+	items := make([]*model.TalkMessageReaction, 0, len(msgIds))
+	for _, msgId := range msgIds {
+		items = append(items, &model.TalkMessageReaction{
+			TalkMode:  opt.TalkMode,
+			MsgId:     msgId,
+			UserId:    opt.UserId,
+			Emoji:     opt.Emoji,
+			CreatedAt: time.Now(),
+		})
+	}
+
+	// 依赖唯一索引去重，并发重复表态时仅首次写入成功的请求推送
+	res := t.Db().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(items)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		t.pushReaction(ctx, opt, toFromId, 1)
+	}
+
+	return nil
+}
+
+// RemoveReaction 取消消息表态
+func (t *TalkService) RemoveReaction(ctx context.Context, opt *TalkReactionOption) error {
+	msgIds, toFromId, err := t.findReactionMsgIds(ctx, opt)
+	if err != nil {
+		return err
+	}
+
+	res := t.Db().WithContext(ctx).
+		Where("msg_id in ? and user_id = ? and emoji = ?", msgIds, opt.UserId, opt.Emoji).
+		Delete(&model.TalkMessageReaction{})
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		t.pushReaction(ctx, opt, toFromId, 2)
+	}
+
+	return nil
+}
+
+// 获取表态需要同步的消息ID(私信消息发送者和接收者各存一份)及会话对象ID
+func (t *TalkService) findReactionMsgIds(ctx context.Context, opt *TalkReactionOption) ([]string, int, error) {
+	db := t.Db().WithContext(ctx)
+
+	switch opt.TalkMode {
+	case entity.ChatPrivateMode:
+		var record model.TalkUserMessage
+
+		err := db.First(&record, "msg_id = ? and user_id = ?", opt.MsgId, opt.UserId).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, 0, errors.New("消息ID不存在")
+			}
+
+			return nil, 0, err
+		}
+
+		if record.IsRevoked == model.Yes {
+			return nil, 0, errors.New("消息已撤回")
+		}
+
+		var msgIds []string
+		err = db.Model(&model.TalkUserMessage{}).Where("org_msg_id = ?", record.OrgMsgId).Pluck("msg_id", &msgIds).Error
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return msgIds, record.ToFromId, nil
+
+	case entity.ChatGroupMode:
+		var record model.TalkGroupMessage
+
+		err := db.First(&record, "msg_id = ?", opt.MsgId).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, 0, errors.New("消息ID不存在")
+			}
+
+			return nil, 0, err
+		}
+
+		if record.IsRevoked == model.Yes {
+			return nil, 0, errors.New("消息已撤回")
+		}
+
+		if !t.GroupMemberRepo.IsMember(ctx, record.GroupId, opt.UserId, false) {
+			return nil, 0, entity.ErrPermissionDenied
+		}
+
+		return []string{record.MsgId}, record.GroupId, nil
+	}
+
+	return nil, 0, errors.New("暂不支持消息表态")
+}
+
+func (t *TalkService) pushReaction(ctx context.Context, opt *TalkReactionOption, toFromId int, action int) {
+	err := t.PushMessage.PushTalk(ctx, opt.TalkMode, opt.UserId, toFromId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessageReaction,
+		Payload: jsonutil.Encode(entity.SubEventTalkReactionPayload{
+			TalkMode: opt.TalkMode,
+			MsgId:    opt.MsgId,
+			UserId:   opt.UserId,
+			Emoji:    opt.Emoji,
+			Action:   action,
+		}),
+	})
+
+	if err != nil {
+		logger.Errorf("reaction push message error:%s", err.Error())
+	}
+}
+
+// ClearUnreadMessage 清除会话未读数并更新已读回执
+func (t *TalkService) ClearUnreadMessage(ctx context.Context, uid int, talkMode int, toFromId int) error {
+	if talkMode == entity.ChatGroupMode && !t.GroupMemberRepo.IsMember(ctx, toFromId, uid, true) {
+		return entity.ErrPermissionDenied
+	}
+
+	t.UnreadStorage.Reset(ctx, uid, talkMode, toFromId)
+
+	if talkMode == entity.ChatGroupMode {
+		if err := t.TalkMentionRepo.MarkRead(ctx, uid, toFromId); err != nil {
+			logger.Errorf("clear unread mark mentions read error:%s", err.Error())
+		}
+	}
+
+	var (
+		sequence int64
+		err      error
+	)
+
+	if talkMode == entity.ChatPrivateMode {
+		sequence, err = t.TalkRecordFriendRepo.FindLastSequence(ctx, uid, toFromId)
+	} else {
+		sequence, err = t.TalkRecordGroupRepo.FindLastSequence(ctx, toFromId)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	lastSequence, err := t.TalkReadReceiptRepo.FindSequence(ctx, uid, talkMode, toFromId)
+	if err != nil {
+		return err
+	}
+
+	// 没有新的已读消息
+	if sequence <= lastSequence {
+		return nil
+	}
+
+	if err := t.TalkReadReceiptRepo.Upsert(ctx, uid, talkMode, toFromId, sequence); err != nil {
+		return err
+	}
+
+	payload := entity.SubEventTalkReadPayload{
+		TalkMode: talkMode,
+		UserId:   uid,
+		ToFromId: toFromId,
+		Sequence: sequence,
+		ReadTime: time.Now().Format(time.DateTime),
+	}
+
+	if talkMode == entity.ChatPrivateMode {
+		// 私聊消息双方各存一份，需要转换为发送者信箱中的消息
+		record := &model.TalkUserMessage{}
+		err := t.Source.Db().WithContext(ctx).
+			Where("user_id = ? and to_from_id = ? and from_id = ? and sequence > ? and sequence <= ?", uid, toFromId, toFromId, lastSequence, sequence).
+			Order("sequence desc").First(record).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		senderRecord, err := t.TalkRecordFriendRepo.FindByWhere(ctx, "user_id = ? and org_msg_id = ?", toFromId, record.OrgMsgId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+
+			return err
+		}
+
+		payload.MsgId = senderRecord.MsgId
+		payload.Sequence = senderRecord.Sequence
+
+		if err := t.TalkRecordFriendRepo.UpdateRead(ctx, toFromId, uid, senderRecord.Sequence); err != nil {
+			logger.Errorf("read receipt update delivery status error:%s", err.Error())
+		}
+	} else {
+		// 仅通知成员入群后的消息发送者，避免首次已读时回溯群聊全部历史
+		member, err := t.GroupMemberRepo.FindByUserId(ctx, toFromId, uid)
+		if err != nil {
+			return err
+		}
+
+		joinSequence, err := t.TalkRecordGroupRepo.FindSequenceBefore(ctx, toFromId, member.JoinTime)
+		if err != nil {
+			return err
+		}
+
+		senderIds, err := t.TalkRecordGroupRepo.FindSenderIds(ctx, toFromId, max(lastSequence, joinSequence), sequence)
+		if err != nil {
+			return err
+		}
+
+		payload.SenderIds = make([]int, 0, len(senderIds))
+		for _, senderId := range senderIds {
+			if senderId != uid {
+				payload.SenderIds = append(payload.SenderIds, senderId)
+			}
+		}
+
+		if len(payload.SenderIds) == 0 {
+			return nil
+		}
+	}
+
+	uids := payload.SenderIds
+	if talkMode == entity.ChatPrivateMode {
+		uids = []int{toFromId}
+	}
+
+	err = t.PushMessage.PushUsers(ctx, uids, &entity.SubscribeMessage{
+		Event:   entity.SubEventImMessageRead,
+		Payload: jsonutil.Encode(payload),
+	})
+
+	if err != nil {
+		logger.Errorf("read receipt push message error:%s", err.Error())
+	}
+
+	return nil
+}
+
+// MarkDelivered 接收者客户端确认收到私聊消息后，标记为已送达并通知发送者
+func (t *TalkService) MarkDelivered(ctx context.Context, uid int, orgMsgId string) error {
+	record, err := t.TalkRecordFriendRepo.FindByWhere(ctx, "org_msg_id = ? and user_id = from_id", orgMsgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if record.ToFromId != uid || record.DeliveryStatus >= model.MessageDeliveryDelivered {
+		return nil
+	}
+
+	rows, err := t.TalkRecordFriendRepo.UpdateDelivered(ctx, orgMsgId)
+	if err != nil {
+		return err
+	}
+
+	// 接收者多端在线时会多次确认，仅首次确认通知发送者
+	if rows == 0 {
+		return nil
+	}
+
+	err = t.PushMessage.PushUsers(ctx, []int{record.UserId}, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessageDelivered,
+		Payload: jsonutil.Encode(entity.SubEventTalkDeliveredPayload{
+			UserId:      record.UserId,
+			ToFromId:    record.ToFromId,
+			MsgId:       record.MsgId,
+			DeliveredAt: time.Now().Format(time.DateTime),
+		}),
+	})
+
+	if err != nil {
+		logger.Errorf("delivered push message error:%s", err.Error())
+	}
+
+	return nil
+}
+
+// FindGroupReadUsers 获取已读群消息的用户列表
+func (t *TalkService) FindGroupReadUsers(ctx context.Context, uid int, msgId string) ([]*model.TalkReadUser, error) {
+	record, err := t.TalkRecordGroupRepo.FindByMsgId(ctx, msgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("消息ID不存在")
+		}
+
+		return nil, err
+	}
+
+	if !t.GroupMemberRepo.IsMember(ctx, record.GroupId, uid, false) {
+		return nil, entity.ErrPermissionDenied
+	}
+
+	return t.TalkReadReceiptRepo.FindGroupReadUsers(ctx, record.GroupId, record.Sequence, record.FromId)
+}
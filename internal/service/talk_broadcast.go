@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/sliceutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+const (
+	talkBroadcastMaxLists   = 50  // 每个用户可创建的群发列表数
+	talkBroadcastMaxMembers = 200 // 单个群发列表的最大接收者数
+)
+
+var _ ITalkBroadcastService = (*TalkBroadcastService)(nil)
+
+type TalkBroadcastSendOption struct {
+	UserId      int
+	BroadcastId int
+	MsgType     int
+	Extra       string
+}
+
+type ITalkBroadcastService interface {
+	Create(ctx context.Context, uid int, name string, userIds []int) (*model.TalkBroadcast, error)
+	Update(ctx context.Context, uid int, id int, name string, userIds []int) error
+	Delete(ctx context.Context, uid int, id int) error
+	List(ctx context.Context, uid int) ([]*model.TalkBroadcast, error)
+	Send(ctx context.Context, opt *TalkBroadcastSendOption) (*model.TalkBroadcastLog, error)
+	Logs(ctx context.Context, uid int, id int) ([]*model.TalkBroadcastLog, error)
+}
+
+type TalkBroadcastService struct {
+	*repo.Source
+	TalkBroadcastRepo    *repo.TalkBroadcast
+	TalkBroadcastLogRepo *repo.TalkBroadcastLog
+	Sequence             *repo.Sequence
+	AuthService          IAuthService
+	MessageService       message.IService
+}
+
+// Create 创建群发列表
+func (t *TalkBroadcastService) Create(ctx context.Context, uid int, name string, userIds []int) (*model.TalkBroadcast, error) {
+	userIds, err := normalizeBroadcastMembers(uid, userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := t.TalkBroadcastRepo.FindCount(ctx, "user_id = ?", uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if count >= talkBroadcastMaxLists {
+		return nil, fmt.Errorf("群发列表数量不能超过%d个", talkBroadcastMaxLists)
+	}
+
+	data := &model.TalkBroadcast{
+		UserId:  uid,
+		Name:    name,
+		UserIds: sliceutil.ToIds(userIds),
+	}
+
+	if err := t.TalkBroadcastRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Update 修改群发列表
+func (t *TalkBroadcastService) Update(ctx context.Context, uid int, id int, name string, userIds []int) error {
+	userIds, err := normalizeBroadcastMembers(uid, userIds)
+	if err != nil {
+		return err
+	}
+
+	if _, err := t.find(ctx, uid, id); err != nil {
+		return err
+	}
+
+	_, err = t.TalkBroadcastRepo.UpdateByWhere(ctx, map[string]any{
+		"name":     name,
+		"user_ids": sliceutil.ToIds(userIds),
+	}, "id = ? and user_id = ?", id, uid)
+
+	return err
+}
+
+// Delete 删除群发列表
+func (t *TalkBroadcastService) Delete(ctx context.Context, uid int, id int) error {
+	res := t.Source.Db().WithContext(ctx).Delete(&model.TalkBroadcast{}, "id = ? and user_id = ?", id, uid)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return errors.New("群发列表不存在")
+	}
+
+	return nil
+}
+
+// List 群发列表
+func (t *TalkBroadcastService) List(ctx context.Context, uid int) ([]*model.TalkBroadcast, error) {
+	return t.TalkBroadcastRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ?", uid).Order("id desc")
+	})
+}
+
+// Send 向群发列表中的接收者逐个发送私聊消息，并记录发送结果
+func (t *TalkBroadcastService) Send(ctx context.Context, opt *TalkBroadcastSendOption) (*model.TalkBroadcastLog, error) {
+	broadcast, err := t.find(ctx, opt.UserId, opt.BroadcastId)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds := sliceutil.ParseIds(broadcast.UserIds)
+	if len(userIds) == 0 {
+		return nil, errors.New("群发列表没有接收者")
+	}
+
+	var (
+		reached = make([]int, 0, len(userIds))
+		failed  = make([]int, 0)
+		allowed = make([]int, 0, len(userIds))
+	)
+
+	for _, uid := range userIds {
+		if err := t.AuthService.IsAuth(ctx, &AuthOption{TalkType: entity.ChatPrivateMode, UserId: opt.UserId, ToFromId: uid}); err != nil {
+			failed = append(failed, uid)
+			continue
+		}
+
+		allowed = append(allowed, uid)
+	}
+
+	// 发送者信箱的时序ID一次性分配，避免逐条自增
+	sequences := make([]int64, 0, len(allowed))
+	if len(allowed) > 0 {
+		sequences = t.Sequence.BatchGet(ctx, opt.UserId, true, int64(len(allowed)))
+	}
+
+	for i, uid := range allowed {
+		err := t.MessageService.CreatePrivateMessage(ctx, message.CreatePrivateMessageOption{
+			MsgType:      opt.MsgType,
+			FromId:       opt.UserId,
+			ToFromId:     uid,
+			Extra:        opt.Extra,
+			FromSequence: sequences[i],
+		})
+
+		if err != nil {
+			logger.Errorf("broadcast %d send to %d error: %s", broadcast.Id, uid, err.Error())
+			failed = append(failed, uid)
+			continue
+		}
+
+		reached = append(reached, uid)
+	}
+
+	data := &model.TalkBroadcastLog{
+		UserId:      opt.UserId,
+		BroadcastId: broadcast.Id,
+		MsgType:     opt.MsgType,
+		Extra:       opt.Extra,
+		Total:       len(userIds),
+		ReachedIds:  sliceutil.ToIds(reached),
+		FailedIds:   sliceutil.ToIds(failed),
+	}
+
+	if err := t.TalkBroadcastLogRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Logs 群发记录
+func (t *TalkBroadcastService) Logs(ctx context.Context, uid int, id int) ([]*model.TalkBroadcastLog, error) {
+	return t.TalkBroadcastLogRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ? and broadcast_id = ?", uid, id).Order("id desc").Limit(50)
+	})
+}
+
+func (t *TalkBroadcastService) find(ctx context.Context, uid int, id int) (*model.TalkBroadcast, error) {
+	data, err := t.TalkBroadcastRepo.FindByWhere(ctx, "id = ? and user_id = ?", id, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("群发列表不存在")
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// 接收者去重并排除自己
+func normalizeBroadcastMembers(uid int, userIds []int) ([]int, error) {
+	userIds = slices.DeleteFunc(sliceutil.Unique(userIds), func(id int) bool {
+		return id <= 0 || id == uid
+	})
+
+	if len(userIds) == 0 {
+		return nil, errors.New("接收者不能为空")
+	}
+
+	if len(userIds) > talkBroadcastMaxMembers {
+		return nil, fmt.Errorf("接收者不能超过%d人", talkBroadcastMaxMembers)
+	}
+
+	return userIds, nil
+}
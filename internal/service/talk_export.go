@@ -0,0 +1,432 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/core/bus"
+	"go-chat/internal/pkg/filesystem"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/strutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"gorm.io/gorm"
+)
+
+const (
+	talkExportMaxDays      = 366            // 单次导出的最大时间跨度(天)
+	talkExportMaxRunning   = 3              // 每个用户同时进行中的导出任务数
+	talkExportMaxMessages  = 100000         // 单次导出的最大消息数
+	talkExportMaxFileSize  = 512 << 20      // 单次打包的附件总大小
+	talkExportPageSize     = 500            // 分页读取消息的数据行数
+	talkExportReasonLength = 255            // 失败原因的最大长度(与 talk_export.reason 字段长度一致)
+	talkExportTimeout      = time.Hour      // 处理中的任务超过该时长未完成视为异常中断(如进程崩溃)
+	talkExportRetryWindow  = 24 * time.Hour // 创建超过该时长的中断任务不再重试
+)
+
+var _ ITalkExportService = (*TalkExportService)(nil)
+
+type TalkExportCreateOption struct {
+	UserId    int
+	TalkMode  int
+	ToFromId  int
+	Format    string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+type ITalkExportService interface {
+	Create(ctx context.Context, opt *TalkExportCreateOption) (*model.TalkExport, error)
+	List(ctx context.Context, uid int) ([]*model.TalkExport, error)
+	FindCompleted(ctx context.Context, uid int, id int) (*model.TalkExport, error)
+	Process(ctx context.Context, id int) error
+	Recover(ctx context.Context) error
+}
+
+type TalkExportService struct {
+	*repo.Source
+	TalkExportRepo    *repo.TalkExport
+	GroupRepo         *repo.Group
+	GroupMemberRepo   *repo.GroupMember
+	UsersRepo         *repo.Users
+	TalkRecordService ITalkRecordService
+	Filesystem        filesystem.IFilesystem
+	Bus               bus.IBus
+}
+
+// Create 创建导出任务，任务由队列异步处理
+func (t *TalkExportService) Create(ctx context.Context, opt *TalkExportCreateOption) (*model.TalkExport, error) {
+	if !opt.EndTime.After(opt.StartTime) {
+		return nil, errors.New("结束时间必须大于开始时间")
+	}
+
+	if opt.EndTime.Sub(opt.StartTime) > talkExportMaxDays*24*time.Hour {
+		return nil, fmt.Errorf("单次导出时间跨度不能超过%d天", talkExportMaxDays)
+	}
+
+	if opt.TalkMode == entity.ChatGroupMode && !t.GroupMemberRepo.IsMember(ctx, opt.ToFromId, opt.UserId, false) {
+		return nil, errors.New("暂无权限导出该群聊记录")
+	}
+
+	running, err := t.TalkExportRepo.FindCount(ctx, "user_id = ? and status in ?", opt.UserId, []int{model.TalkExportStatusWait, model.TalkExportStatusProcessing})
+	if err != nil {
+		return nil, err
+	}
+
+	if running >= talkExportMaxRunning {
+		return nil, errors.New("导出任务过多，请等待当前任务完成后再试")
+	}
+
+	data := &model.TalkExport{
+		UserId:    opt.UserId,
+		TalkMode:  opt.TalkMode,
+		ToFromId:  opt.ToFromId,
+		Format:    opt.Format,
+		StartTime: opt.StartTime,
+		EndTime:   opt.EndTime,
+		Status:    model.TalkExportStatusWait,
+	}
+
+	if err := t.TalkExportRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	err = t.Bus.Publish(ctx, entity.TalkExportTopic, jsonutil.Marshal(entity.TalkExportPayload{
+		ExportId: data.Id,
+	}))
+	if err != nil {
+		_, _ = t.TalkExportRepo.UpdateStatus(ctx, data.Id, model.TalkExportStatusWait, map[string]any{
+			"status": model.TalkExportStatusFail,
+			"reason": "任务投递失败",
+		})
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// List 导出任务列表
+func (t *TalkExportService) List(ctx context.Context, uid int) ([]*model.TalkExport, error) {
+	return t.TalkExportRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ?", uid).Order("id desc").Limit(50)
+	})
+}
+
+// FindCompleted 获取已完成的导出任务
+func (t *TalkExportService) FindCompleted(ctx context.Context, uid int, id int) (*model.TalkExport, error) {
+	item, err := t.TalkExportRepo.FindByWhere(ctx, "id = ? and user_id = ?", id, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("导出任务不存在")
+		}
+
+		return nil, err
+	}
+
+	if item.Status != model.TalkExportStatusSuccess {
+		return nil, errors.New("导出任务尚未完成")
+	}
+
+	return item, nil
+}
+
+// Process 执行导出任务，生成压缩包并写入私有桶
+func (t *TalkExportService) Process(ctx context.Context, id int) error {
+	// 抢占任务，防止多个节点重复处理
+	ok, err := t.TalkExportRepo.UpdateStatus(ctx, id, model.TalkExportStatusWait, map[string]any{
+		"status": model.TalkExportStatusProcessing,
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	item, err := t.TalkExportRepo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	data, err := t.export(ctx, item)
+	if err != nil {
+		logger.Errorf("talk export %d error: %s", id, err.Error())
+		data = map[string]any{"status": model.TalkExportStatusFail, "reason": strutil.MtSubstr(err.Error(), 0, talkExportReasonLength)}
+	}
+
+	_, err = t.TalkExportRepo.UpdateStatus(ctx, id, model.TalkExportStatusProcessing, data)
+	return err
+}
+
+// Recover 恢复处理超时的任务(处理节点异常退出后任务会一直停留在处理中)，重新投递或标记为失败
+func (t *TalkExportService) Recover(ctx context.Context) error {
+	items, err := t.TalkExportRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("status = ? and updated_at < ?", model.TalkExportStatusProcessing, time.Now().Add(-talkExportTimeout)).Limit(100)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if time.Since(item.CreatedAt) > talkExportRetryWindow {
+			_, _ = t.TalkExportRepo.UpdateStatus(ctx, item.Id, model.TalkExportStatusProcessing, map[string]any{
+				"status": model.TalkExportStatusFail,
+				"reason": "任务处理超时",
+			})
+			continue
+		}
+
+		ok, err := t.TalkExportRepo.UpdateStatus(ctx, item.Id, model.TalkExportStatusProcessing, map[string]any{
+			"status": model.TalkExportStatusWait,
+		})
+		if err != nil || !ok {
+			continue
+		}
+
+		err = t.Bus.Publish(ctx, entity.TalkExportTopic, jsonutil.Marshal(entity.TalkExportPayload{
+			ExportId: item.Id,
+		}))
+		if err != nil {
+			logger.Errorf("talk export %d republish error: %s", item.Id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (t *TalkExportService) export(ctx context.Context, item *model.TalkExport) (map[string]any, error) {
+	if item.TalkMode == entity.ChatGroupMode && !t.GroupMemberRepo.IsMember(ctx, item.ToFromId, item.UserId, false) {
+		return nil, errors.New("已不是群成员，无法导出")
+	}
+
+	records, err := t.findRecords(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &talkExportDocument{
+		TalkMode:   item.TalkMode,
+		ToFromId:   item.ToFromId,
+		Name:       t.findTalkName(ctx, item.TalkMode, item.ToFromId),
+		StartTime:  item.StartTime.Format(time.DateTime),
+		EndTime:    item.EndTime.Format(time.DateTime),
+		ExportedAt: time.Now().Format(time.DateTime),
+		Messages:   make([]*talkExportMessage, 0, len(records)),
+	}
+
+	// 压缩包写入临时文件，避免大量附件占用内存
+	file, err := os.CreateTemp("", "talk-export-*.zip")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}()
+
+	var (
+		writer   = zip.NewWriter(file)
+		fileNum  = 0
+		fileSize = 0
+	)
+
+	for _, record := range records {
+		message := newTalkExportMessage(record)
+
+		if attachment := t.attachment(record); attachment != nil && fileSize+attachment.Size <= talkExportMaxFileSize {
+			name, size, err := t.bundle(writer, record.MsgId, attachment)
+			if err != nil {
+				logger.Warnf("talk export %d bundle %s error: %s", item.Id, attachment.Object, err.Error())
+			} else {
+				message.Attachment = name
+				fileNum++
+				fileSize += size
+			}
+		}
+
+		doc.Messages = append(doc.Messages, message)
+	}
+
+	content, err := renderTalkExport(item.Format, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := writer.Create("messages." + item.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	object := fmt.Sprintf("talk-export/%s/%s.zip", time.Now().Format("200601"), uuid.New().String())
+	if err := t.Filesystem.WriteStream(t.Filesystem.BucketPrivateName(), object, file, size); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"status":      model.TalkExportStatusSuccess,
+		"message_num": len(doc.Messages),
+		"file_num":    fileNum,
+		"path":        object,
+		"size":        size,
+	}, nil
+}
+
+// 分页读取时间范围内的消息(按发送顺序正序)
+func (t *TalkExportService) findRecords(ctx context.Context, item *model.TalkExport) ([]*model.TalkMessageRecord, error) {
+	var (
+		items  = make([]*model.TalkMessageRecord, 0)
+		cursor = 0
+	)
+
+	for {
+		list, err := t.TalkRecordService.FindAllTalkRecords(ctx, &FindAllTalkRecordsOpt{
+			TalkType:   item.TalkMode,
+			UserId:     item.UserId,
+			ReceiverId: item.ToFromId,
+			Cursor:     cursor,
+			Limit:      talkExportPageSize,
+			StartTime:  item.StartTime,
+			EndTime:    item.EndTime,
+			WithThread: true, // 导出完整记录，话题回复按时序穿插并标注所属话题
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, list...)
+		if len(items) > talkExportMaxMessages {
+			return nil, fmt.Errorf("消息数超过单次导出上限%d条，请缩小时间范围", talkExportMaxMessages)
+		}
+
+		if len(list) < talkExportPageSize {
+			break
+		}
+
+		cursor = list[len(list)-1].Sequence
+	}
+
+	slices.Reverse(items)
+
+	return items, nil
+}
+
+func (t *TalkExportService) findTalkName(ctx context.Context, talkMode int, toFromId int) string {
+	if talkMode == entity.ChatGroupMode {
+		if group, err := t.GroupRepo.FindById(ctx, toFromId); err == nil {
+			return group.Name
+		}
+
+		return ""
+	}
+
+	if user, err := t.UsersRepo.FindById(ctx, toFromId); err == nil {
+		return user.Nickname
+	}
+
+	return ""
+}
+
+type talkExportFile struct {
+	Bucket string
+	Object string
+	Name   string
+	Size   int
+}
+
+// 解析消息附件的存储位置，非附件消息或已撤回消息返回 nil
+func (t *TalkExportService) attachment(record *model.TalkMessageRecord) *talkExportFile {
+	if record.IsRevoked == model.Yes {
+		return nil
+	}
+
+	var value struct {
+		Name string `json:"name"`
+		Size int    `json:"size"`
+		Url  string `json:"url"`
+		Path string `json:"path"`
+	}
+
+	switch record.MsgType {
+	case entity.ChatMsgTypeFile:
+		if err := jsonutil.Decode(record.Extra, &value); err != nil || value.Path == "" {
+			return nil
+		}
+
+		return &talkExportFile{Bucket: t.Filesystem.BucketPrivateName(), Object: value.Path, Name: value.Name, Size: value.Size}
+	case entity.ChatMsgTypeImage, entity.ChatMsgTypeAudio, entity.ChatMsgTypeVideo:
+		if err := jsonutil.Decode(record.Extra, &value); err != nil || value.Url == "" {
+			return nil
+		}
+
+		uri, err := url.Parse(value.Url)
+		if err != nil {
+			return nil
+		}
+
+		// 仅打包存储在公开桶中的文件，外部链接不做处理
+		_, object, ok := strings.Cut(uri.Path, "/"+t.Filesystem.BucketPublicName()+"/")
+		if !ok {
+			return nil
+		}
+
+		return &talkExportFile{Bucket: t.Filesystem.BucketPublicName(), Object: object, Name: value.Name, Size: value.Size}
+	}
+
+	return nil
+}
+
+// 将附件写入压缩包，返回附件在压缩包中的路径
+func (t *TalkExportService) bundle(writer *zip.Writer, msgId string, file *talkExportFile) (string, int, error) {
+	reader, err := t.Filesystem.ReadStream(file.Bucket, file.Object)
+	if err != nil {
+		return "", 0, err
+	}
+
+	defer reader.Close()
+
+	name := path.Base(strings.ReplaceAll(file.Name, "\\", "/"))
+	if name == "" || name == "." || name == "/" {
+		name = path.Base(file.Object)
+	}
+
+	name = fmt.Sprintf("files/%s_%s", msgId, name)
+
+	w, err := writer.Create(name)
+	if err != nil {
+		return "", 0, err
+	}
+
+	size, err := io.Copy(w, reader)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return name, int(size), nil
+}
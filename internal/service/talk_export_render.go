@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/repository/model"
+)
+
+// 导出文件内容
+type talkExportDocument struct {
+	TalkMode   int                  `json:"talk_mode"`   // 对话类型 1:私聊 2:群聊
+	ToFromId   int                  `json:"to_from_id"`  // 好友ID或群ID
+	Name       string               `json:"name"`        // 好友昵称或群名称
+	StartTime  string               `json:"start_time"`  // 消息发送时间起始
+	EndTime    string               `json:"end_time"`    // 消息发送时间截止
+	ExportedAt string               `json:"exported_at"` // 导出时间
+	Messages   []*talkExportMessage `json:"messages"`    // 消息列表
+}
+
+type talkExportMessage struct {
+	MsgId      string          `json:"msg_id"`               // 消息ID
+	Sequence   int             `json:"sequence"`             // 时序ID
+	MsgType    int             `json:"msg_type"`             // 消息类型
+	FromId     int             `json:"from_id"`              // 发送者ID
+	Nickname   string          `json:"nickname"`             // 发送者昵称
+	SendTime   string          `json:"send_time"`            // 发送时间
+	IsRevoked  int             `json:"is_revoked"`           // 是否已撤回
+	IsEdited   int             `json:"is_edited"`            // 是否已编辑
+	Content    string          `json:"content"`              // 可读的消息内容
+	Extra      json.RawMessage `json:"extra,omitempty"`      // 消息扩展字段
+	Attachment string          `json:"attachment,omitempty"` // 附件在压缩包中的路径
+	ThreadId   string          `json:"thread_id,omitempty"`  // 话题根消息ID(话题回复)
+}
+
+// IsImage 附件是否为图片(HTML 中直接展示)
+func (m *talkExportMessage) IsImage() bool {
+	return m.MsgType == entity.ChatMsgTypeImage && m.Attachment != ""
+}
+
+func newTalkExportMessage(record *model.TalkMessageRecord) *talkExportMessage {
+	message := &talkExportMessage{
+		MsgId:     record.MsgId,
+		Sequence:  record.Sequence,
+		MsgType:   record.MsgType,
+		FromId:    record.FromId,
+		Nickname:  record.Nickname,
+		SendTime:  record.SendTime.Format(time.DateTime),
+		IsRevoked: record.IsRevoked,
+		IsEdited:  record.IsEdited,
+		ThreadId:  record.ThreadId,
+	}
+
+	if record.IsRevoked == model.Yes {
+		message.Content = "[此消息已撤回]"
+		return message
+	}
+
+	if json.Valid([]byte(record.Extra)) {
+		message.Extra = json.RawMessage(record.Extra)
+	}
+
+	message.Content = talkExportContent(record.MsgType, record.Extra)
+
+	return message
+}
+
+// 将消息转换为可读文本
+func talkExportContent(msgType int, extra string) string {
+	label := entity.ChatMsgTypeMapping[msgType]
+
+	switch msgType {
+	case entity.ChatMsgTypeText, entity.ChatMsgSysText:
+		var value model.TalkRecordExtraText
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			return html.UnescapeString(value.Content)
+		}
+	case entity.ChatMsgTypeCode:
+		var value model.TalkRecordExtraCode
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			return fmt.Sprintf("%s %s\n%s", label, value.Lang, value.Code)
+		}
+	case entity.ChatMsgTypeImage, entity.ChatMsgTypeAudio, entity.ChatMsgTypeVideo, entity.ChatMsgTypeFile:
+		var value struct {
+			Name string `json:"name"`
+		}
+
+		if err := jsonutil.Decode(extra, &value); err == nil && value.Name != "" {
+			return fmt.Sprintf("%s %s", label, value.Name)
+		}
+	case entity.ChatMsgTypeLocation:
+		var value model.TalkRecordExtraLocation
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			return fmt.Sprintf("%s %s(%s,%s)", label, value.Description, value.Longitude, value.Latitude)
+		}
+	case entity.ChatMsgTypeForward:
+		var value model.TalkRecordExtraForward
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			lines := []string{label}
+			for _, record := range value.Records {
+				lines = append(lines, fmt.Sprintf("%s: %s", record.Nickname, record.Content))
+			}
+
+			return strings.Join(lines, "\n")
+		}
+	case entity.ChatMsgTypeMixed:
+		var value model.TalkRecordExtraMixed
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			parts := make([]string, 0, len(value.Items))
+			for _, item := range value.Items {
+				if item.Type == entity.ChatMsgTypeText {
+					parts = append(parts, html.UnescapeString(item.Content))
+				} else {
+					parts = append(parts, entity.ChatMsgTypeMapping[item.Type])
+				}
+			}
+
+			return strings.Join(parts, "\n")
+		}
+	case entity.ChatMsgTypeGroupNotice, entity.ChatMsgSysGroupNotice:
+		var value model.TalkRecordExtraGroupNotice
+		if err := jsonutil.Decode(extra, &value); err == nil {
+			return fmt.Sprintf("[群公告] %s\n%s", value.Title, value.Content)
+		}
+	}
+
+	if label == "" {
+		label = "[未知消息]"
+	}
+
+	return label
+}
+
+// 按导出格式渲染文件内容
+func renderTalkExport(format string, doc *talkExportDocument) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(doc, "", "  ")
+	case "html":
+		buffer := &bytes.Buffer{}
+		if err := talkExportHtml.Execute(buffer, doc); err != nil {
+			return nil, err
+		}
+
+		return buffer.Bytes(), nil
+	case "txt":
+		buffer := &bytes.Buffer{}
+		_, _ = fmt.Fprintf(buffer, "%s\n%s ~ %s\n导出时间: %s\n\n", doc.Name, doc.StartTime, doc.EndTime, doc.ExportedAt)
+
+		for _, message := range doc.Messages {
+			_, _ = fmt.Fprintf(buffer, "%s %s(%d)\n", message.SendTime, message.Nickname, message.FromId)
+
+			if message.ThreadId != "" {
+				_, _ = fmt.Fprintf(buffer, "回复话题: %s\n", message.ThreadId)
+			}
+
+			_, _ = fmt.Fprintf(buffer, "%s\n", message.Content)
+
+			if message.Attachment != "" {
+				_, _ = fmt.Fprintf(buffer, "附件: %s\n", message.Attachment)
+			}
+
+			buffer.WriteString("\n")
+		}
+
+		return buffer.Bytes(), nil
+	}
+
+	return nil, fmt.Errorf("不支持的导出格式: %s", format)
+}
+
+// 样式内联，页面不依赖任何外部资源，附件以相对路径引用压缩包内的文件
+var talkExportHtml = template.Must(template.New("talk_export").Parse(`<!DOCTYPE html>
+<html lang="zh-CN">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Name}} 聊天记录</title>
+<style>
+body{margin:0;background:#f5f5f5;font:14px/1.6 -apple-system,"PingFang SC","Microsoft YaHei",sans-serif;color:#333}
+header{padding:16px 24px;background:#fff;border-bottom:1px solid #e5e5e5}
+header h1{margin:0;font-size:18px}
+header p{margin:4px 0 0;color:#999;font-size:12px}
+main{max-width:880px;margin:0 auto;padding:16px}
+.msg{margin-bottom:12px;padding:10px 14px;background:#fff;border-radius:6px}
+.meta{color:#999;font-size:12px}
+.meta b{color:#1e88e5;font-weight:normal;margin-right:8px}
+.content{margin-top:4px;white-space:pre-wrap;word-break:break-all}
+.revoked .content{color:#999;font-style:italic}
+.thread{margin-left:24px;border-left:3px solid #e5e5e5}
+.thread .meta a{color:#999;margin-left:8px}
+.content img{display:block;max-width:320px;max-height:320px;margin-top:6px}
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Name}}</h1>
+<p>{{.StartTime}} ~ {{.EndTime}} · 共 {{len .Messages}} 条消息 · 导出时间 {{.ExportedAt}}</p>
+</header>
+<main>
+{{range .Messages}}<div class="msg{{if eq .IsRevoked 1}} revoked{{end}}{{if .ThreadId}} thread{{end}}" id="{{.MsgId}}">
+<div class="meta"><b>{{.Nickname}}</b>{{.SendTime}}{{if eq .IsEdited 1}} (已编辑){{end}}{{if .ThreadId}}<a href="#{{.ThreadId}}">回复话题</a>{{end}}</div>
+<div class="content">{{.Content}}{{if .IsImage}}<img src="{{.Attachment}}" alt="">{{else if .Attachment}}
+<a href="{{.Attachment}}">{{.Attachment}}</a>{{end}}</div>
+</div>
+{{end}}</main>
+</body>
+</html>
+`))
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+var _ ITalkFavoriteService = (*TalkFavoriteService)(nil)
+
+// 支持收藏的消息类型
+var favoriteMsgTypes = []int{
+	entity.ChatMsgTypeText,
+	entity.ChatMsgTypeCode,
+	entity.ChatMsgTypeImage,
+	entity.ChatMsgTypeFile,
+}
+
+type TalkFavoriteCreateOption struct {
+	UserId   int
+	TalkMode int
+	MsgId    string
+	Tags     []string
+}
+
+type TalkFavoriteListOption struct {
+	UserId  int
+	MsgType int    // 消息类型
+	Tag     string // 标签
+	Cursor  int    // 上次查询的游标(收藏ID)
+	Limit   int
+}
+
+type TalkFavoriteSendOption struct {
+	UserId int
+	Ids    []int // 收藏ID列表
+	Uids   []int // 好友ID列表
+	Gids   []int // 群ID列表
+}
+
+type ITalkFavoriteService interface {
+	Create(ctx context.Context, opt *TalkFavoriteCreateOption) (*model.TalkMessageFavorite, error)
+	Delete(ctx context.Context, uid int, id int) error
+	UpdateTags(ctx context.Context, uid int, id int, tags []string) error
+	List(ctx context.Context, opt *TalkFavoriteListOption) ([]*model.TalkMessageFavorite, error)
+	Tags(ctx context.Context, uid int) ([]string, error)
+	Send(ctx context.Context, opt *TalkFavoriteSendOption) error
+}
+
+type TalkFavoriteService struct {
+	*repo.Source
+	GroupMemberRepo         *repo.GroupMember
+	TalkRecordFriendRepo    *repo.TalkUserMessage
+	TalkRecordGroupRepo     *repo.TalkGroupMessage
+	TalkMessageFavoriteRepo *repo.TalkMessageFavorite
+	TalkMessageFileRepo     *repo.TalkMessageFile
+	AuthService             IAuthService
+	MessageService          message.IService
+}
+
+// Create 收藏消息(保存消息快照，原消息撤回后收藏依然可见)
+func (t *TalkFavoriteService) Create(ctx context.Context, opt *TalkFavoriteCreateOption) (*model.TalkMessageFavorite, error) {
+	data := &model.TalkMessageFavorite{
+		UserId:   opt.UserId,
+		TalkMode: opt.TalkMode,
+		MsgId:    opt.MsgId,
+		Tags:     strings.Join(normalizeTags(opt.Tags), ","),
+	}
+
+	var isRevoked int
+	if opt.TalkMode == entity.ChatGroupMode {
+		record, err := t.TalkRecordGroupRepo.FindByMsgId(ctx, opt.MsgId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("消息ID不存在")
+			}
+
+			return nil, err
+		}
+
+		if !t.GroupMemberRepo.IsMember(ctx, record.GroupId, opt.UserId, false) {
+			return nil, entity.ErrPermissionDenied
+		}
+
+		isRevoked = record.IsRevoked
+		data.ToFromId = record.GroupId
+		data.FromId = record.FromId
+		data.MsgType = record.MsgType
+		data.Extra = record.Extra
+		data.SendTime = record.SendTime
+	} else {
+		record, err := t.TalkRecordFriendRepo.FindByWhere(ctx, "msg_id = ? and user_id = ? and is_deleted = ?", opt.MsgId, opt.UserId, model.No)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("消息ID不存在")
+			}
+
+			return nil, err
+		}
+
+		isRevoked = record.IsRevoked
+		data.ToFromId = record.ToFromId
+		data.FromId = record.FromId
+		data.MsgType = record.MsgType
+		data.Extra = record.Extra
+		data.SendTime = record.SendTime
+	}
+
+	if isRevoked == model.Yes {
+		return nil, errors.New("消息已撤回")
+	}
+
+	if !slices.Contains(favoriteMsgTypes, data.MsgType) {
+		return nil, errors.New("该消息类型不支持收藏")
+	}
+
+	exist, err := t.TalkMessageFavoriteRepo.IsExist(ctx, "user_id = ? and msg_id = ?", opt.UserId, opt.MsgId)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return nil, errors.New("消息已收藏")
+	}
+
+	err = t.Source.Db().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+
+		return t.TalkMessageFileRepo.Attach(tx, model.TalkMessageFileSourceFavorite, strconv.Itoa(data.Id), data.MsgType, data.Extra)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Delete 删除收藏
+func (t *TalkFavoriteService) Delete(ctx context.Context, uid int, id int) error {
+	return t.Source.Db().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.TalkMessageFavorite{}, "id = ? and user_id = ?", id, uid)
+		if res.Error != nil {
+			return res.Error
+		}
+
+		if res.RowsAffected == 0 {
+			return errors.New("收藏不存在")
+		}
+
+		return tx.Delete(&model.TalkMessageFile{}, "source = ? and source_id = ?", model.TalkMessageFileSourceFavorite, strconv.Itoa(id)).Error
+	})
+}
+
+// UpdateTags 修改收藏标签
+func (t *TalkFavoriteService) UpdateTags(ctx context.Context, uid int, id int, tags []string) error {
+	_, err := t.TalkMessageFavoriteRepo.UpdateByWhere(ctx, map[string]any{
+		"tags": strings.Join(normalizeTags(tags), ","),
+	}, "id = ? and user_id = ?", id, uid)
+
+	return err
+}
+
+// List 收藏列表
+func (t *TalkFavoriteService) List(ctx context.Context, opt *TalkFavoriteListOption) ([]*model.TalkMessageFavorite, error) {
+	return t.TalkMessageFavoriteRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ?", opt.UserId)
+
+		if opt.MsgType > 0 {
+			db.Where("msg_type = ?", opt.MsgType)
+		}
+
+		if opt.Tag != "" {
+			db.Where("FIND_IN_SET(?,tags)", opt.Tag)
+		}
+
+		if opt.Cursor > 0 {
+			db.Where("id < ?", opt.Cursor)
+		}
+
+		db.Order("id desc").Limit(opt.Limit)
+	})
+}
+
+// Tags 用户收藏标签列表
+func (t *TalkFavoriteService) Tags(ctx context.Context, uid int) ([]string, error) {
+	items, err := t.TalkMessageFavoriteRepo.FindAllTags(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0)
+	for _, item := range items {
+		tags = append(tags, strings.Split(item, ",")...)
+	}
+
+	return normalizeTags(tags), nil
+}
+
+// Send 将收藏的消息逐条发送到指定会话
+func (t *TalkFavoriteService) Send(ctx context.Context, opt *TalkFavoriteSendOption) error {
+	items, err := t.TalkMessageFavoriteRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ? and id in ?", opt.UserId, opt.Ids).Order("id asc")
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(items) == 0 {
+		return errors.New("收藏不存在")
+	}
+
+	for _, uid := range opt.Uids {
+		if err := t.AuthService.IsAuth(ctx, &AuthOption{TalkType: entity.ChatPrivateMode, UserId: opt.UserId, ToFromId: uid}); err != nil {
+			return err
+		}
+	}
+
+	for _, gid := range opt.Gids {
+		if err := t.AuthService.IsAuth(ctx, &AuthOption{TalkType: entity.ChatGroupMode, UserId: opt.UserId, ToFromId: gid, IsVerifyGroupMute: true}); err != nil {
+			return err
+		}
+	}
+
+	records := make([]model.TalkRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, model.TalkRecord{
+			MsgType: item.MsgType,
+			Extra:   item.Extra,
+		})
+	}
+
+	return t.MessageService.CreateForwardMessage(ctx, message.CreateForwardMessage{
+		FromId:  opt.UserId,
+		UserId:  opt.UserId,
+		Action:  1,
+		Uids:    opt.Uids,
+		Gids:    opt.Gids,
+		Records: records,
+	})
+}
+
+// 标签去重并过滤空标签
+func normalizeTags(tags []string) []string {
+	items := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(strings.ReplaceAll(tag, ",", ""))
+		if tag != "" && !slices.Contains(items, tag) {
+			items = append(items, tag)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-chat/internal/business"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/repository/cache"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+// TalkLiveLocationThrottle 位置更新推送的最小间隔，间隔内的更新仅保存最新坐标
+const TalkLiveLocationThrottle = 3 * time.Second
+
+var _ ITalkLiveLocationService = (*TalkLiveLocationService)(nil)
+
+type TalkLiveLocationStartOption struct {
+	UserId      int
+	TalkMode    int
+	ToFromId    int
+	Duration    int
+	Longitude   string
+	Latitude    string
+	Description string
+}
+
+type TalkLiveLocationUpdateOption struct {
+	UserId    int
+	Id        int
+	Longitude string
+	Latitude  string
+}
+
+type TalkLiveLocationItem struct {
+	Id        int    `json:"id"`
+	UserId    int    `json:"user_id"`
+	Longitude string `json:"longitude"`
+	Latitude  string `json:"latitude"`
+	ExpiredAt string `json:"expired_at"`
+}
+
+type ITalkLiveLocationService interface {
+	Start(ctx context.Context, opt *TalkLiveLocationStartOption) (*model.TalkLiveLocation, error)
+	Update(ctx context.Context, opt *TalkLiveLocationUpdateOption) error
+	Stop(ctx context.Context, uid int, id int) error
+	Expire(ctx context.Context, id int) error
+	List(ctx context.Context, uid int, talkMode int, toFromId int) ([]*TalkLiveLocationItem, error)
+	FindAllSharing(ctx context.Context) ([]*model.TalkLiveLocation, error)
+}
+
+type TalkLiveLocationService struct {
+	*repo.Source
+	TalkLiveLocationRepo *repo.TalkLiveLocation
+	LiveLocationStorage  *cache.LiveLocationStorage
+	GroupMemberRepo      *repo.GroupMember
+	AuthService          IAuthService
+	MessageService       message.IService
+	PushMessage          *business.PushMessage
+}
+
+// Start 开始共享位置，同一会话中已有的共享会先结束
+func (t *TalkLiveLocationService) Start(ctx context.Context, opt *TalkLiveLocationStartOption) (*model.TalkLiveLocation, error) {
+	if err := t.AuthService.IsAuth(ctx, &AuthOption{
+		TalkType:          opt.TalkMode,
+		UserId:            opt.UserId,
+		ToFromId:          opt.ToFromId,
+		IsVerifyGroupMute: true,
+	}); err != nil {
+		return nil, err
+	}
+
+	items, err := t.TalkLiveLocationRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ? and talk_mode = ? and to_from_id = ? and status = ?", opt.UserId, opt.TalkMode, opt.ToFromId, model.TalkLiveLocationStatusSharing)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if err := t.end(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	data := &model.TalkLiveLocation{
+		UserId:      opt.UserId,
+		TalkMode:    opt.TalkMode,
+		ToFromId:    opt.ToFromId,
+		Duration:    opt.Duration,
+		Status:      model.TalkLiveLocationStatusSharing,
+		Longitude:   opt.Longitude,
+		Latitude:    opt.Latitude,
+		Description: opt.Description,
+		ExpiredAt:   time.Now().Add(time.Duration(opt.Duration) * time.Second),
+	}
+
+	if err := t.TalkLiveLocationRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	value := &cache.LiveLocationCache{
+		UserId:    data.UserId,
+		TalkMode:  data.TalkMode,
+		ToFromId:  data.ToFromId,
+		Longitude: data.Longitude,
+		Latitude:  data.Latitude,
+		ExpiredAt: data.ExpiredAt.Unix(),
+		UpdatedAt: data.CreatedAt.Unix(),
+	}
+
+	if err := t.LiveLocationStorage.Set(ctx, data.Id, value); err != nil {
+		return nil, err
+	}
+
+	t.push(ctx, "start", data.Id, value)
+
+	return data, nil
+}
+
+// Update 客户端上报最新位置，推送按 TalkLiveLocationThrottle 节流
+func (t *TalkLiveLocationService) Update(ctx context.Context, opt *TalkLiveLocationUpdateOption) error {
+	value, err := t.LiveLocationStorage.Get(ctx, opt.Id)
+	if err != nil || value.UserId != opt.UserId {
+		return errors.New("位置共享不存在或已结束")
+	}
+
+	if time.Now().Unix() >= value.ExpiredAt {
+		return errors.New("位置共享已结束")
+	}
+
+	value.Longitude = opt.Longitude
+	value.Latitude = opt.Latitude
+	value.UpdatedAt = time.Now().Unix()
+
+	if err := t.LiveLocationStorage.Set(ctx, opt.Id, value); err != nil {
+		return err
+	}
+
+	if t.LiveLocationStorage.Allow(ctx, opt.Id, TalkLiveLocationThrottle) {
+		t.push(ctx, "update", opt.Id, value)
+	}
+
+	return nil
+}
+
+// Stop 共享者主动结束位置共享
+func (t *TalkLiveLocationService) Stop(ctx context.Context, uid int, id int) error {
+	item, err := t.TalkLiveLocationRepo.FindByWhere(ctx, "id = ? and user_id = ?", id, uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("位置共享不存在")
+		}
+
+		return err
+	}
+
+	return t.end(ctx, item)
+}
+
+// Expire 共享到期后自动结束
+func (t *TalkLiveLocationService) Expire(ctx context.Context, id int) error {
+	item, err := t.TalkLiveLocationRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	return t.end(ctx, item)
+}
+
+// List 会话中共享中的位置列表
+func (t *TalkLiveLocationService) List(ctx context.Context, uid int, talkMode int, toFromId int) ([]*TalkLiveLocationItem, error) {
+	if talkMode == entity.ChatGroupMode && !t.GroupMemberRepo.IsMember(ctx, toFromId, uid, true) {
+		return nil, errors.New("暂无权限查看")
+	}
+
+	list, err := t.TalkLiveLocationRepo.FindAllByTalk(ctx, uid, talkMode, toFromId)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*TalkLiveLocationItem, 0, len(list))
+	for _, item := range list {
+		value := &TalkLiveLocationItem{
+			Id:        item.Id,
+			UserId:    item.UserId,
+			Longitude: item.Longitude,
+			Latitude:  item.Latitude,
+			ExpiredAt: item.ExpiredAt.Format(time.DateTime),
+		}
+
+		if latest, err := t.LiveLocationStorage.Get(ctx, item.Id); err == nil {
+			value.Longitude = latest.Longitude
+			value.Latitude = latest.Latitude
+		}
+
+		items = append(items, value)
+	}
+
+	return items, nil
+}
+
+// FindAllSharing 获取全部共享中的位置共享(用于服务重启后恢复到期任务)
+func (t *TalkLiveLocationService) FindAllSharing(ctx context.Context) ([]*model.TalkLiveLocation, error) {
+	return t.TalkLiveLocationRepo.FindAllSharing(ctx)
+}
+
+// 结束位置共享，并在会话中留下最后位置消息
+func (t *TalkLiveLocationService) end(ctx context.Context, item *model.TalkLiveLocation) error {
+	if item.Status != model.TalkLiveLocationStatusSharing {
+		return nil
+	}
+
+	if latest, err := t.LiveLocationStorage.Get(ctx, item.Id); err == nil {
+		item.Longitude = latest.Longitude
+		item.Latitude = latest.Latitude
+	}
+
+	// 多个节点可能同时触发到期，仅抢占成功的节点发送最后位置消息
+	ok, err := t.TalkLiveLocationRepo.End(ctx, item.Id, map[string]any{
+		"status":    model.TalkLiveLocationStatusEnded,
+		"longitude": item.Longitude,
+		"latitude":  item.Latitude,
+		"ended_at":  time.Now(),
+	})
+	if err != nil || !ok {
+		return err
+	}
+
+	_ = t.LiveLocationStorage.Del(ctx, item.Id)
+
+	t.push(ctx, "end", item.Id, &cache.LiveLocationCache{
+		UserId:    item.UserId,
+		TalkMode:  item.TalkMode,
+		ToFromId:  item.ToFromId,
+		Longitude: item.Longitude,
+		Latitude:  item.Latitude,
+		ExpiredAt: item.ExpiredAt.Unix(),
+	})
+
+	err = t.MessageService.CreateLocationMessage(ctx, message.CreateLocationMessage{
+		TalkMode:    item.TalkMode,
+		FromId:      item.UserId,
+		ToFromId:    item.ToFromId,
+		Longitude:   item.Longitude,
+		Latitude:    item.Latitude,
+		Description: item.Description,
+	})
+	if err != nil {
+		logger.Errorf("live location %d create message error: %s", item.Id, err.Error())
+	}
+
+	return nil
+}
+
+func (t *TalkLiveLocationService) push(ctx context.Context, action string, id int, value *cache.LiveLocationCache) {
+	_ = t.PushMessage.PushTalk(ctx, value.TalkMode, value.UserId, value.ToFromId, &entity.SubscribeMessage{
+		Event: entity.SubEventImLiveLocation,
+		Payload: jsonutil.Encode(entity.SubEventLiveLocationPayload{
+			Id:        id,
+			Action:    action,
+			UserId:    value.UserId,
+			TalkMode:  value.TalkMode,
+			ToFromId:  value.ToFromId,
+			Longitude: value.Longitude,
+			Latitude:  value.Latitude,
+			ExpiredAt: value.ExpiredAt,
+		}),
+	})
+}
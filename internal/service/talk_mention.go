@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+)
+
+var _ ITalkMentionService = (*TalkMentionService)(nil)
+
+type TalkMentionListOption struct {
+	UserId int
+	Unread bool // 仅查询未读
+	Cursor int  // 上次查询的游标(提及ID)
+	Limit  int
+}
+
+type ITalkMentionService interface {
+	List(ctx context.Context, opt *TalkMentionListOption) ([]*model.TalkMentionItem, error)
+	UnreadNum(ctx context.Context, uid int) (int, error)
+}
+
+type TalkMentionService struct {
+	*repo.Source
+	TalkMentionRepo *repo.TalkMessageMention
+}
+
+// List 获取提及当前用户的消息(跨群，按时间倒序)
+func (t *TalkMentionService) List(ctx context.Context, opt *TalkMentionListOption) ([]*model.TalkMentionItem, error) {
+	fields := []string{
+		"mention.id", "mention.group_id", "mention.msg_id", "mention.from_id",
+		"mention.is_all", "mention.is_read", "mention.created_at",
+		"`group`.name as group_name", "`group`.avatar as group_avatar",
+		"`users`.nickname", "`users`.avatar",
+		"message.msg_type", "message.extra", "message.is_revoked",
+	}
+
+	query := t.Source.Db().WithContext(ctx).Table("talk_message_mention mention")
+	query.Joins("inner join talk_group_message message on message.msg_id = mention.msg_id")
+	query.Joins("inner join group_member member on member.group_id = mention.group_id and member.user_id = mention.user_id and member.is_quit = ?", model.No)
+	query.Joins("left join `group` on `group`.id = mention.group_id")
+	query.Joins("left join `users` on `users`.id = mention.from_id")
+	query.Where("mention.user_id = ?", opt.UserId)
+
+	if opt.Unread {
+		query.Where("mention.is_read = ?", model.No)
+	}
+
+	if opt.Cursor > 0 {
+		query.Where("mention.id < ?", opt.Cursor)
+	}
+
+	var items []*model.TalkMentionItem
+	if err := query.Select(fields).Order("mention.id desc").Limit(opt.Limit).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// UnreadNum 获取未读提及总数
+func (t *TalkMentionService) UnreadNum(ctx context.Context, uid int) (int, error) {
+	counts, err := t.TalkMentionRepo.FindUnreadCount(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+
+	num := 0
+	for _, value := range counts {
+		num += value
+	}
+
+	return num, nil
+}
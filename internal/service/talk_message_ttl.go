@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+var _ ITalkMessageTtlService = (*TalkMessageTtlService)(nil)
+
+type TalkMessageTtlOption struct {
+	UserId   int
+	TalkMode int
+	ToFromId int
+	Ttl      int // 消息自毁时长(秒)[0:关闭;]
+}
+
+type ITalkMessageTtlService interface {
+	Get(ctx context.Context, uid int, talkMode int, toFromId int) (int, error)
+	Update(ctx context.Context, opt *TalkMessageTtlOption) error
+}
+
+type TalkMessageTtlService struct {
+	*repo.Source
+	GroupRepo       *repo.Group
+	GroupMemberRepo *repo.GroupMember
+	UsersRepo       *repo.Users
+	TalkSessionRepo *repo.TalkSession
+	AuthService     IAuthService
+	MessageService  message.IService
+}
+
+// Get 获取会话消息自毁时长
+func (t *TalkMessageTtlService) Get(ctx context.Context, uid int, talkMode int, toFromId int) (int, error) {
+	if talkMode == entity.ChatGroupMode {
+		if !t.GroupMemberRepo.IsMember(ctx, toFromId, uid, false) {
+			return 0, entity.ErrPermissionDenied
+		}
+
+		group, err := t.GroupRepo.FindById(ctx, toFromId)
+		if err != nil {
+			return 0, err
+		}
+
+		return group.MessageTtl, nil
+	}
+
+	session, err := t.TalkSessionRepo.FindByWhere(ctx, "user_id = ? and to_from_id = ? and talk_mode = ?", uid, toFromId, entity.ChatPrivateMode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.MessageTtlOff, nil
+		}
+
+		return 0, err
+	}
+
+	return session.MessageTtl, nil
+}
+
+// Update 设置会话消息自毁时长，群聊仅群主或管理员可设置，私聊双方共用同一时长
+func (t *TalkMessageTtlService) Update(ctx context.Context, opt *TalkMessageTtlOption) error {
+	if opt.TalkMode == entity.ChatGroupMode {
+		if !t.GroupMemberRepo.IsLeader(ctx, opt.ToFromId, opt.UserId) {
+			return entity.ErrPermissionDenied
+		}
+	} else if err := t.AuthService.IsAuth(ctx, &AuthOption{
+		TalkType: entity.ChatPrivateMode,
+		UserId:   opt.UserId,
+		ToFromId: opt.ToFromId,
+	}); err != nil {
+		return err
+	}
+
+	ttl, err := t.Get(ctx, opt.UserId, opt.TalkMode, opt.ToFromId)
+	if err != nil {
+		return err
+	}
+
+	if ttl == opt.Ttl {
+		return nil
+	}
+
+	if opt.TalkMode == entity.ChatGroupMode {
+		_, err = t.GroupRepo.UpdateByWhere(ctx, map[string]any{
+			"message_ttl": opt.Ttl,
+			"updated_at":  time.Now(),
+		}, "id = ?", opt.ToFromId)
+	} else {
+		err = t.TalkSessionRepo.UpdateMessageTtl(ctx, opt.UserId, opt.ToFromId, opt.Ttl)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	user, err := t.UsersRepo.FindByIdWithCache(ctx, opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	extra := jsonutil.Encode(model.TalkRecordExtraMessageTtl{
+		OwnerId:   user.Id,
+		OwnerName: user.Nickname,
+		Ttl:       opt.Ttl,
+	})
+
+	if opt.TalkMode == entity.ChatGroupMode {
+		err = t.MessageService.CreateGroupMessage(ctx, message.CreateGroupMessageOption{
+			MsgType:  entity.ChatMsgSysMessageTtl,
+			FromId:   opt.UserId,
+			ToFromId: opt.ToFromId,
+			Extra:    extra,
+		})
+	} else {
+		err = t.MessageService.CreatePrivateMessage(ctx, message.CreatePrivateMessageOption{
+			MsgType:  entity.ChatMsgSysMessageTtl,
+			FromId:   opt.UserId,
+			ToFromId: opt.ToFromId,
+			Extra:    extra,
+		})
+	}
+
+	if err != nil {
+		logger.Errorf("message ttl create sys message error:%s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go-chat/internal/business"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+var _ ITalkPinService = (*TalkPinService)(nil)
+
+type TalkPinOption struct {
+	UserId   int
+	TalkMode int
+	MsgId    string
+}
+
+type ITalkPinService interface {
+	Pin(ctx context.Context, opt *TalkPinOption) error
+	Unpin(ctx context.Context, opt *TalkPinOption) error
+	List(ctx context.Context, uid int, talkMode int, toFromId int) ([]*model.TalkMessagePin, error)
+}
+
+type TalkPinService struct {
+	*repo.Source
+	GroupMemberRepo      *repo.GroupMember
+	UsersRepo            *repo.Users
+	TalkMessagePinRepo   *repo.TalkMessagePin
+	TalkRecordFriendRepo *repo.TalkUserMessage
+	TalkRecordGroupRepo  *repo.TalkGroupMessage
+	MessageService       message.IService
+	PushMessage          *business.PushMessage
+}
+
+// Pin 置顶消息
+func (t *TalkPinService) Pin(ctx context.Context, opt *TalkPinOption) error {
+	items, record, err := t.findPinItems(ctx, opt)
+	if err != nil {
+		return err
+	}
+
+	if record.IsRevoked == model.Yes {
+		return errors.New("消息已撤回")
+	}
+
+	if record.MsgType >= entity.ChatMsgSysText {
+		return errors.New("系统消息不支持置顶")
+	}
+
+	exist, err := t.TalkMessagePinRepo.IsExist(ctx, "msg_id = ?", opt.MsgId)
+	if err != nil {
+		return err
+	}
+
+	if exist {
+		return nil
+	}
+
+	if err := t.TalkMessagePinRepo.Db.WithContext(ctx).Create(items).Error; err != nil {
+		return err
+	}
+
+	user, err := t.UsersRepo.FindByIdWithCache(ctx, opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	extra := jsonutil.Encode(model.TalkRecordExtraMessagePinned{
+		OwnerId:   user.Id,
+		OwnerName: user.Nickname,
+		MsgId:     opt.MsgId,
+		Content:   t.MessageService.GetTextMessage(record.MsgType, record.Extra),
+	})
+
+	if opt.TalkMode == entity.ChatGroupMode {
+		err = t.MessageService.CreateGroupMessage(ctx, message.CreateGroupMessageOption{
+			MsgType:  entity.ChatMsgSysMessagePinned,
+			FromId:   opt.UserId,
+			ToFromId: record.ToFromId,
+			Extra:    extra,
+		})
+	} else {
+		err = t.MessageService.CreatePrivateMessage(ctx, message.CreatePrivateMessageOption{
+			MsgType:  entity.ChatMsgSysMessagePinned,
+			FromId:   opt.UserId,
+			ToFromId: record.ToFromId,
+			Extra:    extra,
+		})
+	}
+
+	if err != nil {
+		logger.Errorf("pin message create sys message error:%s", err.Error())
+	}
+
+	t.push(ctx, opt, record.ToFromId, 1)
+
+	return nil
+}
+
+// Unpin 取消置顶消息
+func (t *TalkPinService) Unpin(ctx context.Context, opt *TalkPinOption) error {
+	items, record, err := t.findPinItems(ctx, opt)
+	if err != nil {
+		return err
+	}
+
+	msgIds := make([]string, 0, len(items))
+	for _, item := range items {
+		msgIds = append(msgIds, item.MsgId)
+	}
+
+	res := t.TalkMessagePinRepo.Db.WithContext(ctx).Delete(&model.TalkMessagePin{}, "msg_id in ?", msgIds)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		t.push(ctx, opt, record.ToFromId, 2)
+	}
+
+	return nil
+}
+
+// List 会话置顶消息列表
+func (t *TalkPinService) List(ctx context.Context, uid int, talkMode int, toFromId int) ([]*model.TalkMessagePin, error) {
+	return t.TalkMessagePinRepo.FindAllByTalk(ctx, uid, talkMode, toFromId)
+}
+
+// 校验操作权限并返回需要置顶的消息(私聊消息双方各存一份)
+func (t *TalkPinService) findPinItems(ctx context.Context, opt *TalkPinOption) ([]*model.TalkMessagePin, *model.TalkMessageRecord, error) {
+	if opt.TalkMode == entity.ChatGroupMode {
+		record, err := t.TalkRecordGroupRepo.FindByMsgId(ctx, opt.MsgId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, errors.New("消息ID不存在")
+			}
+
+			return nil, nil, err
+		}
+
+		if !t.GroupMemberRepo.IsLeader(ctx, record.GroupId, opt.UserId) {
+			return nil, nil, entity.ErrPermissionDenied
+		}
+
+		items := []*model.TalkMessagePin{{
+			TalkMode: entity.ChatGroupMode,
+			ToFromId: record.GroupId,
+			MsgId:    record.MsgId,
+			PinnedBy: opt.UserId,
+		}}
+
+		return items, &model.TalkMessageRecord{
+			ToFromId:  record.GroupId,
+			MsgType:   record.MsgType,
+			IsRevoked: record.IsRevoked,
+			Extra:     record.Extra,
+		}, nil
+	}
+
+	record, err := t.TalkRecordFriendRepo.FindByWhere(ctx, "msg_id = ? and user_id = ?", opt.MsgId, opt.UserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("消息ID不存在")
+		}
+
+		return nil, nil, err
+	}
+
+	records, err := t.TalkRecordFriendRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("org_msg_id = ?", record.OrgMsgId)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]*model.TalkMessagePin, 0, len(records))
+	for _, value := range records {
+		items = append(items, &model.TalkMessagePin{
+			TalkMode: entity.ChatPrivateMode,
+			UserId:   value.UserId,
+			ToFromId: value.ToFromId,
+			MsgId:    value.MsgId,
+			PinnedBy: opt.UserId,
+		})
+	}
+
+	return items, &model.TalkMessageRecord{
+		ToFromId:  record.ToFromId,
+		MsgType:   record.MsgType,
+		IsRevoked: record.IsRevoked,
+		Extra:     record.Extra,
+	}, nil
+}
+
+func (t *TalkPinService) push(ctx context.Context, opt *TalkPinOption, toFromId int, action int) {
+	err := t.PushMessage.PushTalk(ctx, opt.TalkMode, opt.UserId, toFromId, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessagePin,
+		Payload: jsonutil.Encode(entity.SubEventTalkPinPayload{
+			TalkMode: opt.TalkMode,
+			MsgId:    opt.MsgId,
+			UserId:   opt.UserId,
+			Action:   action,
+		}),
+	})
+
+	if err != nil {
+		logger.Errorf("pin push message error:%s", err.Error())
+	}
+}
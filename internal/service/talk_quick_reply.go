@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"strings"
+	"time"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/sliceutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+var _ ITalkQuickReplyService = (*TalkQuickReplyService)(nil)
+
+type TalkQuickReplyOption struct {
+	UserId  int
+	Scope   int
+	Title   string
+	MsgType int
+	Content string
+}
+
+type TalkQuickReplySendOption struct {
+	UserId   int
+	Id       int
+	TalkMode int
+	ToFromId int
+	QuoteId  string
+}
+
+type ITalkQuickReplyService interface {
+	Create(ctx context.Context, opt *TalkQuickReplyOption) (*model.TalkQuickReply, error)
+	Update(ctx context.Context, id int, opt *TalkQuickReplyOption) error
+	Delete(ctx context.Context, uid int, id int) error
+	List(ctx context.Context, uid int) ([]*model.TalkQuickReply, error)
+	Send(ctx context.Context, opt *TalkQuickReplySendOption) error
+}
+
+type TalkQuickReplyService struct {
+	*repo.Source
+	TalkQuickReplyRepo *repo.TalkQuickReply
+	OrganizeRepo       *repo.Organize
+	DepartmentRepo     *repo.Department
+	UsersRepo          *repo.Users
+	GroupRepo          *repo.Group
+	AuthService        IAuthService
+	MessageService     message.IService
+}
+
+// Create 创建快捷回复，部门快捷回复归属创建者所在部门
+func (t *TalkQuickReplyService) Create(ctx context.Context, opt *TalkQuickReplyOption) (*model.TalkQuickReply, error) {
+	if err := validateQuickReply(opt.MsgType, opt.Content); err != nil {
+		return nil, err
+	}
+
+	data := &model.TalkQuickReply{
+		Scope:   opt.Scope,
+		UserId:  opt.UserId,
+		Title:   opt.Title,
+		MsgType: opt.MsgType,
+		Content: opt.Content,
+	}
+
+	if opt.Scope == model.TalkQuickReplyScopeDept {
+		member, err := t.OrganizeRepo.FindByWhere(ctx, "user_id = ?", opt.UserId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("非企业成员，无法创建部门快捷回复")
+			}
+
+			return nil, err
+		}
+
+		data.DeptId = member.DeptId
+	}
+
+	if err := t.TalkQuickReplyRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Update 修改快捷回复(仅创建者可修改)
+func (t *TalkQuickReplyService) Update(ctx context.Context, id int, opt *TalkQuickReplyOption) error {
+	if err := validateQuickReply(opt.MsgType, opt.Content); err != nil {
+		return err
+	}
+
+	rows, err := t.TalkQuickReplyRepo.UpdateByWhere(ctx, map[string]any{
+		"title":    opt.Title,
+		"msg_type": opt.MsgType,
+		"content":  opt.Content,
+	}, "id = ? and user_id = ?", id, opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.New("快捷回复不存在")
+	}
+
+	return nil
+}
+
+// Delete 删除快捷回复(仅创建者可删除)
+func (t *TalkQuickReplyService) Delete(ctx context.Context, uid int, id int) error {
+	res := t.Source.Db().WithContext(ctx).Delete(&model.TalkQuickReply{}, "id = ? and user_id = ?", id, uid)
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return errors.New("快捷回复不存在")
+	}
+
+	return nil
+}
+
+// List 用户可见的快捷回复列表
+func (t *TalkQuickReplyService) List(ctx context.Context, uid int) ([]*model.TalkQuickReply, error) {
+	deptIds, err := t.findDeptIds(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.TalkQuickReplyRepo.FindAllVisible(ctx, uid, deptIds)
+}
+
+// Send 展开快捷回复中的变量并发送到指定会话
+func (t *TalkQuickReplyService) Send(ctx context.Context, opt *TalkQuickReplySendOption) error {
+	deptIds, err := t.findDeptIds(ctx, opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	reply, err := t.TalkQuickReplyRepo.FindVisible(ctx, opt.UserId, deptIds, opt.Id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("快捷回复不存在")
+		}
+
+		return err
+	}
+
+	if err := t.AuthService.IsAuth(ctx, &AuthOption{
+		TalkType:          opt.TalkMode,
+		UserId:            opt.UserId,
+		ToFromId:          opt.ToFromId,
+		IsVerifyGroupMute: true,
+	}); err != nil {
+		return err
+	}
+
+	replacer := t.replacer(ctx, opt)
+
+	if reply.MsgType == entity.ChatMsgTypeMixed {
+		var items []message.CreateMixedMessageItem
+		if err := jsonutil.Decode(reply.Content, &items); err != nil {
+			return err
+		}
+
+		for i := range items {
+			if items[i].Type == entity.ChatMsgTypeText {
+				items[i].Content = replacer.Replace(items[i].Content)
+			}
+		}
+
+		return t.MessageService.CreateMixedMessage(ctx, message.CreateMixedMessage{
+			TalkMode:    opt.TalkMode,
+			FromId:      opt.UserId,
+			ToFromId:    opt.ToFromId,
+			QuoteId:     opt.QuoteId,
+			MessageList: items,
+		})
+	}
+
+	return t.MessageService.CreateTextMessage(ctx, message.CreateTextMessage{
+		TalkMode: opt.TalkMode,
+		FromId:   opt.UserId,
+		ToFromId: opt.ToFromId,
+		QuoteId:  opt.QuoteId,
+		Content:  html.EscapeString(replacer.Replace(reply.Content)),
+	})
+}
+
+// 获取用户所在部门及其上级部门ID，非企业成员返回空
+func (t *TalkQuickReplyService) findDeptIds(ctx context.Context, uid int) ([]int, error) {
+	member, err := t.OrganizeRepo.FindByWhere(ctx, "user_id = ?", uid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	dept, err := t.DepartmentRepo.FindById(ctx, member.DeptId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []int{member.DeptId}, nil
+		}
+
+		return nil, err
+	}
+
+	deptIds := append(sliceutil.ParseIds(dept.Ancestors), dept.DeptId)
+
+	return sliceutil.Unique(deptIds), nil
+}
+
+// 快捷回复支持的变量
+//
+//	{nickname}    对方昵称(群聊为群名称)
+//	{my_nickname} 发送者昵称
+//	{date}        当前日期
+//	{time}        当前时间
+func (t *TalkQuickReplyService) replacer(ctx context.Context, opt *TalkQuickReplySendOption) *strings.Replacer {
+	var (
+		now        = time.Now()
+		nickname   string
+		myNickname string
+	)
+
+	if user, err := t.UsersRepo.FindByIdWithCache(ctx, opt.UserId); err == nil {
+		myNickname = user.Nickname
+	}
+
+	if opt.TalkMode == entity.ChatGroupMode {
+		if group, err := t.GroupRepo.FindById(ctx, opt.ToFromId); err == nil {
+			nickname = group.Name
+		}
+	} else if user, err := t.UsersRepo.FindByIdWithCache(ctx, opt.ToFromId); err == nil {
+		nickname = user.Nickname
+	}
+
+	return strings.NewReplacer(
+		"{nickname}", nickname,
+		"{my_nickname}", myNickname,
+		"{date}", now.Format(time.DateOnly),
+		"{time}", now.Format("15:04"),
+	)
+}
+
+// 校验模板内容，图文消息仅支持文本和图片
+func validateQuickReply(msgType int, content string) error {
+	if msgType != entity.ChatMsgTypeMixed {
+		return nil
+	}
+
+	var items []message.CreateMixedMessageItem
+	if err := jsonutil.Decode(content, &items); err != nil || len(items) == 0 {
+		return errors.New("图文消息内容格式错误")
+	}
+
+	for _, item := range items {
+		if item.Type != entity.ChatMsgTypeText && item.Type != entity.ChatMsgTypeImage {
+			return errors.New("图文消息仅支持文本和图片")
+		}
+
+		if item.Content == "" {
+			return errors.New("图文消息内容不能为空")
+		}
+	}
+
+	return nil
+}
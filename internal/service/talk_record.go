@@ -3,6 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
 
 	"go-chat/internal/entity"
 	"go-chat/internal/pkg/sliceutil"
@@ -21,16 +27,26 @@ type ITalkRecordService interface {
 	FindTalkGroupRecord(ctx context.Context, msgId string) (*model.TalkMessageRecord, error)
 	FindAllTalkRecords(ctx context.Context, opt *FindAllTalkRecordsOpt) ([]*model.TalkMessageRecord, error)
 	FindForwardRecords(ctx context.Context, uid int, msgIds []string, talkType int) ([]*model.TalkMessageRecord, error)
+	FindEditRecords(ctx context.Context, uid int, talkMode int, msgId string) ([]*model.TalkMessageEdit, error)
+	FindThreadRecords(ctx context.Context, opt *FindThreadRecordsOpt) ([]*model.TalkMessageRecord, error)
+	SearchRecords(ctx context.Context, opt *SearchTalkRecordsOpt) ([]*model.TalkMessageRecord, error)
+	Sync(ctx context.Context, opt *TalkSyncOpt) (*TalkSyncResult, error)
 }
 
 type TalkRecordService struct {
 	*repo.Source
-	TalkVoteCache         *cache.Vote
-	TalkRecordsVoteRepo   *repo.GroupVote
-	GroupMemberRepo       *repo.GroupMember
-	TalkRecordFriendRepo  *repo.TalkUserMessage
-	TalkRecordGroupRepo   *repo.TalkGroupMessage
-	TalkRecordsDeleteRepo *repo.TalkGroupMessageDel
+	TalkVoteCache           *cache.Vote
+	TalkRecordsVoteRepo     *repo.GroupVote
+	GroupMemberRepo         *repo.GroupMember
+	TalkRecordFriendRepo    *repo.TalkUserMessage
+	TalkRecordGroupRepo     *repo.TalkGroupMessage
+	TalkRecordsDeleteRepo   *repo.TalkGroupMessageDel
+	TalkMessageEditRepo     *repo.TalkMessageEdit
+	TalkMessageReactionRepo *repo.TalkMessageReaction
+	TalkGroupThreadRepo     *repo.TalkGroupThread
+	TalkSyncEventRepo       *repo.TalkSyncEvent
+	TalkUrgentRepo          *repo.TalkMessageUrgent
+	UnreadStorage           *cache.UnreadStorage
 }
 
 func (s *TalkRecordService) FindPrivateRecordByMsgId(ctx context.Context, msgId string) (*model.TalkUserMessage, error) {
@@ -44,12 +60,35 @@ func (s *TalkRecordService) FindAllPrivateRecordByOriMsgId(ctx context.Context,
 }
 
 type FindAllTalkRecordsOpt struct {
-	TalkType   int   // 对话类型
-	UserId     int   // 获取消息的用户
-	ReceiverId int   // 接收者ID
-	MsgType    []int // 消息类型
-	Cursor     int   // 上次查询的游标
-	Limit      int   // 数据行数
+	TalkType   int       // 对话类型
+	UserId     int       // 获取消息的用户
+	ReceiverId int       // 接收者ID
+	MsgType    []int     // 消息类型
+	Cursor     int       // 上次查询的游标
+	Limit      int       // 数据行数
+	StartTime  time.Time // 发送时间起始(包含)，零值不限制
+	EndTime    time.Time // 发送时间截止(不包含)，零值不限制
+	WithThread bool      // 是否包含话题回复(仅群聊)
+}
+
+type SearchTalkRecordsOpt struct {
+	UserId    int       // 搜索消息的用户
+	Keyword   string    // 搜索关键词
+	TalkMode  int       // 对话类型(为 0 时搜索所有会话)
+	ToFromId  int       // 接收者ID(需同时指定对话类型)
+	FromId    int       // 消息发送者ID
+	MsgType   int       // 消息类型
+	StartTime time.Time // 开始时间
+	EndTime   time.Time // 结束时间
+	Page      int       // 页码
+	Limit     int       // 数据行数
+}
+
+type FindThreadRecordsOpt struct {
+	UserId int    // 获取消息的用户
+	MsgId  string // 话题根消息ID
+	Cursor int    // 上次查询的游标
+	Limit  int    // 数据行数
 }
 
 func (s *TalkRecordService) FindTalkPrivateRecord(ctx context.Context, uid int, msgId string) (*model.TalkMessageRecord, error) {
@@ -59,18 +98,19 @@ func (s *TalkRecordService) FindTalkPrivateRecord(ctx context.Context, uid int,
 	}
 
 	record := &model.TalkMessageRecord{
-		TalkMode:  entity.ChatPrivateMode,
-		FromId:    talkRecordFriendInfo.FromId,
-		ToFromId:  talkRecordFriendInfo.ToFromId,
-		MsgId:     talkRecordFriendInfo.MsgId,
-		Sequence:  int(talkRecordFriendInfo.Sequence),
-		MsgType:   talkRecordFriendInfo.MsgType,
-		Nickname:  "",
-		Avatar:    "",
-		IsRevoked: talkRecordFriendInfo.IsRevoked,
-		SendTime:  talkRecordFriendInfo.SendTime,
-		Extra:     talkRecordFriendInfo.Extra,
-		Quote:     talkRecordFriendInfo.Quote,
+		TalkMode:       entity.ChatPrivateMode,
+		FromId:         talkRecordFriendInfo.FromId,
+		ToFromId:       talkRecordFriendInfo.ToFromId,
+		MsgId:          talkRecordFriendInfo.MsgId,
+		Sequence:       int(talkRecordFriendInfo.Sequence),
+		MsgType:        talkRecordFriendInfo.MsgType,
+		Nickname:       "",
+		Avatar:         "",
+		IsRevoked:      talkRecordFriendInfo.IsRevoked,
+		SendTime:       talkRecordFriendInfo.SendTime,
+		Extra:          talkRecordFriendInfo.Extra,
+		Quote:          talkRecordFriendInfo.Quote,
+		DeliveryStatus: talkRecordFriendInfo.DeliveryStatus,
 	}
 
 	list, err := s.handleTalkRecords(ctx, []*model.TalkMessageRecord{record})
@@ -130,6 +170,9 @@ func (s *TalkRecordService) FindAllTalkRecords(ctx context.Context, opt *FindAll
 			MsgType:    opt.MsgType,
 			Cursor:     cursor,
 			Limit:      opt.Limit + 10, // 多查几条数据
+			StartTime:  opt.StartTime,
+			EndTime:    opt.EndTime,
+			WithThread: opt.WithThread,
 		})
 
 		if err != nil {
@@ -179,9 +222,183 @@ func (s *TalkRecordService) FindAllTalkRecords(ctx context.Context, opt *FindAll
 		items = items[:opt.Limit]
 	}
 
+	items, err := s.handleTalkRecords(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.TalkType == entity.ChatGroupMode {
+		s.loadThreadUnread(ctx, opt.UserId, items)
+	}
+
+	s.loadUrgent(ctx, opt.UserId, items)
+
+	return items, nil
+}
+
+// FindThreadRecords 获取话题回复消息(按发送顺序正序)
+func (s *TalkRecordService) FindThreadRecords(ctx context.Context, opt *FindThreadRecordsOpt) ([]*model.TalkMessageRecord, error) {
+	root, err := s.TalkRecordGroupRepo.FindByMsgId(ctx, opt.MsgId)
+	if err != nil {
+		return nil, err
+	}
+
+	if root.ThreadId != "" || !s.GroupMemberRepo.IsMember(ctx, root.GroupId, opt.UserId, false) {
+		return nil, entity.ErrPermissionDenied
+	}
+
+	query := s.Source.Db().WithContext(ctx).Table("talk_group_message")
+	query.Select([]string{
+		"msg_id",
+		"sequence",
+		"msg_type",
+		"is_revoked",
+		"extra",
+		"quote",
+		"send_time",
+		"from_id",
+		"thread_id",
+	})
+	query.Where("thread_id = ?", opt.MsgId)
+	query.Where("sequence > ?", opt.Cursor)
+	query.Where("NOT EXISTS (SELECT 1 FROM talk_group_message_del WHERE talk_group_message_del.msg_id = talk_group_message.msg_id AND talk_group_message_del.user_id = ?)", opt.UserId)
+	query.Order("sequence asc").Limit(opt.Limit)
+
+	var items []*model.TalkMessageRecord
+	if err := query.Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(items); i++ {
+		items[i].TalkMode = entity.ChatGroupMode
+		items[i].ToFromId = root.GroupId
+	}
+
 	return s.handleTalkRecords(ctx, items)
 }
 
+// SearchRecords 全文检索用户可见的所有会话消息
+func (s *TalkRecordService) SearchRecords(ctx context.Context, opt *SearchTalkRecordsOpt) ([]*model.TalkMessageRecord, error) {
+	var (
+		db      = s.Source.Db().WithContext(ctx)
+		offset  = (max(opt.Page, 1) - 1) * opt.Limit
+		against = fmt.Sprintf(`"%s"`, strings.ReplaceAll(opt.Keyword, `"`, " "))
+		queries = make([]any, 0, 2)
+	)
+
+	filter := func(query *gorm.DB) *gorm.DB {
+		query = query.Where("is_revoked = ?", model.No)
+		query = query.Where("MATCH(search_text) AGAINST(? IN BOOLEAN MODE)", against)
+
+		if opt.FromId > 0 {
+			query = query.Where("from_id = ?", opt.FromId)
+		}
+
+		if opt.MsgType > 0 {
+			query = query.Where("msg_type = ?", opt.MsgType)
+		}
+
+		if !opt.StartTime.IsZero() {
+			query = query.Where("send_time >= ?", opt.StartTime)
+		}
+
+		if !opt.EndTime.IsZero() {
+			query = query.Where("send_time <= ?", opt.EndTime)
+		}
+
+		return query.Order("send_time desc").Limit(offset + opt.Limit)
+	}
+
+	if opt.TalkMode == 0 || opt.TalkMode == entity.ChatPrivateMode {
+		query := db.Table("talk_user_message").
+			Select("1 as talk_mode,to_from_id,msg_id,sequence,msg_type,from_id,is_revoked,extra,quote,'' as thread_id,send_time").
+			Where("user_id = ? and is_deleted = ?", opt.UserId, model.No)
+
+		if opt.ToFromId > 0 {
+			query = query.Where("to_from_id = ?", opt.ToFromId)
+		}
+
+		queries = append(queries, filter(query))
+	}
+
+	if opt.TalkMode == 0 || opt.TalkMode == entity.ChatGroupMode {
+		groupIds := s.GroupMemberRepo.GetUserGroupIds(ctx, opt.UserId)
+		if opt.ToFromId > 0 {
+			groupIds = lo.Ternary(slices.Contains(groupIds, opt.ToFromId), []int{opt.ToFromId}, []int{})
+		}
+
+		if len(groupIds) > 0 {
+			query := db.Table("talk_group_message").
+				Select("2 as talk_mode,group_id as to_from_id,msg_id,sequence,msg_type,from_id,is_revoked,extra,quote,thread_id,send_time").
+				Where("group_id in ?", groupIds).
+				Where("NOT EXISTS (SELECT 1 FROM talk_group_message_del WHERE talk_group_message_del.msg_id = talk_group_message.msg_id AND talk_group_message_del.user_id = ?)", opt.UserId)
+
+			queries = append(queries, filter(query))
+		}
+	}
+
+	if len(queries) == 0 {
+		return make([]*model.TalkMessageRecord, 0), nil
+	}
+
+	sql := "SELECT * FROM ((?)) AS t ORDER BY send_time DESC LIMIT ? OFFSET ?"
+	if len(queries) == 2 {
+		sql = "SELECT * FROM ((?) UNION ALL (?)) AS t ORDER BY send_time DESC LIMIT ? OFFSET ?"
+	}
+
+	var items []*model.TalkMessageRecord
+	if err := db.Raw(sql, append(queries, opt.Limit, offset)...).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return s.handleTalkRecords(ctx, items)
+}
+
+// 加载用户话题未读数
+func (s *TalkRecordService) loadThreadUnread(ctx context.Context, uid int, items []*model.TalkMessageRecord) {
+	msgIds := make([]string, 0)
+	for _, item := range items {
+		if item.Thread != nil {
+			msgIds = append(msgIds, item.MsgId)
+		}
+	}
+
+	unread := s.UnreadStorage.MGetThread(ctx, uid, msgIds)
+	for _, item := range items {
+		if item.Thread != nil {
+			item.Thread.UnreadNum = unread[item.MsgId]
+		}
+	}
+}
+
+// 加载用户作为接收者的紧急消息提醒状态
+func (s *TalkRecordService) loadUrgent(ctx context.Context, uid int, items []*model.TalkMessageRecord) {
+	msgIds := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.FromId != uid {
+			msgIds = append(msgIds, item.MsgId)
+		}
+	}
+
+	if len(msgIds) == 0 {
+		return
+	}
+
+	urgent, err := s.TalkUrgentRepo.FindAllByMsgIds(ctx, uid, msgIds)
+	if err != nil {
+		return
+	}
+
+	for _, item := range items {
+		if value, ok := urgent[item.MsgId]; ok {
+			item.Urgent = &model.TalkMessageRecordUrgent{
+				IsAcked:   value.IsAcked,
+				NotifyNum: value.NotifyNum,
+			}
+		}
+	}
+}
+
 func (s *TalkRecordService) findAllRecords(ctx context.Context, opt *FindAllTalkRecordsOpt) ([]*model.TalkMessageRecord, error) {
 	query := s.Source.Db().WithContext(ctx)
 
@@ -197,13 +414,22 @@ func (s *TalkRecordService) findAllRecords(ctx context.Context, opt *FindAllTalk
 	}
 
 	if opt.TalkType == 1 {
+		fields = append(fields, "delivery_status")
+
 		query = query.Table("talk_user_message")
 		query.Where("user_id = ?", opt.UserId)
 		query.Where("to_from_id = ?", opt.ReceiverId)
 		query.Where("is_deleted = ?", model.No)
 	} else {
+		fields = append(fields, "thread_id")
+
 		query = query.Table("talk_group_message")
 		query.Where("group_id = ?", opt.ReceiverId)
+
+		// 话题回复不展示在群聊消息列表中
+		if !opt.WithThread {
+			query.Where("thread_id = ''")
+		}
 	}
 
 	query.Select(fields)
@@ -216,6 +442,14 @@ func (s *TalkRecordService) findAllRecords(ctx context.Context, opt *FindAllTalk
 		query.Where("msg_type in ?", opt.MsgType)
 	}
 
+	if !opt.StartTime.IsZero() {
+		query.Where("send_time >= ?", opt.StartTime)
+	}
+
+	if !opt.EndTime.IsZero() {
+		query.Where("send_time < ?", opt.EndTime)
+	}
+
 	query.Order("sequence desc").Limit(opt.Limit)
 
 	var items []*model.TalkMessageRecord
@@ -264,6 +498,31 @@ func (s *TalkRecordService) FindForwardRecords(ctx context.Context, uid int, msg
 	return s.handleTalkRecords(ctx, items)
 }
 
+// FindEditRecords 获取消息编辑记录
+func (s *TalkRecordService) FindEditRecords(ctx context.Context, uid int, talkMode int, msgId string) ([]*model.TalkMessageEdit, error) {
+	if talkMode == entity.ChatGroupMode {
+		record, err := s.TalkRecordGroupRepo.FindByMsgId(ctx, msgId)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.GroupMemberRepo.IsMember(ctx, record.GroupId, uid, false) {
+			return nil, entity.ErrPermissionDenied
+		}
+	} else {
+		exist, err := s.TalkRecordFriendRepo.IsExist(ctx, "msg_id = ? and user_id = ?", msgId, uid)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exist {
+			return nil, entity.ErrPermissionDenied
+		}
+	}
+
+	return s.TalkMessageEditRepo.FindAllByMsgId(ctx, msgId)
+}
+
 // HandleTalkRecords 处理消息
 func (s *TalkRecordService) handleTalkRecords(ctx context.Context, items []*model.TalkMessageRecord) ([]*model.TalkMessageRecord, error) {
 	if len(items) == 0 {
@@ -288,12 +547,54 @@ func (s *TalkRecordService) handleTalkRecords(ctx context.Context, items []*mode
 		hashUser[user.Id] = user
 	}
 
+	msgIds := make([]string, 0, len(items))
+	for _, item := range items {
+		msgIds = append(msgIds, item.MsgId)
+	}
+
+	editedMsgIds, err := s.TalkMessageEditRepo.FindEditedMsgIds(ctx, msgIds)
+	if err != nil {
+		return nil, err
+	}
+
+	hashEdited := make(map[string]struct{}, len(editedMsgIds))
+	for _, msgId := range editedMsgIds {
+		hashEdited[msgId] = struct{}{}
+	}
+
+	reactions, err := s.TalkMessageReactionRepo.FindCountByMsgIds(ctx, msgIds)
+	if err != nil {
+		return nil, err
+	}
+
+	threads, err := s.TalkGroupThreadRepo.FindByMsgIds(ctx, msgIds)
+	if err != nil {
+		return nil, err
+	}
+
 	for i := 0; i < len(items); i++ {
 		if user, ok := hashUser[items[i].FromId]; ok {
 			items[i].Nickname = user.Nickname
 			items[i].Avatar = user.Avatar
 		}
 
+		items[i].IsEdited = model.No
+		if _, ok := hashEdited[items[i].MsgId]; ok {
+			items[i].IsEdited = model.Yes
+		}
+
+		items[i].Reactions = reactions[items[i].MsgId]
+		if items[i].Reactions == nil {
+			items[i].Reactions = make([]*model.TalkMessageReactionCount, 0)
+		}
+
+		if thread, ok := threads[items[i].MsgId]; ok {
+			items[i].Thread = &model.TalkMessageRecordThread{
+				ReplyCount:  thread.ReplyCount,
+				LastReplyAt: thread.LastReplyAt.Format(time.DateTime),
+			}
+		}
+
 		//if err = jsonutil.Decode(items[i].Extra, &items[i].Extra); err != nil {
 		//	fmt.Println("ERR===>", items[i].MsgId, items[i].Extra, items[i].Extra)
 		//}
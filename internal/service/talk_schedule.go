@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/strutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+const (
+	talkScheduleSendTimeout  = 5 * time.Minute // 发送中的消息超过该时长未完成视为发送节点异常退出
+	talkScheduleReasonLength = 255             // 失败原因的最大长度(与 talk_message_schedule.reason 字段长度一致)
+)
+
+var _ ITalkScheduleService = (*TalkScheduleService)(nil)
+
+type TalkScheduleCreateOption struct {
+	UserId   int
+	TalkMode int
+	ToFromId int
+	MsgType  int
+	QuoteId  string
+	Extra    string
+	SendAt   time.Time
+}
+
+type ITalkScheduleService interface {
+	Create(ctx context.Context, opt *TalkScheduleCreateOption) (*model.TalkMessageSchedule, error)
+	List(ctx context.Context, uid int, status int) ([]*model.TalkMessageSchedule, error)
+	Cancel(ctx context.Context, uid int, id int) error
+	Reschedule(ctx context.Context, uid int, id int, sendAt time.Time) error
+	Dispatch(ctx context.Context) error
+}
+
+type TalkScheduleService struct {
+	*repo.Source
+	TalkMessageScheduleRepo *repo.TalkMessageSchedule
+	AuthService             IAuthService
+	MessageService          message.IService
+}
+
+// Create 创建定时消息
+func (t *TalkScheduleService) Create(ctx context.Context, opt *TalkScheduleCreateOption) (*model.TalkMessageSchedule, error) {
+	if !opt.SendAt.After(time.Now()) {
+		return nil, errors.New("发送时间必须大于当前时间")
+	}
+
+	data := &model.TalkMessageSchedule{
+		UserId:   opt.UserId,
+		TalkMode: opt.TalkMode,
+		ToFromId: opt.ToFromId,
+		MsgType:  opt.MsgType,
+		QuoteId:  opt.QuoteId,
+		Extra:    opt.Extra,
+		Status:   model.TalkMessageScheduleStatusWait,
+		SendAt:   opt.SendAt,
+	}
+
+	if err := t.TalkMessageScheduleRepo.Create(ctx, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// List 定时消息列表
+func (t *TalkScheduleService) List(ctx context.Context, uid int, status int) ([]*model.TalkMessageSchedule, error) {
+	return t.TalkMessageScheduleRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("user_id = ?", uid)
+
+		if status > 0 {
+			db.Where("status = ?", status)
+		}
+
+		db.Order("send_at asc")
+	})
+}
+
+// Cancel 取消定时消息
+func (t *TalkScheduleService) Cancel(ctx context.Context, uid int, id int) error {
+	ok, err := t.updateWait(ctx, uid, id, map[string]any{
+		"status": model.TalkMessageScheduleStatusCancel,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("定时消息不存在或已发送")
+	}
+
+	return nil
+}
+
+// Reschedule 修改定时消息发送时间
+func (t *TalkScheduleService) Reschedule(ctx context.Context, uid int, id int, sendAt time.Time) error {
+	if !sendAt.After(time.Now()) {
+		return errors.New("发送时间必须大于当前时间")
+	}
+
+	ok, err := t.updateWait(ctx, uid, id, map[string]any{
+		"send_at": sendAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("定时消息不存在或已发送")
+	}
+
+	return nil
+}
+
+func (t *TalkScheduleService) updateWait(ctx context.Context, uid int, id int, data map[string]any) (bool, error) {
+	res := t.TalkMessageScheduleRepo.Model(ctx).
+		Where("id = ? and user_id = ? and status = ?", id, uid, model.TalkMessageScheduleStatusWait).
+		Updates(data)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// Dispatch 发送已到期的定时消息
+func (t *TalkScheduleService) Dispatch(ctx context.Context) error {
+	var (
+		now    = time.Now()
+		lastId = 0
+		size   = 100
+	)
+
+	// 发送节点异常退出时消息会一直停留在发送中，无法确认是否已发出，标记为失败由用户确认后重新发送
+	rows, err := t.TalkMessageScheduleRepo.FailTimeout(ctx, now.Add(-talkScheduleSendTimeout), "发送超时，请确认消息是否已送达")
+	if err != nil {
+		logger.Errorf("schedule message fail timeout error: %s", err.Error())
+	} else if rows > 0 {
+		logger.Warnf("schedule message %d sending timeout", rows)
+	}
+
+	for {
+		items, err := t.TalkMessageScheduleRepo.FindAllExpired(ctx, now, lastId, size)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			t.send(ctx, item)
+		}
+
+		if len(items) < size {
+			break
+		}
+
+		lastId = items[len(items)-1].Id
+	}
+
+	return nil
+}
+
+func (t *TalkScheduleService) send(ctx context.Context, item *model.TalkMessageSchedule) {
+	// 抢占任务，防止多个节点重复发送
+	ok, err := t.TalkMessageScheduleRepo.UpdateStatus(ctx, item.Id, model.TalkMessageScheduleStatusWait, map[string]any{
+		"status": model.TalkMessageScheduleStatusSending,
+	})
+	if err != nil || !ok {
+		return
+	}
+
+	err = t.AuthService.IsAuth(ctx, &AuthOption{
+		TalkType:          item.TalkMode,
+		UserId:            item.UserId,
+		ToFromId:          item.ToFromId,
+		IsVerifyGroupMute: true,
+	})
+
+	if err == nil {
+		err = t.MessageService.CreateMessage(ctx, message.CreateMessageOption{
+			TalkMode: item.TalkMode,
+			FromId:   item.UserId,
+			ToFromId: item.ToFromId,
+			MsgType:  item.MsgType,
+			QuoteId:  item.QuoteId,
+			Extra:    item.Extra,
+		})
+	}
+
+	data := map[string]any{"status": model.TalkMessageScheduleStatusSent}
+	if err != nil {
+		logger.Errorf("schedule message %d send error: %s", item.Id, err.Error())
+		data = map[string]any{"status": model.TalkMessageScheduleStatusFail, "reason": strutil.MtSubstr(err.Error(), 0, talkScheduleReasonLength)}
+	}
+
+	_, _ = t.TalkMessageScheduleRepo.UpdateStatus(ctx, item.Id, model.TalkMessageScheduleStatusSending, data)
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"github.com/samber/lo"
+	"go-chat/internal/business"
 	"go-chat/internal/entity"
-
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
 	"go-chat/internal/pkg/timeutil"
+	"go-chat/internal/repository/cache"
 	"go-chat/internal/repository/model"
 	"go-chat/internal/repository/repo"
 	"gorm.io/gorm"
@@ -24,12 +27,18 @@ type ITalkSessionService interface {
 	Delete(ctx context.Context, uid int, talkMode int, toFromId int) error
 	Top(ctx context.Context, opt *TalkSessionTopOpt) error
 	Disturb(ctx context.Context, opt *TalkSessionDisturbOpt) error
+	SaveDraft(ctx context.Context, opt *TalkSessionDraftOpt) error
 	BatchAddList(ctx context.Context, uid int, values map[string]int)
 }
 
 type TalkSessionService struct {
 	*repo.Source
-	TalkSessionRepo *repo.TalkSession
+	TalkSessionRepo   *repo.TalkSession
+	TalkDraftRepo     *repo.TalkDraft
+	TalkSyncEventRepo *repo.TalkSyncEvent
+	TalkMentionRepo   *repo.TalkMessageMention
+	DraftStorage      *cache.DraftStorage
+	PushMessage       *business.PushMessage
 }
 
 func (s *TalkSessionService) List(ctx context.Context, uid int) ([]*model.SearchTalkSession, error) {
@@ -52,6 +61,22 @@ func (s *TalkSessionService) List(ctx context.Context, uid int) ([]*model.Search
 		return nil, err
 	}
 
+	mentions, err := s.TalkMentionRepo.FindUnreadCount(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts := s.findAllDraft(ctx, uid)
+	for _, item := range items {
+		if draft, ok := drafts[fmt.Sprintf("%d_%d", item.TalkMode, item.ToFromId)]; ok {
+			item.Draft = draft.Content
+		}
+
+		if item.TalkMode == entity.ChatGroupMode {
+			item.MentionNum = mentions[item.ToFromId]
+		}
+	}
+
 	return items, nil
 }
 
@@ -107,7 +132,12 @@ func (s *TalkSessionService) Delete(ctx context.Context, uid int, talkMode int,
 		"is_delete":  model.Yes,
 		"updated_at": time.Now(),
 	}, "user_id = ? and to_from_id = ? and talk_mode = ?", uid, toFromId, talkMode)
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.createSyncEvent(ctx, uid, model.SyncEventSessionDelete, talkMode, toFromId, model.Yes)
+	return nil
 }
 
 type TalkSessionTopOpt struct {
@@ -119,11 +149,18 @@ type TalkSessionTopOpt struct {
 
 // Top 会话置顶
 func (s *TalkSessionService) Top(ctx context.Context, opt *TalkSessionTopOpt) error {
+	isTop := lo.Ternary(opt.Action == 1, model.Yes, model.No)
+
 	_, err := s.TalkSessionRepo.UpdateByWhere(ctx, map[string]any{
-		"is_top":     lo.Ternary(opt.Action == 1, model.Yes, model.No),
+		"is_top":     isTop,
 		"updated_at": time.Now(),
 	}, "user_id = ? and talk_mode = ? and to_from_id = ?", opt.UserId, opt.TalkMode, opt.ToFromId)
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.createSyncEvent(ctx, opt.UserId, model.SyncEventSessionTop, opt.TalkMode, opt.ToFromId, isTop)
+	return nil
 }
 
 type TalkSessionDisturbOpt struct {
@@ -135,11 +172,108 @@ type TalkSessionDisturbOpt struct {
 
 // Disturb 会话免打扰
 func (s *TalkSessionService) Disturb(ctx context.Context, opt *TalkSessionDisturbOpt) error {
+	isDisturb := lo.Ternary(opt.Action == 1, model.Yes, model.No)
+
 	_, err := s.TalkSessionRepo.UpdateByWhere(ctx, map[string]any{
-		"is_disturb": lo.Ternary(opt.Action == 1, model.Yes, model.No),
+		"is_disturb": isDisturb,
 		"updated_at": time.Now(),
 	}, "user_id = ? and talk_mode = ? and to_from_id = ?", opt.UserId, opt.TalkMode, opt.ToFromId)
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.createSyncEvent(ctx, opt.UserId, model.SyncEventSessionDisturb, opt.TalkMode, opt.ToFromId, isDisturb)
+	return nil
+}
+
+type TalkSessionDraftOpt struct {
+	UserId   int
+	TalkMode int
+	ToFromId int
+	Content  string // 草稿内容(为空表示清除草稿)
+}
+
+// SaveDraft 保存会话草稿，并同步到用户其它在线设备
+func (s *TalkSessionService) SaveDraft(ctx context.Context, opt *TalkSessionDraftOpt) error {
+	now := time.Now()
+
+	if opt.Content == "" {
+		if err := s.TalkDraftRepo.Delete(ctx, opt.UserId, opt.TalkMode, opt.ToFromId); err != nil {
+			return err
+		}
+
+		if err := s.DraftStorage.Del(ctx, opt.UserId, opt.TalkMode, opt.ToFromId); err != nil {
+			logger.Errorf("talk draft cache del error:%s", err.Error())
+		}
+	} else {
+		if err := s.TalkDraftRepo.Upsert(ctx, opt.UserId, opt.TalkMode, opt.ToFromId, opt.Content, now); err != nil {
+			return err
+		}
+
+		if err := s.DraftStorage.Set(ctx, opt.UserId, opt.TalkMode, opt.ToFromId, &cache.DraftCacheMessage{
+			Content:   opt.Content,
+			UpdatedAt: timeutil.FormatDatetime(now),
+		}); err != nil {
+			logger.Errorf("talk draft cache set error:%s", err.Error())
+		}
+	}
+
+	// 草稿仅同步给用户的其它在线设备
+	err := s.PushMessage.PushUsers(ctx, []int{opt.UserId}, &entity.SubscribeMessage{
+		Event: entity.SubEventImTalkDraft,
+		Payload: jsonutil.Encode(entity.SubEventTalkDraftPayload{
+			UserId:    opt.UserId,
+			TalkMode:  opt.TalkMode,
+			ToFromId:  opt.ToFromId,
+			Content:   opt.Content,
+			UpdatedAt: timeutil.FormatDatetime(now),
+		}),
+	})
+	if err != nil {
+		logger.Errorf("talk draft push message error:%s", err.Error())
+	}
+
+	return nil
+}
+
+// 获取用户全部会话草稿，缓存未命中时从数据库加载并回写缓存
+func (s *TalkSessionService) findAllDraft(ctx context.Context, uid int) map[string]*cache.DraftCacheMessage {
+	drafts, loaded, err := s.DraftStorage.All(ctx, uid)
+	if err == nil && loaded {
+		return drafts
+	}
+
+	items, err := s.TalkDraftRepo.FindAllByUserId(ctx, uid)
+	if err != nil {
+		logger.Errorf("talk draft find all error:%s", err.Error())
+		return map[string]*cache.DraftCacheMessage{}
+	}
+
+	drafts = make(map[string]*cache.DraftCacheMessage, len(items))
+	for _, item := range items {
+		drafts[fmt.Sprintf("%d_%d", item.TalkMode, item.ToFromId)] = &cache.DraftCacheMessage{
+			Content:   item.Content,
+			UpdatedAt: timeutil.FormatDatetime(item.UpdatedAt),
+		}
+	}
+
+	_ = s.DraftStorage.MSet(ctx, uid, drafts)
+
+	return drafts
+}
+
+// 记录会话设置变更的多端同步事件
+func (s *TalkSessionService) createSyncEvent(ctx context.Context, uid int, event string, talkMode int, toFromId int, value int) {
+	err := s.TalkSyncEventRepo.CreateUserEvent(ctx, uid, &model.TalkSyncEvent{
+		Event:    event,
+		TalkMode: talkMode,
+		ToFromId: toFromId,
+		Payload:  jsonutil.Encode(model.TalkSyncEventPayload{Value: value}),
+	})
+
+	if err != nil {
+		logger.Errorf("create session sync event error:%s", err.Error())
+	}
 }
 
 // BatchAddList 批量添加会话列表
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+
+	"go-chat/internal/entity"
+	"go-chat/internal/repository/model"
+)
+
+type TalkSyncOpt struct {
+	UserId        int
+	Sequence      int64                   // 私聊消息时序ID水位
+	EventSequence int64                   // 用户同步事件时序ID水位
+	Groups        map[int]*TalkSyncCursor // 群聊水位 group_id => cursor
+	Limit         int                     // 每个会话返回的最大消息数及事件数
+}
+
+// TalkSyncCursor 群聊同步水位，消息与同步事件各自发号，需分别记录
+type TalkSyncCursor struct {
+	Sequence      int64 // 群消息时序ID水位
+	EventSequence int64 // 群同步事件时序ID水位
+}
+
+type TalkSyncResult struct {
+	Private *TalkSyncItem   // 私聊消息及用户同步事件
+	Groups  []*TalkSyncItem // 群聊消息及群同步事件
+}
+
+type TalkSyncItem struct {
+	GroupId       int                        // 群ID(私聊为0)
+	Sequence      int64                      // 新的消息时序ID水位
+	EventSequence int64                      // 新的同步事件时序ID水位
+	HasMore       bool                       // 是否还有未同步的数据
+	Messages      []*model.TalkMessageRecord // 新消息
+	Events        []*model.TalkSyncEvent     // 变更事件
+}
+
+// Sync 增量同步，返回时序ID水位之后的新消息及变更事件
+func (s *TalkRecordService) Sync(ctx context.Context, opt *TalkSyncOpt) (*TalkSyncResult, error) {
+	private, err := s.syncPrivate(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TalkSyncResult{Private: private, Groups: make([]*TalkSyncItem, 0, len(opt.Groups))}
+
+	for groupId, cursor := range opt.Groups {
+		if !s.GroupMemberRepo.IsMember(ctx, groupId, opt.UserId, true) {
+			continue
+		}
+
+		item, err := s.syncGroup(ctx, opt.UserId, groupId, cursor, opt.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Groups = append(result.Groups, item)
+	}
+
+	return result, nil
+}
+
+func (s *TalkRecordService) syncPrivate(ctx context.Context, opt *TalkSyncOpt) (*TalkSyncItem, error) {
+	query := s.Source.Db().WithContext(ctx).Table("talk_user_message")
+	query.Select("to_from_id,msg_id,sequence,msg_type,from_id,is_revoked,extra,quote,send_time,delivery_status")
+	query.Where("user_id = ? and sequence > ? and is_deleted = ?", opt.UserId, opt.Sequence, model.No)
+	query.Order("sequence asc").Limit(opt.Limit)
+
+	var items []*model.TalkMessageRecord
+	if err := query.Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.TalkMode = entity.ChatPrivateMode
+	}
+
+	events, err := s.TalkSyncEventRepo.FindAllUserEvents(ctx, opt.UserId, opt.EventSequence, opt.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mergeSyncItem(ctx, &TalkSyncItem{Sequence: opt.Sequence, EventSequence: opt.EventSequence}, items, events, opt.Limit)
+}
+
+func (s *TalkRecordService) syncGroup(ctx context.Context, uid int, groupId int, cursor *TalkSyncCursor, limit int) (*TalkSyncItem, error) {
+	query := s.Source.Db().WithContext(ctx).Table("talk_group_message")
+	query.Select("msg_id,sequence,msg_type,from_id,is_revoked,extra,quote,thread_id,send_time")
+	// 话题回复一并同步并携带 thread_id，由客户端归入对应话题，否则回复会因水位前移而永久丢失
+	query.Where("group_id = ? and sequence > ?", groupId, cursor.Sequence)
+	query.Where("msg_id not in (?)", s.Source.Db().Model(&model.TalkGroupMessageDel{}).Select("msg_id").Where("group_id = ? and user_id = ?", groupId, uid))
+	query.Order("sequence asc").Limit(limit)
+
+	var items []*model.TalkMessageRecord
+	if err := query.Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		item.TalkMode = entity.ChatGroupMode
+		item.ToFromId = groupId
+	}
+
+	events, err := s.TalkSyncEventRepo.FindAllGroupEvents(ctx, groupId, cursor.EventSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mergeSyncItem(ctx, &TalkSyncItem{GroupId: groupId, Sequence: cursor.Sequence, EventSequence: cursor.EventSequence}, items, events, limit)
+}
+
+// 合并消息及事件并分别计算新的水位，任一结果集达到分页上限时标记还有未同步的数据
+func (s *TalkRecordService) mergeSyncItem(ctx context.Context, item *TalkSyncItem, messages []*model.TalkMessageRecord, events []*model.TalkSyncEvent, limit int) (*TalkSyncItem, error) {
+	item.HasMore = len(messages) == limit || len(events) == limit
+
+	item.Messages = messages
+	if len(messages) > 0 {
+		item.Sequence = max(item.Sequence, int64(messages[len(messages)-1].Sequence))
+	}
+
+	item.Events = events
+	if len(events) > 0 {
+		item.EventSequence = max(item.EventSequence, events[len(events)-1].Sequence)
+	}
+
+	messages, err := s.handleTalkRecords(ctx, item.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Messages = messages
+
+	return item, nil
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go-chat/internal/business"
+	"go-chat/internal/entity"
+	"go-chat/internal/pkg/jsonutil"
+	"go-chat/internal/pkg/logger"
+	"go-chat/internal/pkg/sliceutil"
+	"go-chat/internal/repository/model"
+	"go-chat/internal/repository/repo"
+	"go-chat/internal/service/message"
+	"gorm.io/gorm"
+)
+
+const talkUrgentMaxNotify = 10 // 未确认时的最大重复提醒次数
+
+var _ ITalkUrgentService = (*TalkUrgentService)(nil)
+
+type TalkUrgentAckItem struct {
+	UserId    int    `json:"user_id"`
+	IsAcked   int    `json:"is_acked"`
+	NotifyNum int    `json:"notify_num"`
+	AckedAt   string `json:"acked_at"`
+}
+
+type ITalkUrgentService interface {
+	Allow(ctx context.Context, uid int, talkMode int, toFromId int) error
+	Ack(ctx context.Context, uid int, msgId string) error
+	Acks(ctx context.Context, uid int, talkMode int, msgId string) ([]*TalkUrgentAckItem, error)
+	Dispatch(ctx context.Context) error
+}
+
+type TalkUrgentService struct {
+	*repo.Source
+	TalkUrgentRepo      *repo.TalkMessageUrgent
+	TalkUserMessageRepo *repo.TalkUserMessage
+	GroupMemberRepo     *repo.GroupMember
+	OrganizeRepo        *repo.Organize
+	DepartmentRepo      *repo.Department
+	PositionRepo        *repo.Position
+	PushMessage         *business.PushMessage
+}
+
+// Allow 判断用户是否有权限发送紧急消息
+//
+//	群聊: 群主或管理员
+//	私聊: 双方均为企业成员，且发送者部门是接收者部门的上级部门，或同部门且发送者岗位排序更靠前
+func (t *TalkUrgentService) Allow(ctx context.Context, uid int, talkMode int, toFromId int) error {
+	if talkMode == entity.ChatGroupMode {
+		if !t.GroupMemberRepo.IsLeader(ctx, toFromId, uid) {
+			return errors.New("仅群主或管理员可发送紧急消息")
+		}
+
+		return nil
+	}
+
+	if !t.isSuperior(ctx, uid, toFromId) {
+		return errors.New("暂无权限向对方发送紧急消息")
+	}
+
+	return nil
+}
+
+func (t *TalkUrgentService) isSuperior(ctx context.Context, uid int, toUid int) bool {
+	sender, err := t.OrganizeRepo.FindByWhere(ctx, "user_id = ?", uid)
+	if err != nil {
+		return false
+	}
+
+	receiver, err := t.OrganizeRepo.FindByWhere(ctx, "user_id = ?", toUid)
+	if err != nil {
+		return false
+	}
+
+	if sender.DeptId != receiver.DeptId {
+		dept, err := t.DepartmentRepo.FindById(ctx, receiver.DeptId)
+		if err != nil {
+			return false
+		}
+
+		return slices.Contains(sliceutil.ParseIds(dept.Ancestors), sender.DeptId)
+	}
+
+	senderPost, err := t.PositionRepo.FindById(ctx, sender.PositionId)
+	if err != nil {
+		return false
+	}
+
+	receiverPost, err := t.PositionRepo.FindById(ctx, receiver.PositionId)
+	if err != nil {
+		return false
+	}
+
+	return senderPost.Sort < receiverPost.Sort
+}
+
+// Ack 接收者确认紧急消息，确认后不再重复提醒
+func (t *TalkUrgentService) Ack(ctx context.Context, uid int, msgId string) error {
+	rows, err := t.TalkUrgentRepo.Ack(ctx, uid, msgId)
+	if err != nil {
+		return err
+	}
+
+	if rows > 0 {
+		return nil
+	}
+
+	ok, err := t.TalkUrgentRepo.IsExist(ctx, "user_id = ? and msg_id = ?", uid, msgId)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("紧急消息不存在")
+	}
+
+	return nil
+}
+
+// Acks 发送者查看紧急消息的确认情况
+func (t *TalkUrgentService) Acks(ctx context.Context, uid int, talkMode int, msgId string) ([]*TalkUrgentAckItem, error) {
+	orgMsgId := msgId
+
+	// 私聊发送者看到的消息ID与接收者不同，需通过原始消息ID关联
+	if talkMode == entity.ChatPrivateMode {
+		record, err := t.TalkUserMessageRepo.FindByWhere(ctx, "user_id = ? and msg_id = ?", uid, msgId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("消息不存在")
+			}
+
+			return nil, err
+		}
+
+		orgMsgId = record.OrgMsgId
+	}
+
+	list, err := t.TalkUrgentRepo.FindAll(ctx, func(db *gorm.DB) {
+		db.Where("org_msg_id = ? and from_id = ?", orgMsgId, uid).Order("id asc")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*TalkUrgentAckItem, 0, len(list))
+	for _, item := range list {
+		value := &TalkUrgentAckItem{
+			UserId:    item.UserId,
+			IsAcked:   item.IsAcked,
+			NotifyNum: item.NotifyNum,
+		}
+
+		if item.AckedAt != nil {
+			value.AckedAt = item.AckedAt.Format(time.DateTime)
+		}
+
+		items = append(items, value)
+	}
+
+	return items, nil
+}
+
+// Dispatch 对到期未确认的紧急消息重复提醒
+func (t *TalkUrgentService) Dispatch(ctx context.Context) error {
+	var (
+		now    = time.Now()
+		lastId = 0
+		size   = 200
+	)
+
+	for {
+		items, err := t.TalkUrgentRepo.FindAllDue(ctx, now, talkUrgentMaxNotify, lastId, size)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			t.notify(ctx, item)
+		}
+
+		if len(items) < size {
+			break
+		}
+
+		lastId = items[len(items)-1].Id
+	}
+
+	return nil
+}
+
+func (t *TalkUrgentService) notify(ctx context.Context, item *model.TalkMessageUrgent) {
+	// 以提醒次数作为版本号抢占，防止多个节点重复提醒
+	rows, err := t.TalkUrgentRepo.UpdateByWhere(ctx, map[string]any{
+		"notify_num":     item.NotifyNum + 1,
+		"next_notify_at": time.Now().Add(message.UrgentNotifyInterval),
+	}, "id = ? and notify_num = ? and is_acked = ?", item.Id, item.NotifyNum, model.No)
+	if err != nil {
+		logger.Errorf("urgent message %d notify error: %s", item.Id, err.Error())
+		return
+	}
+
+	if rows == 0 {
+		return
+	}
+
+	_ = t.PushMessage.PushUsers(ctx, []int{item.UserId}, &entity.SubscribeMessage{
+		Event: entity.SubEventImMessageUrgent,
+		Payload: jsonutil.Encode(entity.SubEventTalkUrgentPayload{
+			UserId:    item.UserId,
+			TalkMode:  item.TalkMode,
+			ToFromId:  item.ToFromId,
+			FromId:    item.FromId,
+			MsgId:     item.MsgId,
+			NotifyNum: item.NotifyNum + 1,
+		}),
+	})
+}
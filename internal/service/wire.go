@@ -75,6 +75,31 @@ var ProviderSet = wire.NewSet(
 	wire.Struct(new(RoomService), "*"),
 	wire.Bind(new(IRoomService), new(*RoomService)),
 
+	wire.Struct(new(TalkScheduleService), "*"),
+	wire.Bind(new(ITalkScheduleService), new(*TalkScheduleService)),
+
+	wire.Struct(new(TalkPinService), "*"),
+	wire.Bind(new(ITalkPinService), new(*TalkPinService)),
+
+	wire.Struct(new(TalkFavoriteService), "*"),
+	wire.Bind(new(ITalkFavoriteService), new(*TalkFavoriteService)),
+
+	wire.Struct(new(TalkMessageTtlService), "*"),
+	wire.Bind(new(ITalkMessageTtlService), new(*TalkMessageTtlService)),
+
+	wire.Struct(new(TalkMentionService), "*"),
+	wire.Bind(new(ITalkMentionService), new(*TalkMentionService)),
+	wire.Struct(new(TalkExportService), "*"),
+	wire.Bind(new(ITalkExportService), new(*TalkExportService)),
+	wire.Struct(new(TalkBroadcastService), "*"),
+	wire.Bind(new(ITalkBroadcastService), new(*TalkBroadcastService)),
+	wire.Struct(new(TalkQuickReplyService), "*"),
+	wire.Bind(new(ITalkQuickReplyService), new(*TalkQuickReplyService)),
+	wire.Struct(new(TalkUrgentService), "*"),
+	wire.Bind(new(ITalkUrgentService), new(*TalkUrgentService)),
+	wire.Struct(new(TalkLiveLocationService), "*"),
+	wire.Bind(new(ITalkLiveLocationService), new(*TalkLiveLocationService)),
+
 	wire.Struct(new(message.Service), "*"),
 	wire.Bind(new(message.IService), new(*message.Service)),
 )